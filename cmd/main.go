@@ -6,14 +6,19 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/mixdone/uptime-monitoring/internal/config"
 	"github.com/mixdone/uptime-monitoring/internal/database"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
 	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/repository"
 	"github.com/mixdone/uptime-monitoring/internal/services"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
 	"github.com/mixdone/uptime-monitoring/internal/transport"
 	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/mailer"
+	"github.com/mixdone/uptime-monitoring/pkg/message"
 )
 
 // @title Uptime Monitoring API
@@ -38,6 +43,19 @@ func main() {
 		panic("Failed to initialize logger: " + err.Error())
 	}
 
+	tracing, err := tracing.New(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.WithError(err).Error("Failed to initialize tracing")
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tracing.Shutdown(ctx); err != nil {
+			log.WithError(err).Error("Failed to flush traces")
+		}
+	}()
+
 	db, err := database.NewDB(cfg)
 	if err != nil {
 		log.WithError(err).Error("Failed db connection")
@@ -47,9 +65,57 @@ func main() {
 
 	log.Info("Connected to PostgreSQL")
 
-	repository := repository.NewRepository(db)
-	services := services.NewServices(repository, *cfg, log)
-	handlers := transport.NewHandler(services, log)
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		log.WithError(err).Error("Failed to load migrations")
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := runMigrate(context.Background(), migrator, os.Args[2:])
+		db.Close()
+		if err != nil {
+			log.WithError(err).Error("Migration failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cfg.DB.AutoMigrate {
+		if err := migrator.Up(context.Background()); err != nil {
+			log.WithError(err).Error("Failed to apply migrations")
+			return
+		}
+	}
+
+	mail, err := mailer.New(cfg, log)
+	if err != nil {
+		log.WithError(err).Error("Failed to initialize mailer")
+		return
+	}
+
+	mq := message.NewLocalMQ(log)
+
+	metrics := metrics.New(cfg.Metrics)
+	metrics.RegisterPool(db)
+	metrics.RegisterQueues(mq.Depths)
+
+	repository := repository.NewRepository(db, cfg)
+	services, err := services.NewServices(repository, *cfg, mail, mq, metrics, log)
+	if err != nil {
+		log.WithError(err).Error("Failed to initialize services")
+		return
+	}
+
+	handlers := transport.NewHandler(services, metrics, tracing, log)
+
+	background, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	go services.Audit.RunRetention(background)
+	go services.LoginGuard.RunRetention(background)
+	go services.Check.Run(background)
+	go services.Escalation.Run(background)
 
 	srv := new(models.ServerApi)
 
@@ -65,6 +131,8 @@ func main() {
 
 	log.Info("Shutdown signal received")
 
+	stopBackground()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
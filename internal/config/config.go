@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -16,22 +17,140 @@ type Config struct {
 		Password string
 		DBName   string `mapstructure:"dbname"`
 		SSLMode  string `mapstructure:"sslmode"`
+		// AutoMigrate applies pending migrations on startup
+		AutoMigrate bool `mapstructure:"auto_migrate"`
 	} `mapstructure:"db"`
 
 	Log struct {
 		Level  string `mapstructure:"level"`
 		Format string `mapstructure:"format"`
+		// Backend is "logrus" or "slog"
+		Backend string `mapstructure:"backend"`
+		// RedactFields are masked in every entry, as are fields whose
+		// name contains one of them
+		RedactFields []string `mapstructure:"redact_fields"`
 	} `mapstructure:"log"`
 
 	Server struct {
-		Host string `mapstructure:"host"`
-		Port string `mapstructure:"port"`
+		Host      string `mapstructure:"host"`
+		Port      string `mapstructure:"port"`
+		PublicURL string `mapstructure:"public_url"`
 	} `mapstructure:"server"`
 
-	Jwt struct {
-		AccessSecret  string
-		RefreshSecret string
-	}
+	Mail struct {
+		Driver string `mapstructure:"driver"`
+		From   string `mapstructure:"from"`
+		Dir    string `mapstructure:"dir"`
+		SMTP   struct {
+			Host     string `mapstructure:"host"`
+			Port     string `mapstructure:"port"`
+			Username string `mapstructure:"username"`
+			Password string
+		} `mapstructure:"smtp"`
+	} `mapstructure:"mail"`
+
+	Jwt JWT `mapstructure:"jwt"`
+
+	Auth struct {
+		LoginThrottle LoginThrottle `mapstructure:"login_throttle"`
+	} `mapstructure:"auth"`
+
+	OIDC OIDC `mapstructure:"oidc"`
+
+	Checks struct {
+		// Workers is the number of checks running at the same time
+		Workers      int           `mapstructure:"workers"`
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+	} `mapstructure:"checks"`
+
+	Telegram struct {
+		BotToken string
+		// APIURL replaces the public Bot API, e.g. with a local bot server
+		APIURL string `mapstructure:"api_url"`
+	} `mapstructure:"telegram"`
+
+	Escalation struct {
+		// PollInterval is how often due escalation levels are looked up
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+	} `mapstructure:"escalation"`
+
+	Audit struct {
+		// Retention is how long audit entries are kept, zero keeps them forever
+		Retention time.Duration `mapstructure:"retention"`
+	} `mapstructure:"audit"`
+
+	Metrics Metrics `mapstructure:"metrics"`
+
+	Tracing Tracing `mapstructure:"tracing"`
+}
+
+// Metrics configures the Prometheus endpoint. Per-monitor gauges add a
+// series per monitor and metric; MaxMonitors caps how many monitors get
+// them (zero means no cap) and MonitorNameLabel adds the monitor name as a
+// label next to its ID.
+type Metrics struct {
+	Enabled          bool   `mapstructure:"enabled"`
+	Path             string `mapstructure:"path"`
+	MonitorGauges    bool   `mapstructure:"monitor_gauges"`
+	MaxMonitors      int    `mapstructure:"max_monitors"`
+	MonitorNameLabel bool   `mapstructure:"monitor_name_label"`
+}
+
+// Tracing exports OpenTelemetry spans, either over OTLP/HTTP to Endpoint or
+// as JSON on stdout for local debugging. SampleRatio applies to new traces
+// only; requests carrying a sampled parent stay sampled.
+type Tracing struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	ServiceName string  `mapstructure:"service_name"`
+}
+
+// JWT selects how tokens are signed. HS256 uses the two secrets, RS256 and
+// EdDSA use the key files in Keys. Keys other than the signing one only
+// verify tokens, which lets a rotated-out key stay until its tokens expire.
+type JWT struct {
+	AccessSecret  string
+	RefreshSecret string
+	Algorithm     string   `mapstructure:"algorithm"`
+	SigningKeyID  string   `mapstructure:"signing_key_id"`
+	Keys          []JWTKey `mapstructure:"keys"`
+}
+
+type JWTKey struct {
+	ID             string `mapstructure:"id"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+// OIDC configures single sign-on through an OpenID Connect provider. With
+// AutoProvision, identities that match no local user get a new account;
+// otherwise they have to be linked through a verified email first.
+type OIDC struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	IssuerURL     string `mapstructure:"issuer_url"`
+	ClientID      string `mapstructure:"client_id"`
+	ClientSecret  string
+	RedirectURL   string   `mapstructure:"redirect_url"`
+	Scopes        []string `mapstructure:"scopes"`
+	AutoProvision bool     `mapstructure:"auto_provision"`
+}
+
+// LoginThrottle configures brute-force protection of the login endpoint.
+// Failures beyond the free attempts double the delay before the next try,
+// and reaching the lockout threshold blocks the username or IP entirely.
+type LoginThrottle struct {
+	Store              string        `mapstructure:"store"`
+	Window             time.Duration `mapstructure:"window"`
+	FreeAttempts       int           `mapstructure:"free_attempts"`
+	BaseDelay          time.Duration `mapstructure:"base_delay"`
+	MaxDelay           time.Duration `mapstructure:"max_delay"`
+	LockoutThreshold   int           `mapstructure:"lockout_threshold"`
+	LockoutDuration    time.Duration `mapstructure:"lockout_duration"`
+	IPFreeAttempts     int           `mapstructure:"ip_free_attempts"`
+	IPLockoutThreshold int           `mapstructure:"ip_lockout_threshold"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -42,7 +161,47 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "json")
+	viper.SetDefault("log.backend", "logrus")
+	viper.SetDefault("log.redact_fields", []string{"password", "token", "authorization", "fingerprint", "secret", "username", "email"})
 	viper.SetDefault("db.sslmode", "disable")
+	viper.SetDefault("db.auto_migrate", true)
+	viper.SetDefault("server.public_url", "http://localhost:8080")
+
+	viper.SetDefault("jwt.algorithm", "HS256")
+
+	viper.SetDefault("oidc.scopes", []string{"email", "profile"})
+
+	viper.SetDefault("audit.retention", "2160h")
+
+	viper.SetDefault("checks.workers", 8)
+	viper.SetDefault("checks.poll_interval", "5s")
+
+	viper.SetDefault("escalation.poll_interval", "10s")
+
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("metrics.monitor_gauges", true)
+	viper.SetDefault("metrics.max_monitors", 1000)
+
+	viper.SetDefault("tracing.exporter", "otlp")
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("tracing.service_name", "uptime-monitoring")
+
+	viper.SetDefault("mail.driver", "log")
+	viper.SetDefault("mail.from", "uptime-monitoring@localhost")
+	viper.SetDefault("mail.dir", "mail")
+	viper.SetDefault("mail.smtp.port", "587")
+
+	viper.SetDefault("auth.login_throttle.store", "memory")
+	viper.SetDefault("auth.login_throttle.window", "1h")
+	viper.SetDefault("auth.login_throttle.free_attempts", 3)
+	viper.SetDefault("auth.login_throttle.base_delay", "1s")
+	viper.SetDefault("auth.login_throttle.max_delay", "5m")
+	viper.SetDefault("auth.login_throttle.lockout_threshold", 10)
+	viper.SetDefault("auth.login_throttle.lockout_duration", "15m")
+	viper.SetDefault("auth.login_throttle.ip_free_attempts", 20)
+	viper.SetDefault("auth.login_throttle.ip_lockout_threshold", 100)
 
 	viper.SetEnvPrefix("UPTIME")
 	viper.AutomaticEnv()
@@ -64,13 +223,48 @@ func LoadConfig() (*Config, error) {
 	}
 
 	cfg.Jwt.AccessSecret = viper.GetString("jwt.accesssecret")
-	if cfg.Jwt.AccessSecret == "" {
-		return nil, errors.New("password not set in UPTIME_JWT_ACCESSSECRET")
+	cfg.Jwt.RefreshSecret = viper.GetString("jwt.refreshsecret")
+
+	switch cfg.Jwt.Algorithm {
+	case "HS256":
+		if cfg.Jwt.AccessSecret == "" {
+			return nil, errors.New("password not set in UPTIME_JWT_ACCESSSECRET")
+		}
+		if cfg.Jwt.RefreshSecret == "" {
+			return nil, errors.New("password not set in UPTIME_JWT_REFRESHSECRET")
+		}
+	case "RS256", "EdDSA":
+		if cfg.Jwt.SigningKeyID == "" {
+			return nil, errors.New("jwt.signing_key_id is required for " + cfg.Jwt.Algorithm)
+		}
+	default:
+		return nil, fmt.Errorf("unknown jwt algorithm %q", cfg.Jwt.Algorithm)
 	}
 
-	cfg.Jwt.RefreshSecret = viper.GetString("jwt.refreshsecret")
-	if cfg.Jwt.RefreshSecret == "" {
-		return nil, errors.New("password not set in UPTIME_JWT_REFRESHSECRET")
+	cfg.Mail.SMTP.Password = viper.GetString("mail.smtp.password")
+	cfg.OIDC.ClientSecret = viper.GetString("oidc.client_secret")
+	cfg.Telegram.BotToken = viper.GetString("telegram.bot_token")
+
+	if cfg.OIDC.Enabled && (cfg.OIDC.IssuerURL == "" || cfg.OIDC.ClientID == "" || cfg.OIDC.RedirectURL == "") {
+		return nil, errors.New("oidc requires issuer_url, client_id and redirect_url")
+	}
+
+	switch cfg.Auth.LoginThrottle.Store {
+	case "memory", "postgres":
+	default:
+		return nil, fmt.Errorf("unknown login throttle store %q", cfg.Auth.LoginThrottle.Store)
+	}
+
+	switch cfg.Log.Backend {
+	case "logrus", "slog":
+	default:
+		return nil, fmt.Errorf("unknown log backend %q", cfg.Log.Backend)
+	}
+
+	switch cfg.Tracing.Exporter {
+	case "otlp", "stdout":
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Tracing.Exporter)
 	}
 
 	return &cfg, nil
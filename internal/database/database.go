@@ -19,8 +19,13 @@ func NewDB(cfg *config.Config) (*pgxpool.Pool, error) {
 		cfg.DB.SSLMode,
 	)
 
-	pool, err := pgxpool.New(context.Background(), conn)
+	poolConfig, err := pgxpool.ParseConfig(conn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection settings: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = queryTracer{}
 
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
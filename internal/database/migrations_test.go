@@ -0,0 +1,68 @@
+package database_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/database"
+	"github.com/mixdone/uptime-monitoring/schema"
+)
+
+func file(sql string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(sql)}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_monitors.up.sql":   file("CREATE TABLE monitors ();"),
+		"000002_monitors.down.sql": file("DROP TABLE monitors;"),
+		"000001_init.up.sql":       file("CREATE TABLE users ();"),
+		"000001_init.down.sql":     file("DROP TABLE users;"),
+		"embed.go":                 file("package schema"),
+	}
+
+	migrations, err := database.LoadMigrations(fsys)
+	require.NoError(t, err)
+
+	assert.Equal(t, []database.Migration{
+		{Version: 1, Name: "init", Up: "CREATE TABLE users ();", Down: "DROP TABLE users;"},
+		{Version: 2, Name: "monitors", Up: "CREATE TABLE monitors ();", Down: "DROP TABLE monitors;"},
+	}, migrations)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"000001_init.up.sql": file("CREATE TABLE users ();"),
+		},
+		"names differ": {
+			"000001_init.up.sql":    file("CREATE TABLE users ();"),
+			"000001_users.down.sql": file("DROP TABLE users;"),
+		},
+		"version zero": {
+			"000000_init.up.sql":   file("CREATE TABLE users ();"),
+			"000000_init.down.sql": file("DROP TABLE users;"),
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := database.LoadMigrations(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := database.LoadMigrations(schema.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.Equal(t, int64(i+1), m.Version, "migrations are numbered without gaps")
+	}
+	assert.Equal(t, migrations[len(migrations)-1].Version, database.SchemaVersion())
+}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+// migrationLockID keys the advisory lock that keeps replicas starting at
+// the same time from migrating concurrently.
+const migrationLockID int64 = 0x7570_7469_6d65
+
+// The table has the layout the migrate tool uses, so databases it migrated
+// carry on where it left them.
+const createMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT NOT NULL PRIMARY KEY,
+    dirty BOOLEAN NOT NULL
+)`
+
+var ErrDirtySchema = errors.New("a previous migration failed halfway, fix the schema and the schema_migrations row by hand")
+
+// MigrationStatus compares the database to the embedded migrations.
+type MigrationStatus struct {
+	Version int64
+	Dirty   bool
+	Latest  int64
+	Pending []Migration
+}
+
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []Migration
+	logger     logger.Logger
+}
+
+// NewMigrator works with the migrations embedded in the binary.
+func NewMigrator(pool *pgxpool.Pool, log logger.Logger) (*Migrator, error) {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded migrations: %w", err)
+	}
+
+	return &Migrator{
+		db:         pool,
+		migrations: migrations,
+		logger:     log.WithField("component", "migrator"),
+	}, nil
+}
+
+// Up applies every pending migration, each in a transaction of its own.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *pgxpool.Conn, version int64) error {
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			m.logger.Infof("Applied migration %d_%s", migration.Version, migration.Name)
+		}
+		return nil
+	})
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.locked(ctx, func(conn *pgxpool.Conn, version int64) error {
+		if latest := latestVersion(m.migrations); version > latest {
+			return fmt.Errorf("schema version %d is newer than the migrations in this binary, which end at %d",
+				version, latest)
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+
+			var previous int64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			if err := m.apply(ctx, conn, migration.Down, previous); err != nil {
+				return fmt.Errorf("reverting migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			m.logger.Infof("Reverted migration %d_%s", migration.Version, migration.Name)
+			steps--
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	if _, err := m.db.Exec(ctx, createMigrationsTable); err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := currentVersion(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MigrationStatus{Version: version, Dirty: dirty, Latest: latestVersion(m.migrations)}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// locked runs fn on a connection holding the migration lock, with the
+// version the database is at once the lock is taken.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn, version int64) error) (err error) {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer func() {
+		// The lock belongs to the session, so a connection that can't
+		// release it must not go back to the pool.
+		_, unlockErr := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+		if unlockErr != nil {
+			conn.Conn().Close(context.Background())
+			if err == nil {
+				err = unlockErr
+			}
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createMigrationsTable); err != nil {
+		return err
+	}
+
+	version, dirty, err := currentVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema version %d: %w", version, ErrDirtySchema)
+	}
+
+	return fn(conn, version)
+}
+
+// apply runs sql and records the version the database is at afterwards,
+// zero meaning no migration is applied.
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, sql string, version int64) (err error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if version > 0 {
+		_, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version)
+	}
+	return err
+}
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func currentVersion(ctx context.Context, db querier) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
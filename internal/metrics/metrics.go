@@ -0,0 +1,231 @@
+// Package metrics collects what the service exposes to Prometheus on
+// /metrics: HTTP traffic, the database pool, the check queue and scheduler,
+// and the latest state of each monitor.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "uptime"
+
+// UnmatchedRoute labels requests that matched no route, so scanners probing
+// random paths can't create new series.
+const UnmatchedRoute = "unmatched"
+
+type Metrics struct {
+	registry *prometheus.Registry
+	path     string
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	schedulerLag prometheus.Histogram
+	checks       *prometheus.CounterVec
+
+	monitorUp      *prometheus.GaugeVec
+	monitorLatency *prometheus.GaugeVec
+	monitorCert    *prometheus.GaugeVec
+
+	monitorGauges bool
+	maxMonitors   int
+	nameLabel     bool
+
+	mu       sync.Mutex
+	monitors map[int64]string
+}
+
+// New registers the collectors in a registry of their own, so nothing
+// registered globally by a library ends up on /metrics by accident.
+func New(cfg config.Metrics) *Metrics {
+	monitorLabels := []string{"monitor_id"}
+	if cfg.MonitorNameLabel {
+		monitorLabels = append(monitorLabels, "monitor_name")
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+
+		schedulerLag: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "scheduler",
+			Name:      "lag_seconds",
+			Help:      "How long after it became due a monitor was queued for a check.",
+			Buckets:   []float64{.1, .5, 1, 2.5, 5, 10, 30, 60, 300},
+		}),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "checks",
+			Name:      "executed_total",
+			Help:      "Checks executed by result status.",
+		}, []string{"status"}),
+
+		monitorUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "monitor",
+			Name:      "up",
+			Help:      "Whether the latest check of the monitor succeeded.",
+		}, monitorLabels),
+		monitorLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "monitor",
+			Name:      "response_time_seconds",
+			Help:      "Response time of the latest check of the monitor.",
+		}, monitorLabels),
+		monitorCert: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "monitor",
+			Name:      "certificate_expiry_days",
+			Help:      "Days until the TLS certificate of the monitor target expires.",
+		}, monitorLabels),
+
+		monitorGauges: cfg.MonitorGauges,
+		maxMonitors:   cfg.MaxMonitors,
+		nameLabel:     cfg.MonitorNameLabel,
+		monitors:      make(map[int64]string),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.schedulerLag, m.checks,
+	)
+	if m.monitorGauges {
+		m.registry.MustRegister(m.monitorUp, m.monitorLatency, m.monitorCert)
+	}
+	if cfg.Enabled {
+		m.path = cfg.Path
+	}
+
+	return m
+}
+
+// Path is where the metrics are served, or empty if they aren't.
+func (m *Metrics) Path() string {
+	return m.path
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterPool exports the statistics of the database pool.
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+// RegisterQueues exports the number of messages waiting in each queue.
+func (m *Metrics) RegisterQueues(depths func() map[string]int) {
+	m.registry.MustRegister(newQueueCollector(depths))
+}
+
+// ObserveRequest counts a finished HTTP request. Route must be the route
+// pattern, not the path, to keep the number of series bounded.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveDispatch records how late a monitor was queued.
+func (m *Metrics) ObserveDispatch(lag time.Duration) {
+	m.schedulerLag.Observe(max(lag, 0).Seconds())
+}
+
+// ObserveCheck counts a check and updates the gauges of its monitor. Only
+// the first MaxMonitors monitors seen get gauges; later ones are counted in
+// the totals only.
+func (m *Metrics) ObserveCheck(monitor models.Monitor, result models.CheckResult) {
+	m.checks.WithLabelValues(result.Status).Inc()
+
+	labels, ok := m.monitorLabels(monitor)
+	if !ok {
+		return
+	}
+
+	up := 0.0
+	if result.Status == models.CheckUp {
+		up = 1
+	}
+	m.monitorUp.WithLabelValues(labels...).Set(up)
+	m.monitorLatency.WithLabelValues(labels...).Set(float64(result.ResponseTimeMs) / 1000)
+
+	if result.CertificateExpiresAt != nil {
+		days := time.Until(*result.CertificateExpiresAt).Hours() / 24
+		m.monitorCert.WithLabelValues(labels...).Set(days)
+	}
+}
+
+// ForgetMonitor drops the gauges of a deleted monitor and frees its slot.
+func (m *Metrics) ForgetMonitor(id int64) {
+	m.mu.Lock()
+	name, ok := m.monitors[id]
+	delete(m.monitors, id)
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	labels := m.labelValues(id, name)
+	m.monitorUp.DeleteLabelValues(labels...)
+	m.monitorLatency.DeleteLabelValues(labels...)
+	m.monitorCert.DeleteLabelValues(labels...)
+}
+
+func (m *Metrics) monitorLabels(monitor models.Monitor) ([]string, bool) {
+	if !m.monitorGauges {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	name, tracked := m.monitors[monitor.ID]
+	if !tracked && (m.maxMonitors <= 0 || len(m.monitors) < m.maxMonitors) {
+		name, tracked = monitor.Name, true
+		m.monitors[monitor.ID] = name
+	}
+	m.mu.Unlock()
+
+	if !tracked {
+		return nil, false
+	}
+
+	// a renamed monitor keeps the name it was first seen with until restart,
+	// so its series don't split in two
+	return m.labelValues(monitor.ID, name), true
+}
+
+func (m *Metrics) labelValues(id int64, name string) []string {
+	labels := []string{strconv.FormatInt(id, 10)}
+	if m.nameLabel {
+		labels = append(labels, name)
+	}
+	return labels
+}
@@ -0,0 +1,78 @@
+package metrics_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	return rec.Body.String()
+}
+
+func TestObserveRequest(t *testing.T) {
+	m := metrics.New(config.Metrics{Enabled: true, Path: "/metrics"})
+
+	m.ObserveRequest("GET", "/monitors/:id", 200, 20*time.Millisecond)
+	m.ObserveRequest("GET", "", 404, time.Millisecond)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `uptime_http_requests_total{method="GET",route="/monitors/:id",status="200"} 1`)
+	assert.Contains(t, body, `uptime_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{method="GET",route="/monitors/:id"} 1`)
+	assert.Equal(t, "/metrics", m.Path())
+}
+
+func TestObserveCheck_MonitorLimit(t *testing.T) {
+	m := metrics.New(config.Metrics{MonitorGauges: true, MaxMonitors: 1})
+	expires := time.Now().Add(30*24*time.Hour + time.Hour)
+
+	m.ObserveCheck(models.Monitor{ID: 1}, models.CheckResult{
+		Status: models.CheckUp, ResponseTimeMs: 250, CertificateExpiresAt: &expires,
+	})
+	m.ObserveCheck(models.Monitor{ID: 2}, models.CheckResult{Status: models.CheckDown})
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `uptime_monitor_up{monitor_id="1"} 1`)
+	assert.Contains(t, body, `uptime_monitor_response_time_seconds{monitor_id="1"} 0.25`)
+	assert.Contains(t, body, `uptime_monitor_certificate_expiry_days{monitor_id="1"} 30.04`)
+	assert.NotContains(t, body, `monitor_id="2"`)
+	assert.Contains(t, body, `uptime_checks_executed_total{status="down"} 1`)
+
+	m.ForgetMonitor(1)
+	m.ObserveCheck(models.Monitor{ID: 2}, models.CheckResult{Status: models.CheckDown})
+
+	body = scrape(t, m)
+	assert.NotContains(t, body, `monitor_id="1"`)
+	assert.Contains(t, body, `uptime_monitor_up{monitor_id="2"} 0`)
+}
+
+func TestObserveCheck_NameLabel(t *testing.T) {
+	m := metrics.New(config.Metrics{MonitorGauges: true, MonitorNameLabel: true})
+
+	m.ObserveCheck(models.Monitor{ID: 1, Name: "API"}, models.CheckResult{Status: models.CheckUp})
+
+	assert.Contains(t, scrape(t, m), `uptime_monitor_up{monitor_id="1",monitor_name="API"} 1`)
+}
+
+func TestRegisterQueues(t *testing.T) {
+	m := metrics.New(config.Metrics{})
+	m.RegisterQueues(func() map[string]int { return map[string]int{"monitor.checks": 3} })
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `uptime_mq_queue_depth{queue="monitor.checks"} 3`)
+	assert.NotContains(t, body, "uptime_monitor_up")
+	assert.Empty(t, m.Path())
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: AnnouncementRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockAnnouncementRepository is a mock of AnnouncementRepository interface.
+type MockAnnouncementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnnouncementRepositoryMockRecorder
+}
+
+// MockAnnouncementRepositoryMockRecorder is the mock recorder for MockAnnouncementRepository.
+type MockAnnouncementRepositoryMockRecorder struct {
+	mock *MockAnnouncementRepository
+}
+
+// NewMockAnnouncementRepository creates a new mock instance.
+func NewMockAnnouncementRepository(ctrl *gomock.Controller) *MockAnnouncementRepository {
+	mock := &MockAnnouncementRepository{ctrl: ctrl}
+	mock.recorder = &MockAnnouncementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnnouncementRepository) EXPECT() *MockAnnouncementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddUpdate mocks base method.
+func (m *MockAnnouncementRepository) AddUpdate(arg0 context.Context, arg1 models.AnnouncementUpdate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUpdate", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUpdate indicates an expected call of AddUpdate.
+func (mr *MockAnnouncementRepositoryMockRecorder) AddUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdate", reflect.TypeOf((*MockAnnouncementRepository)(nil).AddUpdate), arg0, arg1)
+}
+
+// CreateAnnouncement mocks base method.
+func (m *MockAnnouncementRepository) CreateAnnouncement(arg0 context.Context, arg1 models.Announcement) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnnouncement", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnnouncement indicates an expected call of CreateAnnouncement.
+func (mr *MockAnnouncementRepositoryMockRecorder) CreateAnnouncement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnnouncement", reflect.TypeOf((*MockAnnouncementRepository)(nil).CreateAnnouncement), arg0, arg1)
+}
+
+// DeleteAnnouncement mocks base method.
+func (m *MockAnnouncementRepository) DeleteAnnouncement(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnnouncement", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAnnouncement indicates an expected call of DeleteAnnouncement.
+func (mr *MockAnnouncementRepositoryMockRecorder) DeleteAnnouncement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnnouncement", reflect.TypeOf((*MockAnnouncementRepository)(nil).DeleteAnnouncement), arg0, arg1)
+}
+
+// GetAnnouncement mocks base method.
+func (m *MockAnnouncementRepository) GetAnnouncement(arg0 context.Context, arg1 int64) (*models.Announcement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnnouncement", arg0, arg1)
+	ret0, _ := ret[0].(*models.Announcement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnnouncement indicates an expected call of GetAnnouncement.
+func (mr *MockAnnouncementRepositoryMockRecorder) GetAnnouncement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnnouncement", reflect.TypeOf((*MockAnnouncementRepository)(nil).GetAnnouncement), arg0, arg1)
+}
+
+// GetPageAnnouncements mocks base method.
+func (m *MockAnnouncementRepository) GetPageAnnouncements(arg0 context.Context, arg1 int64, arg2 time.Time) ([]models.Announcement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageAnnouncements", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Announcement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageAnnouncements indicates an expected call of GetPageAnnouncements.
+func (mr *MockAnnouncementRepositoryMockRecorder) GetPageAnnouncements(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageAnnouncements", reflect.TypeOf((*MockAnnouncementRepository)(nil).GetPageAnnouncements), arg0, arg1, arg2)
+}
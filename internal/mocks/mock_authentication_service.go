@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/services/auth (interfaces: AuthenticationService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/mixdone/uptime-monitoring/internal/models/dto"
+)
+
+// MockAuthenticationService is a mock of AuthenticationService interface.
+type MockAuthenticationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticationServiceMockRecorder
+}
+
+// MockAuthenticationServiceMockRecorder is the mock recorder for MockAuthenticationService.
+type MockAuthenticationServiceMockRecorder struct {
+	mock *MockAuthenticationService
+}
+
+// NewMockAuthenticationService creates a new mock instance.
+func NewMockAuthenticationService(ctrl *gomock.Controller) *MockAuthenticationService {
+	mock := &MockAuthenticationService{ctrl: ctrl}
+	mock.recorder = &MockAuthenticationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthenticationService) EXPECT() *MockAuthenticationServiceMockRecorder {
+	return m.recorder
+}
+
+// CompleteOIDCLogin mocks base method.
+func (m *MockAuthenticationService) CompleteOIDCLogin(arg0 context.Context, arg1 dto.OIDCCallbackRequest) (*dto.AuthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOIDCLogin", arg0, arg1)
+	ret0, _ := ret[0].(*dto.AuthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOIDCLogin indicates an expected call of CompleteOIDCLogin.
+func (mr *MockAuthenticationServiceMockRecorder) CompleteOIDCLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOIDCLogin", reflect.TypeOf((*MockAuthenticationService)(nil).CompleteOIDCLogin), arg0, arg1)
+}
+
+// CompleteTwoFactorLogin mocks base method.
+func (m *MockAuthenticationService) CompleteTwoFactorLogin(arg0 context.Context, arg1 dto.TwoFactorLoginRequest) (*dto.AuthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteTwoFactorLogin", arg0, arg1)
+	ret0, _ := ret[0].(*dto.AuthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteTwoFactorLogin indicates an expected call of CompleteTwoFactorLogin.
+func (mr *MockAuthenticationServiceMockRecorder) CompleteTwoFactorLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTwoFactorLogin", reflect.TypeOf((*MockAuthenticationService)(nil).CompleteTwoFactorLogin), arg0, arg1)
+}
+
+// Login mocks base method.
+func (m *MockAuthenticationService) Login(arg0 context.Context, arg1 dto.LoginRequest) (*dto.AuthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", arg0, arg1)
+	ret0, _ := ret[0].(*dto.AuthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthenticationServiceMockRecorder) Login(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthenticationService)(nil).Login), arg0, arg1)
+}
+
+// Logout mocks base method.
+func (m *MockAuthenticationService) Logout(arg0 context.Context, arg1 int64, arg2 dto.LogoutRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthenticationServiceMockRecorder) Logout(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthenticationService)(nil).Logout), arg0, arg1, arg2)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthenticationService) LogoutAll(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthenticationServiceMockRecorder) LogoutAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthenticationService)(nil).LogoutAll), arg0, arg1)
+}
+
+// RefreshTokens mocks base method.
+func (m *MockAuthenticationService) RefreshTokens(arg0 context.Context, arg1 int64, arg2 dto.RefreshRequest) (*dto.AuthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokens", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.AuthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshTokens indicates an expected call of RefreshTokens.
+func (mr *MockAuthenticationServiceMockRecorder) RefreshTokens(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockAuthenticationService)(nil).RefreshTokens), arg0, arg1, arg2)
+}
+
+// Register mocks base method.
+func (m *MockAuthenticationService) Register(arg0 context.Context, arg1 dto.RegisterRequest) (*dto.AuthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", arg0, arg1)
+	ret0, _ := ret[0].(*dto.AuthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockAuthenticationServiceMockRecorder) Register(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthenticationService)(nil).Register), arg0, arg1)
+}
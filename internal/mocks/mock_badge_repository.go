@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: BadgeRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockBadgeRepository is a mock of BadgeRepository interface.
+type MockBadgeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBadgeRepositoryMockRecorder
+}
+
+// MockBadgeRepositoryMockRecorder is the mock recorder for MockBadgeRepository.
+type MockBadgeRepositoryMockRecorder struct {
+	mock *MockBadgeRepository
+}
+
+// NewMockBadgeRepository creates a new mock instance.
+func NewMockBadgeRepository(ctrl *gomock.Controller) *MockBadgeRepository {
+	mock := &MockBadgeRepository{ctrl: ctrl}
+	mock.recorder = &MockBadgeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBadgeRepository) EXPECT() *MockBadgeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBadge mocks base method.
+func (m *MockBadgeRepository) DeleteBadge(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBadge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBadge indicates an expected call of DeleteBadge.
+func (mr *MockBadgeRepositoryMockRecorder) DeleteBadge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBadge", reflect.TypeOf((*MockBadgeRepository)(nil).DeleteBadge), arg0, arg1)
+}
+
+// GetBadge mocks base method.
+func (m *MockBadgeRepository) GetBadge(arg0 context.Context, arg1 int64) (*models.Badge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBadge", arg0, arg1)
+	ret0, _ := ret[0].(*models.Badge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBadge indicates an expected call of GetBadge.
+func (mr *MockBadgeRepositoryMockRecorder) GetBadge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBadge", reflect.TypeOf((*MockBadgeRepository)(nil).GetBadge), arg0, arg1)
+}
+
+// GetBadgeByToken mocks base method.
+func (m *MockBadgeRepository) GetBadgeByToken(arg0 context.Context, arg1 string) (*models.Badge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBadgeByToken", arg0, arg1)
+	ret0, _ := ret[0].(*models.Badge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBadgeByToken indicates an expected call of GetBadgeByToken.
+func (mr *MockBadgeRepositoryMockRecorder) GetBadgeByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBadgeByToken", reflect.TypeOf((*MockBadgeRepository)(nil).GetBadgeByToken), arg0, arg1)
+}
+
+// SaveBadge mocks base method.
+func (m *MockBadgeRepository) SaveBadge(arg0 context.Context, arg1 models.Badge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBadge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBadge indicates an expected call of SaveBadge.
+func (mr *MockBadgeRepositoryMockRecorder) SaveBadge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBadge", reflect.TypeOf((*MockBadgeRepository)(nil).SaveBadge), arg0, arg1)
+}
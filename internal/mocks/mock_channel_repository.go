@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: ChannelRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockChannelRepository is a mock of ChannelRepository interface.
+type MockChannelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelRepositoryMockRecorder
+}
+
+// MockChannelRepositoryMockRecorder is the mock recorder for MockChannelRepository.
+type MockChannelRepositoryMockRecorder struct {
+	mock *MockChannelRepository
+}
+
+// NewMockChannelRepository creates a new mock instance.
+func NewMockChannelRepository(ctrl *gomock.Controller) *MockChannelRepository {
+	mock := &MockChannelRepository{ctrl: ctrl}
+	mock.recorder = &MockChannelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelRepository) EXPECT() *MockChannelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateChannel mocks base method.
+func (m *MockChannelRepository) CreateChannel(arg0 context.Context, arg1 models.NotificationChannel) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannel", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChannel indicates an expected call of CreateChannel.
+func (mr *MockChannelRepositoryMockRecorder) CreateChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockChannelRepository)(nil).CreateChannel), arg0, arg1)
+}
+
+// DeleteChannel mocks base method.
+func (m *MockChannelRepository) DeleteChannel(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannel indicates an expected call of DeleteChannel.
+func (mr *MockChannelRepositoryMockRecorder) DeleteChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockChannelRepository)(nil).DeleteChannel), arg0, arg1)
+}
+
+// GetChannel mocks base method.
+func (m *MockChannelRepository) GetChannel(arg0 context.Context, arg1 int64) (*models.NotificationChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
+	ret0, _ := ret[0].(*models.NotificationChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockChannelRepositoryMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockChannelRepository)(nil).GetChannel), arg0, arg1)
+}
+
+// GetChannels mocks base method.
+func (m *MockChannelRepository) GetChannels(arg0 context.Context, arg1 int64, arg2 []int64) ([]models.NotificationChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.NotificationChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockChannelRepositoryMockRecorder) GetChannels(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockChannelRepository)(nil).GetChannels), arg0, arg1, arg2)
+}
+
+// GetOrganizationChannels mocks base method.
+func (m *MockChannelRepository) GetOrganizationChannels(arg0 context.Context, arg1 int64) ([]models.NotificationChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationChannels", arg0, arg1)
+	ret0, _ := ret[0].([]models.NotificationChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationChannels indicates an expected call of GetOrganizationChannels.
+func (mr *MockChannelRepositoryMockRecorder) GetOrganizationChannels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationChannels", reflect.TypeOf((*MockChannelRepository)(nil).GetOrganizationChannels), arg0, arg1)
+}
+
+// UpdateChannel mocks base method.
+func (m *MockChannelRepository) UpdateChannel(arg0 context.Context, arg1 models.NotificationChannel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChannel indicates an expected call of UpdateChannel.
+func (mr *MockChannelRepositoryMockRecorder) UpdateChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannel", reflect.TypeOf((*MockChannelRepository)(nil).UpdateChannel), arg0, arg1)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: CheckRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockCheckRepository is a mock of CheckRepository interface.
+type MockCheckRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckRepositoryMockRecorder
+}
+
+// MockCheckRepositoryMockRecorder is the mock recorder for MockCheckRepository.
+type MockCheckRepositoryMockRecorder struct {
+	mock *MockCheckRepository
+}
+
+// NewMockCheckRepository creates a new mock instance.
+func NewMockCheckRepository(ctrl *gomock.Controller) *MockCheckRepository {
+	mock := &MockCheckRepository{ctrl: ctrl}
+	mock.recorder = &MockCheckRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckRepository) EXPECT() *MockCheckRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountStateChanges mocks base method.
+func (m *MockCheckRepository) CountStateChanges(arg0 context.Context, arg1 int64, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStateChanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStateChanges indicates an expected call of CountStateChanges.
+func (mr *MockCheckRepositoryMockRecorder) CountStateChanges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStateChanges", reflect.TypeOf((*MockCheckRepository)(nil).CountStateChanges), arg0, arg1, arg2)
+}
+
+// CreateResult mocks base method.
+func (m *MockCheckRepository) CreateResult(arg0 context.Context, arg1 models.CheckResult) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResult", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResult indicates an expected call of CreateResult.
+func (mr *MockCheckRepositoryMockRecorder) CreateResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResult", reflect.TypeOf((*MockCheckRepository)(nil).CreateResult), arg0, arg1)
+}
+
+// GetAverageResponseTime mocks base method.
+func (m *MockCheckRepository) GetAverageResponseTime(arg0 context.Context, arg1 int64, arg2 time.Time) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAverageResponseTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAverageResponseTime indicates an expected call of GetAverageResponseTime.
+func (mr *MockCheckRepositoryMockRecorder) GetAverageResponseTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageResponseTime", reflect.TypeOf((*MockCheckRepository)(nil).GetAverageResponseTime), arg0, arg1, arg2)
+}
+
+// GetDailyUptime mocks base method.
+func (m *MockCheckRepository) GetDailyUptime(arg0 context.Context, arg1 []int64, arg2 time.Time) (map[int64][]models.DailyUptime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyUptime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64][]models.DailyUptime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyUptime indicates an expected call of GetDailyUptime.
+func (mr *MockCheckRepositoryMockRecorder) GetDailyUptime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyUptime", reflect.TypeOf((*MockCheckRepository)(nil).GetDailyUptime), arg0, arg1, arg2)
+}
+
+// GetLatestStatuses mocks base method.
+func (m *MockCheckRepository) GetLatestStatuses(arg0 context.Context, arg1 []int64) (map[int64]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestStatuses", arg0, arg1)
+	ret0, _ := ret[0].(map[int64]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestStatuses indicates an expected call of GetLatestStatuses.
+func (mr *MockCheckRepositoryMockRecorder) GetLatestStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestStatuses", reflect.TypeOf((*MockCheckRepository)(nil).GetLatestStatuses), arg0, arg1)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/services/checks (interfaces: CheckService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCheckService is a mock of CheckService interface.
+type MockCheckService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckServiceMockRecorder
+}
+
+// MockCheckServiceMockRecorder is the mock recorder for MockCheckService.
+type MockCheckServiceMockRecorder struct {
+	mock *MockCheckService
+}
+
+// NewMockCheckService creates a new mock instance.
+func NewMockCheckService(ctrl *gomock.Controller) *MockCheckService {
+	mock := &MockCheckService{ctrl: ctrl}
+	mock.recorder = &MockCheckServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckService) EXPECT() *MockCheckServiceMockRecorder {
+	return m.recorder
+}
+
+// Heartbeat mocks base method.
+func (m *MockCheckService) Heartbeat() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockCheckServiceMockRecorder) Heartbeat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockCheckService)(nil).Heartbeat))
+}
+
+// Run mocks base method.
+func (m *MockCheckService) Run(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", arg0)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCheckServiceMockRecorder) Run(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCheckService)(nil).Run), arg0)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: EscalationRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockEscalationRepository is a mock of EscalationRepository interface.
+type MockEscalationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscalationRepositoryMockRecorder
+}
+
+// MockEscalationRepositoryMockRecorder is the mock recorder for MockEscalationRepository.
+type MockEscalationRepositoryMockRecorder struct {
+	mock *MockEscalationRepository
+}
+
+// NewMockEscalationRepository creates a new mock instance.
+func NewMockEscalationRepository(ctrl *gomock.Controller) *MockEscalationRepository {
+	mock := &MockEscalationRepository{ctrl: ctrl}
+	mock.recorder = &MockEscalationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscalationRepository) EXPECT() *MockEscalationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueEscalations mocks base method.
+func (m *MockEscalationRepository) ClaimDueEscalations(arg0 context.Context, arg1 time.Time, arg2 int) ([]models.EscalationStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueEscalations", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.EscalationStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueEscalations indicates an expected call of ClaimDueEscalations.
+func (mr *MockEscalationRepositoryMockRecorder) ClaimDueEscalations(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueEscalations", reflect.TypeOf((*MockEscalationRepository)(nil).ClaimDueEscalations), arg0, arg1, arg2)
+}
+
+// CreatePolicy mocks base method.
+func (m *MockEscalationRepository) CreatePolicy(arg0 context.Context, arg1 models.EscalationPolicy) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePolicy", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePolicy indicates an expected call of CreatePolicy.
+func (mr *MockEscalationRepositoryMockRecorder) CreatePolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePolicy", reflect.TypeOf((*MockEscalationRepository)(nil).CreatePolicy), arg0, arg1)
+}
+
+// DeletePolicy mocks base method.
+func (m *MockEscalationRepository) DeletePolicy(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePolicy indicates an expected call of DeletePolicy.
+func (mr *MockEscalationRepositoryMockRecorder) DeletePolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicy", reflect.TypeOf((*MockEscalationRepository)(nil).DeletePolicy), arg0, arg1)
+}
+
+// GetOrganizationPolicies mocks base method.
+func (m *MockEscalationRepository) GetOrganizationPolicies(arg0 context.Context, arg1 int64) ([]models.EscalationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationPolicies", arg0, arg1)
+	ret0, _ := ret[0].([]models.EscalationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationPolicies indicates an expected call of GetOrganizationPolicies.
+func (mr *MockEscalationRepositoryMockRecorder) GetOrganizationPolicies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationPolicies", reflect.TypeOf((*MockEscalationRepository)(nil).GetOrganizationPolicies), arg0, arg1)
+}
+
+// GetPolicy mocks base method.
+func (m *MockEscalationRepository) GetPolicy(arg0 context.Context, arg1 int64) (*models.EscalationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*models.EscalationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockEscalationRepositoryMockRecorder) GetPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockEscalationRepository)(nil).GetPolicy), arg0, arg1)
+}
+
+// StartEscalation mocks base method.
+func (m *MockEscalationRepository) StartEscalation(arg0 context.Context, arg1, arg2 int64, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartEscalation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartEscalation indicates an expected call of StartEscalation.
+func (mr *MockEscalationRepositoryMockRecorder) StartEscalation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartEscalation", reflect.TypeOf((*MockEscalationRepository)(nil).StartEscalation), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePolicy mocks base method.
+func (m *MockEscalationRepository) UpdatePolicy(arg0 context.Context, arg1 models.EscalationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockEscalationRepositoryMockRecorder) UpdatePolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockEscalationRepository)(nil).UpdatePolicy), arg0, arg1)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: FeedTokenRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFeedTokenRepository is a mock of FeedTokenRepository interface.
+type MockFeedTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedTokenRepositoryMockRecorder
+}
+
+// MockFeedTokenRepositoryMockRecorder is the mock recorder for MockFeedTokenRepository.
+type MockFeedTokenRepositoryMockRecorder struct {
+	mock *MockFeedTokenRepository
+}
+
+// NewMockFeedTokenRepository creates a new mock instance.
+func NewMockFeedTokenRepository(ctrl *gomock.Controller) *MockFeedTokenRepository {
+	mock := &MockFeedTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedTokenRepository) EXPECT() *MockFeedTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteFeedToken mocks base method.
+func (m *MockFeedTokenRepository) DeleteFeedToken(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeedToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeedToken indicates an expected call of DeleteFeedToken.
+func (mr *MockFeedTokenRepositoryMockRecorder) DeleteFeedToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeedToken", reflect.TypeOf((*MockFeedTokenRepository)(nil).DeleteFeedToken), arg0, arg1)
+}
+
+// GetFeedTokenUser mocks base method.
+func (m *MockFeedTokenRepository) GetFeedTokenUser(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedTokenUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedTokenUser indicates an expected call of GetFeedTokenUser.
+func (mr *MockFeedTokenRepositoryMockRecorder) GetFeedTokenUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedTokenUser", reflect.TypeOf((*MockFeedTokenRepository)(nil).GetFeedTokenUser), arg0, arg1)
+}
+
+// SaveFeedToken mocks base method.
+func (m *MockFeedTokenRepository) SaveFeedToken(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFeedToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFeedToken indicates an expected call of SaveFeedToken.
+func (mr *MockFeedTokenRepositoryMockRecorder) SaveFeedToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFeedToken", reflect.TypeOf((*MockFeedTokenRepository)(nil).SaveFeedToken), arg0, arg1, arg2)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: IncidentRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockIncidentRepository is a mock of IncidentRepository interface.
+type MockIncidentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentRepositoryMockRecorder
+}
+
+// MockIncidentRepositoryMockRecorder is the mock recorder for MockIncidentRepository.
+type MockIncidentRepositoryMockRecorder struct {
+	mock *MockIncidentRepository
+}
+
+// NewMockIncidentRepository creates a new mock instance.
+func NewMockIncidentRepository(ctrl *gomock.Controller) *MockIncidentRepository {
+	mock := &MockIncidentRepository{ctrl: ctrl}
+	mock.recorder = &MockIncidentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentRepository) EXPECT() *MockIncidentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcknowledgeIncident mocks base method.
+func (m *MockIncidentRepository) AcknowledgeIncident(arg0 context.Context, arg1, arg2 int64, arg3 time.Time) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeIncident", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeIncident indicates an expected call of AcknowledgeIncident.
+func (mr *MockIncidentRepositoryMockRecorder) AcknowledgeIncident(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeIncident", reflect.TypeOf((*MockIncidentRepository)(nil).AcknowledgeIncident), arg0, arg1, arg2, arg3)
+}
+
+// CreateIncident mocks base method.
+func (m *MockIncidentRepository) CreateIncident(arg0 context.Context, arg1 models.Incident) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncident", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncident indicates an expected call of CreateIncident.
+func (mr *MockIncidentRepositoryMockRecorder) CreateIncident(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncident", reflect.TypeOf((*MockIncidentRepository)(nil).CreateIncident), arg0, arg1)
+}
+
+// GetIncident mocks base method.
+func (m *MockIncidentRepository) GetIncident(arg0 context.Context, arg1 int64) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncident", arg0, arg1)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncident indicates an expected call of GetIncident.
+func (mr *MockIncidentRepositoryMockRecorder) GetIncident(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncident", reflect.TypeOf((*MockIncidentRepository)(nil).GetIncident), arg0, arg1)
+}
+
+// GetMonitorIncidents mocks base method.
+func (m *MockIncidentRepository) GetMonitorIncidents(arg0 context.Context, arg1 int64, arg2 int) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonitorIncidents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonitorIncidents indicates an expected call of GetMonitorIncidents.
+func (mr *MockIncidentRepositoryMockRecorder) GetMonitorIncidents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonitorIncidents", reflect.TypeOf((*MockIncidentRepository)(nil).GetMonitorIncidents), arg0, arg1, arg2)
+}
+
+// GetOpenIncident mocks base method.
+func (m *MockIncidentRepository) GetOpenIncident(arg0 context.Context, arg1 int64) (*models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenIncident", arg0, arg1)
+	ret0, _ := ret[0].(*models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenIncident indicates an expected call of GetOpenIncident.
+func (mr *MockIncidentRepositoryMockRecorder) GetOpenIncident(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenIncident", reflect.TypeOf((*MockIncidentRepository)(nil).GetOpenIncident), arg0, arg1)
+}
+
+// GetOpenIncidents mocks base method.
+func (m *MockIncidentRepository) GetOpenIncidents(arg0 context.Context, arg1 []int64) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenIncidents", arg0, arg1)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenIncidents indicates an expected call of GetOpenIncidents.
+func (mr *MockIncidentRepositoryMockRecorder) GetOpenIncidents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenIncidents", reflect.TypeOf((*MockIncidentRepository)(nil).GetOpenIncidents), arg0, arg1)
+}
+
+// GetRecentIncidents mocks base method.
+func (m *MockIncidentRepository) GetRecentIncidents(arg0 context.Context, arg1 []int64, arg2 int) ([]models.Incident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentIncidents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Incident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentIncidents indicates an expected call of GetRecentIncidents.
+func (mr *MockIncidentRepositoryMockRecorder) GetRecentIncidents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentIncidents", reflect.TypeOf((*MockIncidentRepository)(nil).GetRecentIncidents), arg0, arg1, arg2)
+}
+
+// ResolveIncident mocks base method.
+func (m *MockIncidentRepository) ResolveIncident(arg0 context.Context, arg1 int64, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveIncident", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveIncident indicates an expected call of ResolveIncident.
+func (mr *MockIncidentRepositoryMockRecorder) ResolveIncident(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveIncident", reflect.TypeOf((*MockIncidentRepository)(nil).ResolveIncident), arg0, arg1, arg2)
+}
+
+// SetIncidentMaintenance mocks base method.
+func (m *MockIncidentRepository) SetIncidentMaintenance(arg0 context.Context, arg1 int64, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIncidentMaintenance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIncidentMaintenance indicates an expected call of SetIncidentMaintenance.
+func (mr *MockIncidentRepositoryMockRecorder) SetIncidentMaintenance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIncidentMaintenance", reflect.TypeOf((*MockIncidentRepository)(nil).SetIncidentMaintenance), arg0, arg1, arg2)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/services/maintenance (interfaces: MaintenanceService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockMaintenanceService is a mock of MaintenanceService interface.
+type MockMaintenanceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceServiceMockRecorder
+}
+
+// MockMaintenanceServiceMockRecorder is the mock recorder for MockMaintenanceService.
+type MockMaintenanceServiceMockRecorder struct {
+	mock *MockMaintenanceService
+}
+
+// NewMockMaintenanceService creates a new mock instance.
+func NewMockMaintenanceService(ctrl *gomock.Controller) *MockMaintenanceService {
+	mock := &MockMaintenanceService{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceService) EXPECT() *MockMaintenanceServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateWindow mocks base method.
+func (m *MockMaintenanceService) CreateWindow(arg0 context.Context, arg1 models.MaintenanceWindow) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWindow", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWindow indicates an expected call of CreateWindow.
+func (mr *MockMaintenanceServiceMockRecorder) CreateWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWindow", reflect.TypeOf((*MockMaintenanceService)(nil).CreateWindow), arg0, arg1)
+}
+
+// DeleteWindow mocks base method.
+func (m *MockMaintenanceService) DeleteWindow(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWindow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWindow indicates an expected call of DeleteWindow.
+func (mr *MockMaintenanceServiceMockRecorder) DeleteWindow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWindow", reflect.TypeOf((*MockMaintenanceService)(nil).DeleteWindow), arg0, arg1, arg2)
+}
+
+// GetOrganizationWindows mocks base method.
+func (m *MockMaintenanceService) GetOrganizationWindows(arg0 context.Context, arg1 int64) ([]models.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationWindows", arg0, arg1)
+	ret0, _ := ret[0].([]models.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationWindows indicates an expected call of GetOrganizationWindows.
+func (mr *MockMaintenanceServiceMockRecorder) GetOrganizationWindows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationWindows", reflect.TypeOf((*MockMaintenanceService)(nil).GetOrganizationWindows), arg0, arg1)
+}
+
+// GetWindow mocks base method.
+func (m *MockMaintenanceService) GetWindow(arg0 context.Context, arg1, arg2 int64) (*models.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindow indicates an expected call of GetWindow.
+func (mr *MockMaintenanceServiceMockRecorder) GetWindow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindow", reflect.TypeOf((*MockMaintenanceService)(nil).GetWindow), arg0, arg1, arg2)
+}
+
+// MonitorMaintenance mocks base method.
+func (m *MockMaintenanceService) MonitorMaintenance(arg0 context.Context, arg1 int64, arg2 time.Time) ([]models.MaintenanceOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonitorMaintenance", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.MaintenanceOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonitorMaintenance indicates an expected call of MonitorMaintenance.
+func (mr *MockMaintenanceServiceMockRecorder) MonitorMaintenance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorMaintenance", reflect.TypeOf((*MockMaintenanceService)(nil).MonitorMaintenance), arg0, arg1, arg2)
+}
+
+// OrganizationMaintenance mocks base method.
+func (m *MockMaintenanceService) OrganizationMaintenance(arg0 context.Context, arg1 int64, arg2 time.Time) (map[int64][]models.MaintenanceOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationMaintenance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64][]models.MaintenanceOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrganizationMaintenance indicates an expected call of OrganizationMaintenance.
+func (mr *MockMaintenanceServiceMockRecorder) OrganizationMaintenance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationMaintenance", reflect.TypeOf((*MockMaintenanceService)(nil).OrganizationMaintenance), arg0, arg1, arg2)
+}
+
+// UpdateWindow mocks base method.
+func (m *MockMaintenanceService) UpdateWindow(arg0 context.Context, arg1 models.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWindow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWindow indicates an expected call of UpdateWindow.
+func (mr *MockMaintenanceServiceMockRecorder) UpdateWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWindow", reflect.TypeOf((*MockMaintenanceService)(nil).UpdateWindow), arg0, arg1)
+}
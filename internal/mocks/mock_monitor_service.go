@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/services/monitors (interfaces: MonitorService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockMonitorService is a mock of MonitorService interface.
+type MockMonitorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitorServiceMockRecorder
+}
+
+// MockMonitorServiceMockRecorder is the mock recorder for MockMonitorService.
+type MockMonitorServiceMockRecorder struct {
+	mock *MockMonitorService
+}
+
+// NewMockMonitorService creates a new mock instance.
+func NewMockMonitorService(ctrl *gomock.Controller) *MockMonitorService {
+	mock := &MockMonitorService{ctrl: ctrl}
+	mock.recorder = &MockMonitorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMonitorService) EXPECT() *MockMonitorServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateMonitor mocks base method.
+func (m *MockMonitorService) CreateMonitor(arg0 context.Context, arg1 models.Monitor) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMonitor", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMonitor indicates an expected call of CreateMonitor.
+func (mr *MockMonitorServiceMockRecorder) CreateMonitor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMonitor", reflect.TypeOf((*MockMonitorService)(nil).CreateMonitor), arg0, arg1)
+}
+
+// DeleteMonitor mocks base method.
+func (m *MockMonitorService) DeleteMonitor(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMonitor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMonitor indicates an expected call of DeleteMonitor.
+func (mr *MockMonitorServiceMockRecorder) DeleteMonitor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMonitor", reflect.TypeOf((*MockMonitorService)(nil).DeleteMonitor), arg0, arg1)
+}
+
+// GetAllActiveMonitors mocks base method.
+func (m *MockMonitorService) GetAllActiveMonitors(arg0 context.Context) ([]models.Monitor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllActiveMonitors", arg0)
+	ret0, _ := ret[0].([]models.Monitor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllActiveMonitors indicates an expected call of GetAllActiveMonitors.
+func (mr *MockMonitorServiceMockRecorder) GetAllActiveMonitors(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActiveMonitors", reflect.TypeOf((*MockMonitorService)(nil).GetAllActiveMonitors), arg0)
+}
+
+// GetAllOrganizationMonitors mocks base method.
+func (m *MockMonitorService) GetAllOrganizationMonitors(arg0 context.Context, arg1 int64) ([]models.Monitor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrganizationMonitors", arg0, arg1)
+	ret0, _ := ret[0].([]models.Monitor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrganizationMonitors indicates an expected call of GetAllOrganizationMonitors.
+func (mr *MockMonitorServiceMockRecorder) GetAllOrganizationMonitors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrganizationMonitors", reflect.TypeOf((*MockMonitorService)(nil).GetAllOrganizationMonitors), arg0, arg1)
+}
+
+// GetMonitor mocks base method.
+func (m *MockMonitorService) GetMonitor(arg0 context.Context, arg1 int64) (*models.Monitor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonitor", arg0, arg1)
+	ret0, _ := ret[0].(*models.Monitor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonitor indicates an expected call of GetMonitor.
+func (mr *MockMonitorServiceMockRecorder) GetMonitor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonitor", reflect.TypeOf((*MockMonitorService)(nil).GetMonitor), arg0, arg1)
+}
+
+// UpdateLastCheckedAt mocks base method.
+func (m *MockMonitorService) UpdateLastCheckedAt(arg0 context.Context, arg1 int64, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastCheckedAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastCheckedAt indicates an expected call of UpdateLastCheckedAt.
+func (mr *MockMonitorServiceMockRecorder) UpdateLastCheckedAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastCheckedAt", reflect.TypeOf((*MockMonitorService)(nil).UpdateLastCheckedAt), arg0, arg1, arg2)
+}
+
+// UpdateMonitor mocks base method.
+func (m *MockMonitorService) UpdateMonitor(arg0 context.Context, arg1 models.Monitor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMonitor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMonitor indicates an expected call of UpdateMonitor.
+func (mr *MockMonitorServiceMockRecorder) UpdateMonitor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMonitor", reflect.TypeOf((*MockMonitorService)(nil).UpdateMonitor), arg0, arg1)
+}
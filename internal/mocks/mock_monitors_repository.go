@@ -80,19 +80,19 @@ func (mr *MockMonitorsRepositoryMockRecorder) GetAllActiveMonitors(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActiveMonitors", reflect.TypeOf((*MockMonitorsRepository)(nil).GetAllActiveMonitors), arg0)
 }
 
-// GetAllUserMonitors mocks base method.
-func (m *MockMonitorsRepository) GetAllUserMonitors(arg0 context.Context, arg1 int64) ([]models.Monitor, error) {
+// GetAllOrganizationMonitors mocks base method.
+func (m *MockMonitorsRepository) GetAllOrganizationMonitors(arg0 context.Context, arg1 int64) ([]models.Monitor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUserMonitors", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllOrganizationMonitors", arg0, arg1)
 	ret0, _ := ret[0].([]models.Monitor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllUserMonitors indicates an expected call of GetAllUserMonitors.
-func (mr *MockMonitorsRepositoryMockRecorder) GetAllUserMonitors(arg0, arg1 interface{}) *gomock.Call {
+// GetAllOrganizationMonitors indicates an expected call of GetAllOrganizationMonitors.
+func (mr *MockMonitorsRepositoryMockRecorder) GetAllOrganizationMonitors(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserMonitors", reflect.TypeOf((*MockMonitorsRepository)(nil).GetAllUserMonitors), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrganizationMonitors", reflect.TypeOf((*MockMonitorsRepository)(nil).GetAllOrganizationMonitors), arg0, arg1)
 }
 
 // GetMonitor mocks base method.
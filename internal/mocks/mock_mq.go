@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/pkg/message (interfaces: MQ)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMQ is a mock of MQ interface.
+type MockMQ struct {
+	ctrl     *gomock.Controller
+	recorder *MockMQMockRecorder
+}
+
+// MockMQMockRecorder is the mock recorder for MockMQ.
+type MockMQMockRecorder struct {
+	mock *MockMQ
+}
+
+// NewMockMQ creates a new mock instance.
+func NewMockMQ(ctrl *gomock.Controller) *MockMQ {
+	mock := &MockMQ{ctrl: ctrl}
+	mock.recorder = &MockMQMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMQ) EXPECT() *MockMQMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMQ) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMQMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMQ)(nil).Close))
+}
+
+// Consume mocks base method.
+func (m *MockMQ) Consume(arg0 context.Context, arg1 string, arg2 func([]byte) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockMQMockRecorder) Consume(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockMQ)(nil).Consume), arg0, arg1, arg2)
+}
+
+// Depths mocks base method.
+func (m *MockMQ) Depths() map[string]int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Depths")
+	ret0, _ := ret[0].(map[string]int)
+	return ret0
+}
+
+// Depths indicates an expected call of Depths.
+func (mr *MockMQMockRecorder) Depths() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Depths", reflect.TypeOf((*MockMQ)(nil).Depths))
+}
+
+// Ping mocks base method.
+func (m *MockMQ) Ping() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockMQMockRecorder) Ping() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockMQ)(nil).Ping))
+}
+
+// Publish mocks base method.
+func (m *MockMQ) Publish(arg0 string, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockMQMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMQ)(nil).Publish), arg0, arg1)
+}
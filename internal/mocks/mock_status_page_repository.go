@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/repository (interfaces: StatusPageRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockStatusPageRepository is a mock of StatusPageRepository interface.
+type MockStatusPageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusPageRepositoryMockRecorder
+}
+
+// MockStatusPageRepositoryMockRecorder is the mock recorder for MockStatusPageRepository.
+type MockStatusPageRepositoryMockRecorder struct {
+	mock *MockStatusPageRepository
+}
+
+// NewMockStatusPageRepository creates a new mock instance.
+func NewMockStatusPageRepository(ctrl *gomock.Controller) *MockStatusPageRepository {
+	mock := &MockStatusPageRepository{ctrl: ctrl}
+	mock.recorder = &MockStatusPageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusPageRepository) EXPECT() *MockStatusPageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePage mocks base method.
+func (m *MockStatusPageRepository) CreatePage(arg0 context.Context, arg1 models.StatusPage) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePage", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePage indicates an expected call of CreatePage.
+func (mr *MockStatusPageRepositoryMockRecorder) CreatePage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePage", reflect.TypeOf((*MockStatusPageRepository)(nil).CreatePage), arg0, arg1)
+}
+
+// DeletePage mocks base method.
+func (m *MockStatusPageRepository) DeletePage(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePage indicates an expected call of DeletePage.
+func (mr *MockStatusPageRepositoryMockRecorder) DeletePage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePage", reflect.TypeOf((*MockStatusPageRepository)(nil).DeletePage), arg0, arg1)
+}
+
+// GetOrganizationPages mocks base method.
+func (m *MockStatusPageRepository) GetOrganizationPages(arg0 context.Context, arg1 int64) ([]models.StatusPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationPages", arg0, arg1)
+	ret0, _ := ret[0].([]models.StatusPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationPages indicates an expected call of GetOrganizationPages.
+func (mr *MockStatusPageRepositoryMockRecorder) GetOrganizationPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationPages", reflect.TypeOf((*MockStatusPageRepository)(nil).GetOrganizationPages), arg0, arg1)
+}
+
+// GetPage mocks base method.
+func (m *MockStatusPageRepository) GetPage(arg0 context.Context, arg1 int64) (*models.StatusPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", arg0, arg1)
+	ret0, _ := ret[0].(*models.StatusPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockStatusPageRepositoryMockRecorder) GetPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockStatusPageRepository)(nil).GetPage), arg0, arg1)
+}
+
+// GetPageBySlug mocks base method.
+func (m *MockStatusPageRepository) GetPageBySlug(arg0 context.Context, arg1 string) (*models.StatusPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageBySlug", arg0, arg1)
+	ret0, _ := ret[0].(*models.StatusPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageBySlug indicates an expected call of GetPageBySlug.
+func (mr *MockStatusPageRepositoryMockRecorder) GetPageBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageBySlug", reflect.TypeOf((*MockStatusPageRepository)(nil).GetPageBySlug), arg0, arg1)
+}
+
+// UpdatePage mocks base method.
+func (m *MockStatusPageRepository) UpdatePage(arg0 context.Context, arg1 models.StatusPage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePage indicates an expected call of UpdatePage.
+func (mr *MockStatusPageRepositoryMockRecorder) UpdatePage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePage", reflect.TypeOf((*MockStatusPageRepository)(nil).UpdatePage), arg0, arg1)
+}
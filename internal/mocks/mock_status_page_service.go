@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/mixdone/uptime-monitoring/internal/services/statuspage (interfaces: StatusPageService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// MockStatusPageService is a mock of StatusPageService interface.
+type MockStatusPageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusPageServiceMockRecorder
+}
+
+// MockStatusPageServiceMockRecorder is the mock recorder for MockStatusPageService.
+type MockStatusPageServiceMockRecorder struct {
+	mock *MockStatusPageService
+}
+
+// NewMockStatusPageService creates a new mock instance.
+func NewMockStatusPageService(ctrl *gomock.Controller) *MockStatusPageService {
+	mock := &MockStatusPageService{ctrl: ctrl}
+	mock.recorder = &MockStatusPageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusPageService) EXPECT() *MockStatusPageServiceMockRecorder {
+	return m.recorder
+}
+
+// AddAnnouncementUpdate mocks base method.
+func (m *MockStatusPageService) AddAnnouncementUpdate(arg0 context.Context, arg1, arg2 int64, arg3 models.AnnouncementUpdate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAnnouncementUpdate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAnnouncementUpdate indicates an expected call of AddAnnouncementUpdate.
+func (mr *MockStatusPageServiceMockRecorder) AddAnnouncementUpdate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnnouncementUpdate", reflect.TypeOf((*MockStatusPageService)(nil).AddAnnouncementUpdate), arg0, arg1, arg2, arg3)
+}
+
+// CreateAnnouncement mocks base method.
+func (m *MockStatusPageService) CreateAnnouncement(arg0 context.Context, arg1 int64, arg2 models.Announcement) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnnouncement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnnouncement indicates an expected call of CreateAnnouncement.
+func (mr *MockStatusPageServiceMockRecorder) CreateAnnouncement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnnouncement", reflect.TypeOf((*MockStatusPageService)(nil).CreateAnnouncement), arg0, arg1, arg2)
+}
+
+// CreatePage mocks base method.
+func (m *MockStatusPageService) CreatePage(arg0 context.Context, arg1 models.StatusPage) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePage", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePage indicates an expected call of CreatePage.
+func (mr *MockStatusPageServiceMockRecorder) CreatePage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePage", reflect.TypeOf((*MockStatusPageService)(nil).CreatePage), arg0, arg1)
+}
+
+// DeleteAnnouncement mocks base method.
+func (m *MockStatusPageService) DeleteAnnouncement(arg0 context.Context, arg1, arg2, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnnouncement", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAnnouncement indicates an expected call of DeleteAnnouncement.
+func (mr *MockStatusPageServiceMockRecorder) DeleteAnnouncement(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnnouncement", reflect.TypeOf((*MockStatusPageService)(nil).DeleteAnnouncement), arg0, arg1, arg2, arg3)
+}
+
+// DeletePage mocks base method.
+func (m *MockStatusPageService) DeletePage(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePage indicates an expected call of DeletePage.
+func (mr *MockStatusPageServiceMockRecorder) DeletePage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePage", reflect.TypeOf((*MockStatusPageService)(nil).DeletePage), arg0, arg1, arg2)
+}
+
+// GetAnnouncements mocks base method.
+func (m *MockStatusPageService) GetAnnouncements(arg0 context.Context, arg1, arg2 int64) ([]models.Announcement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnnouncements", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Announcement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnnouncements indicates an expected call of GetAnnouncements.
+func (mr *MockStatusPageServiceMockRecorder) GetAnnouncements(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnnouncements", reflect.TypeOf((*MockStatusPageService)(nil).GetAnnouncements), arg0, arg1, arg2)
+}
+
+// GetOrganizationPages mocks base method.
+func (m *MockStatusPageService) GetOrganizationPages(arg0 context.Context, arg1 int64) ([]models.StatusPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationPages", arg0, arg1)
+	ret0, _ := ret[0].([]models.StatusPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationPages indicates an expected call of GetOrganizationPages.
+func (mr *MockStatusPageServiceMockRecorder) GetOrganizationPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationPages", reflect.TypeOf((*MockStatusPageService)(nil).GetOrganizationPages), arg0, arg1)
+}
+
+// GetPage mocks base method.
+func (m *MockStatusPageService) GetPage(arg0 context.Context, arg1, arg2 int64) (*models.StatusPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.StatusPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockStatusPageServiceMockRecorder) GetPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockStatusPageService)(nil).GetPage), arg0, arg1, arg2)
+}
+
+// GetPublicPage mocks base method.
+func (m *MockStatusPageService) GetPublicPage(arg0 context.Context, arg1 string) (*models.PublicStatusPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicPage", arg0, arg1)
+	ret0, _ := ret[0].(*models.PublicStatusPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicPage indicates an expected call of GetPublicPage.
+func (mr *MockStatusPageServiceMockRecorder) GetPublicPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicPage", reflect.TypeOf((*MockStatusPageService)(nil).GetPublicPage), arg0, arg1)
+}
+
+// UpdatePage mocks base method.
+func (m *MockStatusPageService) UpdatePage(arg0 context.Context, arg1 models.StatusPage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePage indicates an expected call of UpdatePage.
+func (mr *MockStatusPageServiceMockRecorder) UpdatePage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePage", reflect.TypeOf((*MockStatusPageService)(nil).UpdatePage), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+type AnnouncementKind string
+
+const (
+	AnnouncementIncident    AnnouncementKind = "incident"
+	AnnouncementMaintenance AnnouncementKind = "maintenance"
+)
+
+type AnnouncementStatus string
+
+// Incident announcements go from investigating to resolved, maintenance
+// announcements from scheduled to completed. Resolved and completed close
+// an announcement.
+const (
+	AnnouncementInvestigating AnnouncementStatus = "investigating"
+	AnnouncementIdentified    AnnouncementStatus = "identified"
+	AnnouncementMonitoring    AnnouncementStatus = "monitoring"
+	AnnouncementResolved      AnnouncementStatus = "resolved"
+
+	AnnouncementScheduled  AnnouncementStatus = "scheduled"
+	AnnouncementInProgress AnnouncementStatus = "in_progress"
+	AnnouncementCompleted  AnnouncementStatus = "completed"
+)
+
+// Announcement is a message posted on a status page about an incident or a
+// planned maintenance of some of its components. While it is open, Impact
+// overrides the status of those components if it is worse. Maintenance
+// announcements take effect between ScheduledFor and ScheduledUntil.
+type Announcement struct {
+	ID             int64              `json:"id" db:"id"`
+	StatusPageID   int64              `json:"status_page_id" db:"status_page_id"`
+	Kind           AnnouncementKind   `json:"kind" db:"kind"`
+	Title          string             `json:"title" db:"title"`
+	Status         AnnouncementStatus `json:"status" db:"status"`
+	Impact         ServiceStatus      `json:"impact" db:"impact"`
+	ComponentIDs   []int64            `json:"component_ids" db:"component_ids"`
+	ScheduledFor   *time.Time         `json:"scheduled_for,omitempty" db:"scheduled_for"`
+	ScheduledUntil *time.Time         `json:"scheduled_until,omitempty" db:"scheduled_until"`
+	CreatedBy      *int64             `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+	ClosedAt       *time.Time         `json:"closed_at,omitempty" db:"closed_at"`
+	// Updates are ordered newest first
+	Updates []AnnouncementUpdate `json:"updates" db:"-"`
+}
+
+type AnnouncementUpdate struct {
+	ID             int64              `json:"id" db:"id"`
+	AnnouncementID int64              `json:"announcement_id" db:"announcement_id"`
+	Status         AnnouncementStatus `json:"status" db:"status"`
+	Message        string             `json:"message" db:"message"`
+	CreatedBy      *int64             `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+}
+
+// Closes reports whether an update with this status closes the announcement.
+func (s AnnouncementStatus) Closes() bool {
+	return s == AnnouncementResolved || s == AnnouncementCompleted
+}
+
+// PublicAnnouncement is an announcement as shown on the public page.
+type PublicAnnouncement struct {
+	Kind           AnnouncementKind           `json:"kind"`
+	Title          string                     `json:"title"`
+	Status         AnnouncementStatus         `json:"status"`
+	Impact         ServiceStatus              `json:"impact"`
+	Components     []string                   `json:"components"`
+	ScheduledFor   *time.Time                 `json:"scheduled_for,omitempty"`
+	ScheduledUntil *time.Time                 `json:"scheduled_until,omitempty"`
+	Updates        []PublicAnnouncementUpdate `json:"updates"`
+}
+
+type PublicAnnouncementUpdate struct {
+	Status    AnnouncementStatus `json:"status"`
+	Message   string             `json:"message"`
+	CreatedAt time.Time          `json:"created_at"`
+}
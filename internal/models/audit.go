@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+const (
+	AuditRegister            = "auth.register"
+	AuditLogin               = "auth.login"
+	AuditLoginFailed         = "auth.login_failed"
+	AuditLogout              = "auth.logout"
+	AuditSessionRevoke       = "session.revoke"
+	AuditSessionRevokeAll    = "session.revoke_all"
+	AuditTokenReuse          = "session.token_reuse"
+	AuditMonitorCreate       = "monitor.create"
+	AuditMonitorUpdate       = "monitor.update"
+	AuditMonitorDelete       = "monitor.delete"
+	AuditMaintenanceCreate   = "maintenance.create"
+	AuditMaintenanceUpdate   = "maintenance.update"
+	AuditMaintenanceDelete   = "maintenance.delete"
+	AuditIncidentAcknowledge = "incident.acknowledge"
+	AuditStatusPageCreate    = "status_page.create"
+	AuditStatusPageUpdate    = "status_page.update"
+	AuditStatusPageDelete    = "status_page.delete"
+	AuditAnnouncementCreate  = "announcement.create"
+	AuditAnnouncementUpdate  = "announcement.update"
+	AuditAnnouncementDelete  = "announcement.delete"
+	AuditBadgeEnable         = "monitor.badge_enable"
+	AuditBadgeDisable        = "monitor.badge_disable"
+	AuditTargetUser          = "user"
+	AuditTargetSession       = "session"
+	AuditTargetMonitor       = "monitor"
+	AuditTargetMaintenance   = "maintenance_window"
+	AuditTargetIncident      = "incident"
+	AuditTargetStatusPage    = "status_page"
+	AuditTargetAnnouncement  = "announcement"
+)
+
+// AuditEntry records who did what to which object. Changes holds the fields
+// that differ between the old and the new state of the target.
+type AuditEntry struct {
+	ID             int64                  `json:"id" db:"id"`
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+	ActorID        *int64                 `json:"actor_id,omitempty" db:"actor_id"`
+	OrganizationID *int64                 `json:"organization_id,omitempty" db:"organization_id"`
+	Action         string                 `json:"action" db:"action"`
+	TargetType     string                 `json:"target_type" db:"target_type"`
+	TargetID       *int64                 `json:"target_id,omitempty" db:"target_id"`
+	Changes        map[string]AuditChange `json:"changes,omitempty" db:"changes"`
+	Details        map[string]any         `json:"details,omitempty" db:"details"`
+	IP             string                 `json:"ip" db:"ip"`
+	UserAgent      string                 `json:"user_agent" db:"user_agent"`
+}
+
+type AuditChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// AuditFilter narrows down audit entries. Nil and zero fields don't filter.
+// UserID matches entries the user either performed or was the target of.
+// Action ending in ".*" matches every action with that prefix.
+type AuditFilter struct {
+	OrganizationID *int64
+	UserID         *int64
+	ActorID        *int64
+	Action         string
+	TargetType     string
+	TargetID       *int64
+	From           *time.Time
+	To             *time.Time
+	BeforeID       int64
+	Limit          int
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Badge makes the status, uptime and latency badges of a monitor available
+// to anyone who knows the token.
+type Badge struct {
+	MonitorID int64     `json:"monitor_id" db:"monitor_id"`
+	Token     string    `json:"token" db:"token"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	CheckUp   = "up"
+	CheckDown = "down"
+	// CheckUnreachable is a failure while a parent monitor was down
+	CheckUnreachable = "unreachable"
+)
+
+// CheckResult is the outcome of one probe of a monitor. Maintenance is set
+// when the check ran inside a maintenance window of the monitor.
+type CheckResult struct {
+	ID             int64     `json:"id" db:"id"`
+	MonitorID      int64     `json:"monitor_id" db:"monitor_id"`
+	CheckedAt      time.Time `json:"checked_at" db:"checked_at"`
+	Status         string    `json:"status" db:"status"`
+	StatusCode     int       `json:"status_code,omitempty" db:"status_code"`
+	ResponseTimeMs int64     `json:"response_time_ms" db:"response_time_ms"`
+	Error          string    `json:"error,omitempty" db:"error"`
+	Maintenance    bool      `json:"maintenance" db:"maintenance"`
+	// CertificateExpiresAt is the end of validity of the certificate the
+	// target presented over TLS. It is exported as a metric, not stored.
+	CertificateExpiresAt *time.Time `json:"certificate_expires_at,omitempty" db:"-"`
+}
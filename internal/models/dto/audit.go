@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type AuditQuery struct {
+	ActorID    *int64     `form:"actor_id"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"target_type"`
+	TargetID   *int64     `form:"target_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	BeforeID   int64      `form:"before_id" binding:"omitempty,gt=0"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// AuditPage is one page of entries, newest first. NextBeforeID is passed as
+// before_id to fetch the following page.
+type AuditPage struct {
+	Entries      []models.AuditEntry `json:"entries"`
+	NextBeforeID *int64              `json:"next_before_id,omitempty"`
+}
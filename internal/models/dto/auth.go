@@ -1,27 +1,43 @@
 package dto
 
+import "time"
+
+// AuthResult carries either the token pair or, when the account has
+// two-factor authentication enabled, the challenge token for /auth/2fa/login.
 type AuthResult struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken       string `json:"access_token,omitempty"`
+	RefreshToken      string `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+// ClientInfo describes the client a session is opened from. It is filled
+// by the handler from the request, never from the JSON body.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
 }
 
 type RegisterRequest struct {
-	Username    string `json:"username" binding:"required,min=3,max=30"`
-	Password    string `json:"password" binding:"required,min=6"`
-	Email       string `json:"email" binding:"omitempty,email"`
-	TelegramID  int64  `json:"telegram_id" binding:"omitempty"`
-	Fingerprint string `json:"fingerprint" binding:"required"`
+	Username    string     `json:"username" binding:"required,min=3,max=30"`
+	Password    string     `json:"password" binding:"required,min=6"`
+	Email       string     `json:"email" binding:"omitempty,email"`
+	TelegramID  int64      `json:"telegram_id" binding:"omitempty"`
+	Fingerprint string     `json:"fingerprint" binding:"required"`
+	Client      ClientInfo `json:"-"`
 }
 
 type LoginRequest struct {
-	Username    string `json:"username" binding:"required"`
-	Password    string `json:"password" binding:"required"`
-	Fingerprint string `json:"fingerprint" binding:"required"`
+	Username    string     `json:"username" binding:"required"`
+	Password    string     `json:"password" binding:"required"`
+	Fingerprint string     `json:"fingerprint" binding:"required"`
+	Client      ClientInfo `json:"-"`
 }
 
 type RefreshRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required"`
-	Fingerprint  string `json:"fingerprint" binding:"required"`
+	RefreshToken string     `json:"refresh_token" binding:"required"`
+	Fingerprint  string     `json:"fingerprint" binding:"required"`
+	Client       ClientInfo `json:"-"`
 }
 
 type LogoutRequest struct {
@@ -29,6 +45,27 @@ type LogoutRequest struct {
 	Fingerprint  string `json:"fingerprint" binding:"required"`
 }
 
+type OIDCLoginRequest struct {
+	Fingerprint string `form:"fingerprint" binding:"required"`
+}
+
+type OIDCCallbackRequest struct {
+	Code   string     `form:"code" binding:"required"`
+	State  string     `form:"state" binding:"required"`
+	Client ClientInfo `form:"-"`
+}
+
+type SessionResponse struct {
+	ID          int64     `json:"id"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	IP          string    `json:"ip"`
+	UserAgent   string    `json:"user_agent"`
+	Current     bool      `json:"current"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// BadgeResponse lists the public badge URLs relative to the API root.
+type BadgeResponse struct {
+	Token      string    `json:"token"`
+	CreatedAt  time.Time `json:"created_at"`
+	StatusURL  string    `json:"status_url"`
+	UptimeURL  string    `json:"uptime_url"`
+	LatencyURL string    `json:"latency_url"`
+}
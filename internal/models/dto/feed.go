@@ -0,0 +1,8 @@
+package dto
+
+// FeedTokenResponse is shown once; the token can't be fetched again.
+type FeedTokenResponse struct {
+	Token   string `json:"token"`
+	AtomURL string `json:"atom_url"`
+	RSSURL  string `json:"rss_url"`
+}
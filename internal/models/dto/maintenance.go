@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// MaintenanceWindowRequest leaves recurrence empty for a one-off window from
+// starts_at to ends_at. A recurring window sets recurrence to a cron
+// expression or an RRULE and duration_minutes; ends_at then ends the series.
+type MaintenanceWindowRequest struct {
+	Title           string     `json:"title" binding:"required,max=200"`
+	Description     string     `json:"description"`
+	StartsAt        time.Time  `json:"starts_at" binding:"required"`
+	EndsAt          *time.Time `json:"ends_at"`
+	Recurrence      string     `json:"recurrence"`
+	DurationMinutes int        `json:"duration_minutes" binding:"gte=0"`
+	Timezone        string     `json:"timezone"`
+	MonitorIDs      []int64    `json:"monitor_ids" binding:"required,min=1"`
+}
+
+type MaintenanceWindowResponse struct {
+	ID int64 `json:"id"`
+}
@@ -11,6 +11,13 @@ type MonitorRequest struct {
 	IsActive         bool            `json:"is_active"`
 	RequestSpec      json.RawMessage `json:"request_spec" binding:"required"`
 	ExpectedResponse json.RawMessage `json:"expected_response"`
+	ParentIDs        []int64         `json:"parent_ids"`
+	// EscalationPolicyID selects who gets paged when the monitor goes down
+	EscalationPolicyID *int64 `json:"escalation_policy_id"`
+	// FlapThreshold is the number of status changes within
+	// FlapWindowMinutes that counts as flapping, zero disables detection
+	FlapThreshold     int `json:"flap_threshold" binding:"omitempty,gte=2"`
+	FlapWindowMinutes int `json:"flap_window_minutes" binding:"gte=0"`
 }
 
 type MonitorResponse struct {
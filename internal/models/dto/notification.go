@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/mixdone/uptime-monitoring/internal/models"
+
+// NotificationChannelRequest sets the config fields of its type: chat_id for
+// telegram, email for email and url with an optional secret for webhook.
+type NotificationChannelRequest struct {
+	Name   string               `json:"name" binding:"required,max=200"`
+	Type   models.ChannelType   `json:"type" binding:"required,oneof=telegram email webhook"`
+	Config models.ChannelConfig `json:"config"`
+}
+
+type NotificationChannelResponse struct {
+	ID int64 `json:"id"`
+}
+
+// EscalationPolicyRequest lists levels in the order they fire. Each level's
+// delay counts from the start of the incident.
+type EscalationPolicyRequest struct {
+	Name   string                   `json:"name" binding:"required,max=200"`
+	Levels []models.EscalationLevel `json:"levels" binding:"required,min=1"`
+}
+
+type EscalationPolicyResponse struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=200"`
+}
+
+type OrganizationResponse struct {
+	ID int64 `json:"id"`
+}
+
+type InviteMemberRequest struct {
+	Email    string `json:"email" binding:"required_without=Username,omitempty,email"`
+	Username string `json:"username" binding:"required_without=Email"`
+	Role     string `json:"role" binding:"required,oneof=owner admin editor viewer"`
+}
+
+type InvitationResponse struct {
+	ID int64 `json:"id"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin editor viewer"`
+}
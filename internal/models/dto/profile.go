@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/mixdone/uptime-monitoring/internal/models"
+
+// UpdateProfileRequest changes only the fields that are present.
+type UpdateProfileRequest struct {
+	Username                *string                         `json:"username" binding:"omitempty,min=3,max=30"`
+	Email                   *string                         `json:"email" binding:"omitempty,email"`
+	TelegramID              *int64                          `json:"telegram_id" binding:"omitempty,gte=0"`
+	Timezone                *string                         `json:"timezone" binding:"omitempty,max=64"`
+	NotificationPreferences *models.NotificationPreferences `json:"notification_preferences"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string     `json:"old_password" binding:"required"`
+	NewPassword string     `json:"new_password" binding:"required,min=6"`
+	Client      ClientInfo `json:"-"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code"`
+}
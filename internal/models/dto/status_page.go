@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// StatusPageRequest describes the whole page. Components are shown in the
+// given order; those with an id update an existing component of the page.
+type StatusPageRequest struct {
+	Slug        string                   `json:"slug" binding:"required,max=64"`
+	Title       string                   `json:"title" binding:"required,max=200"`
+	Description string                   `json:"description"`
+	Components  []StatusComponentRequest `json:"components" binding:"dive"`
+}
+
+type StatusComponentRequest struct {
+	ID       int64                      `json:"id"`
+	Name     string                     `json:"name" binding:"required,max=200"`
+	Monitors []StatusPageMonitorRequest `json:"monitors" binding:"dive"`
+}
+
+// StatusPageMonitorRequest falls back to the monitor's own name when
+// display_name is empty.
+type StatusPageMonitorRequest struct {
+	MonitorID   int64  `json:"monitor_id" binding:"required"`
+	DisplayName string `json:"display_name" binding:"max=200"`
+}
+
+type StatusPageResponse struct {
+	ID int64 `json:"id"`
+}
+
+// AnnouncementRequest posts a new announcement with its first update. An
+// empty status starts incidents as investigating and maintenance as
+// scheduled.
+type AnnouncementRequest struct {
+	Kind           string     `json:"kind" binding:"required,oneof=incident maintenance"`
+	Title          string     `json:"title" binding:"required,max=200"`
+	Message        string     `json:"message" binding:"required"`
+	Status         string     `json:"status"`
+	Impact         string     `json:"impact" binding:"omitempty,oneof=degraded outage"`
+	ComponentIDs   []int64    `json:"component_ids"`
+	ScheduledFor   *time.Time `json:"scheduled_for"`
+	ScheduledUntil *time.Time `json:"scheduled_until"`
+}
+
+type AnnouncementUpdateRequest struct {
+	Status  string `json:"status" binding:"required"`
+	Message string `json:"message" binding:"required"`
+}
+
+type AnnouncementResponse struct {
+	ID int64 `json:"id"`
+}
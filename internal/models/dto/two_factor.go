@@ -0,0 +1,27 @@
+package dto
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorVerifyRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type TwoFactorVerifyResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string     `json:"challenge_token" binding:"required"`
+	Code           string     `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode   string     `json:"recovery_code" binding:"required_without=Code"`
+	Fingerprint    string     `json:"fingerprint" binding:"required"`
+	Client         ClientInfo `json:"-"`
+}
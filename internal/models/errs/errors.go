@@ -1,18 +1,90 @@
 package errs
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	ErrTokenExpired     = errors.New("token is expired")
 	ErrTokenInvalid     = errors.New("token is invalid")
 	ErrTokenWrongFormat = errors.New("wrong token format")
 
-	ErrSessionNotFound = errors.New("session not found")
-	ErrUserNotFound    = errors.New("user not found")
-	ErrUsernameTaken   = errors.New("username already taken")
-	ErrHashingFailed   = errors.New("failed to hash password")
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrTokenReused      = errors.New("refresh token reuse detected")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrUsernameTaken    = errors.New("username already taken")
+	ErrEmailTaken       = errors.New("email already taken")
+	ErrTelegramIDTaken  = errors.New("telegram id already taken")
+	ErrInvalidTimezone  = errors.New("unknown timezone")
+	ErrHashingFailed    = errors.New("failed to hash password")
+	ErrUserTokenInvalid = errors.New("token is invalid or expired")
+	ErrEmailMissing     = errors.New("user has no email address")
+	ErrEmailVerified    = errors.New("email already verified")
+
+	ErrSSODisabled      = errors.New("single sign-on is not configured")
+	ErrSSOStateInvalid  = errors.New("sign-on state is invalid or expired")
+	ErrSSOFailed        = errors.New("sign-on with the identity provider failed")
+	ErrSSONoAccount     = errors.New("no account is linked to this identity")
+	ErrIdentityNotFound = errors.New("identity not found")
+
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrTooManyAttempts    = errors.New("too many login attempts")
+
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication not enabled")
+	ErrTwoFactorNotSetUp       = errors.New("two-factor authentication setup not started")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrNoActiveOrganization = errors.New("no active organization")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrAlreadyMember        = errors.New("user is already a member")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvalidRole          = errors.New("invalid role")
+	ErrLastOwner            = errors.New("organization must keep at least one owner")
+	ErrForbidden            = errors.New("forbidden")
+
+	ErrMonitorNotFound           = errors.New("monitor not found")
+	ErrIncidentNotFound          = errors.New("incident not found")
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+	ErrInvalidSchedule           = errors.New("invalid maintenance schedule")
+	ErrDependencyCycle           = errors.New("monitor dependencies form a cycle")
+	ErrSelfDependency            = errors.New("monitor can't depend on itself")
+	ErrIncidentResolved          = errors.New("incident is already resolved")
+	ErrIncidentAcknowledged      = errors.New("incident is already acknowledged")
+
+	ErrChannelNotFound          = errors.New("notification channel not found")
+	ErrInvalidChannel           = errors.New("invalid notification channel")
+	ErrEscalationPolicyNotFound = errors.New("escalation policy not found")
+	ErrInvalidEscalationPolicy  = errors.New("invalid escalation policy")
+
+	ErrStatusPageNotFound      = errors.New("status page not found")
+	ErrStatusComponentNotFound = errors.New("status page component not found")
+	ErrSlugTaken               = errors.New("status page slug is already taken")
+	ErrInvalidStatusPage       = errors.New("invalid status page")
+
+	ErrAnnouncementNotFound = errors.New("announcement not found")
+	ErrAnnouncementClosed   = errors.New("announcement is already closed")
+	ErrInvalidAnnouncement  = errors.New("invalid announcement")
+
+	ErrBadgeNotFound      = errors.New("badge not found")
+	ErrInvalidBadgePeriod = errors.New("invalid badge period")
+
+	ErrFeedNotFound = errors.New("feed not found")
+
+	ErrNoMigrations = errors.New("no migration has been applied")
 
 	ErrInternal = errors.New("internal error")
 
 	ErrNotFound = errors.New("resource not found ")
 )
+
+// RetryAfterError tells the caller when the rejected operation may be retried.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+func (e *RetryAfterError) Unwrap() error { return e.Err }
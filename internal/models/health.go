@@ -0,0 +1,26 @@
+package models
+
+const (
+	HealthOK   = "ok"
+	HealthFail = "fail"
+)
+
+// ComponentHealth is the outcome of one readiness check. Only critical
+// components take the whole service out of rotation when they fail.
+type ComponentHealth struct {
+	Status   string         `json:"status"`
+	Critical bool           `json:"critical"`
+	Error    string         `json:"error,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+}
+
+// Readiness is the breakdown served by /readyz.
+type Readiness struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// Ready reports whether every critical component is healthy.
+func (r *Readiness) Ready() bool {
+	return r.Status == HealthOK
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// UserIdentity links a local user to an account at an OpenID Connect
+// provider, identified by issuer and subject.
+type UserIdentity struct {
+	ID          int64     `json:"id" db:"id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	Issuer      string    `json:"issuer" db:"issuer"`
+	Subject     string    `json:"subject" db:"subject"`
+	Email       string    `json:"email,omitempty" db:"email"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at" db:"last_login_at"`
+}
+
+// OIDCLoginState keeps what is needed to finish a login after the provider
+// redirects back. It is looked up by the hash of the state parameter.
+type OIDCLoginState struct {
+	StateHash    string    `db:"state_hash"`
+	Nonce        string    `db:"nonce"`
+	CodeVerifier string    `db:"code_verifier"`
+	Fingerprint  string    `db:"fingerprint"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
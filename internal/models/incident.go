@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Incident spans the time a monitor was down. Incidents that began inside a
+// maintenance window are marked as maintenance and don't alert anyone. A
+// flapping incident spans the time a monitor kept switching between up and
+// down; it is announced once and holds back other alerts until it ends.
+type Incident struct {
+	ID          int64      `json:"id" db:"id"`
+	MonitorID   int64      `json:"monitor_id" db:"monitor_id"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	Cause       string     `json:"cause" db:"cause"`
+	Maintenance bool       `json:"maintenance" db:"maintenance"`
+	Flapping    bool       `json:"flapping" db:"flapping"`
+
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	AcknowledgedBy *int64     `json:"acknowledged_by,omitempty" db:"acknowledged_by"`
+	// EscalationLevel is the next level of the policy to notify, so the
+	// levels before it have already been paged
+	EscalationPolicyID *int64     `json:"escalation_policy_id,omitempty" db:"escalation_policy_id"`
+	EscalationLevel    int        `json:"escalation_level" db:"escalation_level"`
+	NextEscalationAt   *time.Time `json:"next_escalation_at,omitempty" db:"next_escalation_at"`
+}
+
+const (
+	AlertIncidentOpened       = "incident.opened"
+	AlertIncidentEscalated    = "incident.escalated"
+	AlertIncidentAcknowledged = "incident.acknowledged"
+	AlertIncidentResolved     = "incident.resolved"
+	AlertIncidentFlapping     = "incident.flapping"
+)
+
+// Alert is a notification about a change of an incident. Level is the
+// escalation level being notified.
+type Alert struct {
+	Kind     string
+	Monitor  Monitor
+	Incident Incident
+	Level    int
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type LoginAttempt struct {
+	ID        int64     `json:"id" db:"id"`
+	Username  string    `json:"username" db:"username"`
+	IP        string    `json:"ip" db:"ip"`
+	Success   bool      `json:"success" db:"success"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// FailureStats summarizes recent failed logins for one username or IP.
+type FailureStats struct {
+	Count       int
+	LastFailure time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// MaintenanceWindow silences alerts of its monitors. Without Recurrence it is
+// a single period from StartsAt to EndsAt. With a cron expression or RRULE in
+// Recurrence, occurrences are evaluated in Timezone from StartsAt on and each
+// lasts DurationMinutes; EndsAt, if set, ends the series.
+type MaintenanceWindow struct {
+	ID              int64      `json:"id" db:"id"`
+	OrganizationID  int64      `json:"organization_id" db:"organization_id"`
+	Title           string     `json:"title" db:"title"`
+	Description     string     `json:"description" db:"description"`
+	StartsAt        time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt          *time.Time `json:"ends_at,omitempty" db:"ends_at"`
+	Recurrence      string     `json:"recurrence,omitempty" db:"recurrence"`
+	DurationMinutes int        `json:"duration_minutes,omitempty" db:"duration_minutes"`
+	Timezone        string     `json:"timezone" db:"timezone"`
+	MonitorIDs      []int64    `json:"monitor_ids" db:"monitor_ids"`
+	CreatedBy       *int64     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// MaintenanceOccurrence is a period during which a window is in effect.
+type MaintenanceOccurrence struct {
+	WindowID int64     `json:"window_id"`
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
@@ -5,9 +5,13 @@ import (
 	"time"
 )
 
+// DefaultFlapWindowMinutes is the flapping window of monitors that set none.
+const DefaultFlapWindowMinutes = 10
+
 type Monitor struct {
-	ID     int64 `json:"id" db:"id"`
-	UserID int64 `json:"user_id" db:"user_id"`
+	ID             int64 `json:"id" db:"id"`
+	OrganizationID int64 `json:"organization_id" db:"organization_id"`
+	UserID         int64 `json:"user_id" db:"user_id"`
 
 	Name          string     `json:"name" db:"name"`
 	Type          string     `json:"type" db:"type"`
@@ -19,4 +23,26 @@ type Monitor struct {
 
 	RequestSpec      json.RawMessage `json:"request" db:"request"`
 	ExpectedResponse json.RawMessage `json:"expected_response" db:"expected_response"`
+
+	// ParentIDs are monitors this one depends on. While a parent is down,
+	// failures of this monitor are recorded as unreachable.
+	ParentIDs []int64 `json:"parent_ids" db:"parent_ids"`
+
+	EscalationPolicyID *int64 `json:"escalation_policy_id,omitempty" db:"escalation_policy_id"`
+
+	// FlapThreshold status changes within FlapWindowMinutes mark the monitor
+	// as flapping. Zero turns flapping detection off.
+	FlapThreshold     int `json:"flap_threshold" db:"flap_threshold"`
+	FlapWindowMinutes int `json:"flap_window_minutes" db:"flap_window_minutes"`
+
+	// ActiveMaintenance lists the maintenance windows in effect right now
+	ActiveMaintenance []MaintenanceOccurrence `json:"active_maintenance,omitempty" db:"-"`
+}
+
+// FlapWindow returns FlapWindowMinutes, or the default window when it is unset.
+func (m Monitor) FlapWindow() int {
+	if m.FlapWindowMinutes <= 0 {
+		return DefaultFlapWindowMinutes
+	}
+	return m.FlapWindowMinutes
 }
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+type ChannelType string
+
+const (
+	ChannelTelegram ChannelType = "telegram"
+	ChannelEmail    ChannelType = "email"
+	ChannelWebhook  ChannelType = "webhook"
+)
+
+// NotificationChannel is a destination for alerts. Which Config fields are
+// used depends on Type.
+type NotificationChannel struct {
+	ID             int64         `json:"id" db:"id"`
+	OrganizationID int64         `json:"organization_id" db:"organization_id"`
+	Name           string        `json:"name" db:"name"`
+	Type           ChannelType   `json:"type" db:"type"`
+	Config         ChannelConfig `json:"config" db:"config"`
+	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
+}
+
+type ChannelConfig struct {
+	// ChatID is the Telegram chat the bot writes to
+	ChatID int64 `json:"chat_id,omitempty"`
+	// Email is the recipient address
+	Email string `json:"email,omitempty"`
+	// URL receives a JSON POST, signed with Secret when it is set
+	URL    string `json:"url,omitempty"`
+	Secret string `json:"secret,omitempty"`
+}
+
+// EscalationPolicy notifies its levels one after another until the incident
+// is acknowledged or resolved.
+type EscalationPolicy struct {
+	ID             int64             `json:"id" db:"id"`
+	OrganizationID int64             `json:"organization_id" db:"organization_id"`
+	Name           string            `json:"name" db:"name"`
+	Levels         []EscalationLevel `json:"levels" db:"levels"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+}
+
+// EscalationLevel fires DelayMinutes after the incident started.
+type EscalationLevel struct {
+	DelayMinutes int     `json:"delay_minutes"`
+	ChannelIDs   []int64 `json:"channel_ids"`
+}
+
+// EscalationStep is a level of an incident that is due to be notified.
+type EscalationStep struct {
+	Incident   Incident
+	Level      int
+	ChannelIDs []int64
+}
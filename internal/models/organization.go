@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+type OrganizationRole string
+
+const (
+	RoleOwner  OrganizationRole = "owner"
+	RoleAdmin  OrganizationRole = "admin"
+	RoleEditor OrganizationRole = "editor"
+	RoleViewer OrganizationRole = "viewer"
+)
+
+var roleRank = map[OrganizationRole]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+func (r OrganizationRole) IsValid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// Allows reports whether a member with role r may act where required is needed.
+func (r OrganizationRole) Allows(required OrganizationRole) bool {
+	return r.IsValid() && roleRank[r] >= roleRank[required]
+}
+
+type Organization struct {
+	ID        int64            `json:"id" db:"id"`
+	Name      string           `json:"name" db:"name"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	Role      OrganizationRole `json:"role,omitempty" db:"role"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64            `json:"organization_id" db:"organization_id"`
+	UserID         int64            `json:"user_id" db:"user_id"`
+	Username       string           `json:"username" db:"username"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	JoinedAt       time.Time        `json:"joined_at" db:"joined_at"`
+}
+
+type OrganizationInvitation struct {
+	ID               int64            `json:"id" db:"id"`
+	OrganizationID   int64            `json:"organization_id" db:"organization_id"`
+	OrganizationName string           `json:"organization_name,omitempty" db:"organization_name"`
+	Email            *string          `json:"email,omitempty" db:"email"`
+	Username         *string          `json:"username,omitempty" db:"username"`
+	Role             OrganizationRole `json:"role" db:"role"`
+	InvitedBy        *int64           `json:"invited_by,omitempty" db:"invited_by"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	AcceptedAt       *time.Time       `json:"accepted_at,omitempty" db:"accepted_at"`
+}
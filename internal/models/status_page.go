@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// StatusPage is a public page under /status/{slug} that shows the state of
+// the monitors grouped into its components.
+type StatusPage struct {
+	ID             int64             `json:"id" db:"id"`
+	OrganizationID int64             `json:"organization_id" db:"organization_id"`
+	Slug           string            `json:"slug" db:"slug"`
+	Title          string            `json:"title" db:"title"`
+	Description    string            `json:"description" db:"description"`
+	Components     []StatusComponent `json:"components" db:"-"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+}
+
+// StatusComponent groups monitors on a status page. Components keep their ID
+// across page updates when the update names it.
+type StatusComponent struct {
+	ID       int64               `json:"id" db:"id"`
+	Name     string              `json:"name" db:"name"`
+	Monitors []StatusPageMonitor `json:"monitors" db:"-"`
+}
+
+// StatusPageMonitor is a monitor as the public knows it. An empty display
+// name is replaced by the monitor's name when the page is saved.
+type StatusPageMonitor struct {
+	MonitorID   int64  `json:"monitor_id" db:"monitor_id"`
+	DisplayName string `json:"display_name" db:"display_name"`
+}
+
+type ServiceStatus string
+
+// Service statuses from best to worst. The status of a component or a page
+// is the worst status of its monitors.
+const (
+	StatusOperational ServiceStatus = "operational"
+	StatusUnknown     ServiceStatus = "unknown"
+	StatusMaintenance ServiceStatus = "maintenance"
+	StatusDegraded    ServiceStatus = "degraded"
+	StatusOutage      ServiceStatus = "outage"
+)
+
+// DailyUptime counts the checks of a monitor on one UTC day. Checks during
+// maintenance count as up.
+type DailyUptime struct {
+	Day    time.Time
+	Checks int
+	Up     int
+}
+
+// PublicStatusPage is everything an anonymous visitor may see. It is built
+// from separate types so monitor targets, specs and errors can't slip in.
+// Incidents are detected by the monitors, announcements are posted by hand.
+type PublicStatusPage struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	Status      ServiceStatus     `json:"status"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Components  []PublicComponent `json:"components"`
+	Incidents   []PublicIncident  `json:"incidents"`
+	// Announcements are open or recently closed, newest first
+	Announcements []PublicAnnouncement `json:"announcements"`
+	// ScheduledMaintenance lists announced maintenance that hasn't begun
+	ScheduledMaintenance []PublicAnnouncement `json:"scheduled_maintenance"`
+}
+
+type PublicComponent struct {
+	ID       int64           `json:"id"`
+	Name     string          `json:"name"`
+	Status   ServiceStatus   `json:"status"`
+	Monitors []PublicMonitor `json:"monitors"`
+}
+
+// PublicMonitor has one UptimeDay per day of the uptime history, oldest
+// first. Uptime is a percentage and nil when there were no checks.
+type PublicMonitor struct {
+	Name   string        `json:"name"`
+	Status ServiceStatus `json:"status"`
+	Uptime *float64      `json:"uptime"`
+	Days   []UptimeDay   `json:"days"`
+}
+
+type UptimeDay struct {
+	Date   string   `json:"date"`
+	Uptime *float64 `json:"uptime"`
+}
+
+// PublicIncident is an ongoing incident of a monitor on the page.
+type PublicIncident struct {
+	Component string        `json:"component"`
+	Monitor   string        `json:"monitor"`
+	Status    ServiceStatus `json:"status"`
+	StartedAt time.Time     `json:"started_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// TwoFactor is the TOTP state of a user. Secret is set during enrollment and
+// only takes effect once Enabled is true.
+type TwoFactor struct {
+	UserID   int64   `db:"id"`
+	Secret   *string `db:"totp_secret"`
+	Enabled  bool    `db:"totp_enabled"`
+	LastStep int64   `db:"totp_last_step"`
+}
@@ -8,12 +8,38 @@ type User struct {
 	Email        string `json:"email,omitempty" db:"email"`
 	TelegramID   int64  `json:"telegram_id" db:"telegram_id"`
 	PasswordHash string `json:"-" db:"password_hash"`
+
+	ActiveOrganizationID *int64     `json:"active_organization_id,omitempty" db:"active_organization_id"`
+	EmailVerifiedAt      *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+
+	Timezone                string                  `json:"timezone" db:"timezone"`
+	NotificationPreferences NotificationPreferences `json:"notification_preferences" db:"notification_preferences"`
+}
+
+// NotificationPreferences says through which channels the user wants to hear
+// about their monitors.
+type NotificationPreferences struct {
+	Email    bool `json:"email"`
+	Telegram bool `json:"telegram"`
 }
 
 type Session struct {
-	ID           int64     `json:"id" db:"id"`
-	UserID       int64     `json:"user_id" db:"user_id"`
-	RefreshToken string    `json:"refresh_token" db:"refresh_token"`
-	ExpiresAt    time.Time `json:"-" db:"expires_at"`
-	Fingerprint  string    `json:"fingerprint" db:"fingerprint"`
+	ID          int64     `json:"id" db:"id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	ExpiresAt   time.Time `json:"-" db:"expires_at"`
+	Fingerprint string    `json:"fingerprint" db:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at" db:"last_used_at"`
+	IP          string    `json:"ip" db:"ip"`
+	UserAgent   string    `json:"user_agent" db:"user_agent"`
+}
+
+// RefreshToken is one member of a session's token family. Only the SHA-256
+// hash of the token is stored.
+type RefreshToken struct {
+	ID        int64      `json:"id" db:"id"`
+	SessionID int64      `json:"session_id" db:"session_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
 }
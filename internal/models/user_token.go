@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type TokenPurpose string
+
+const (
+	PurposeEmailVerification TokenPurpose = "email_verification"
+	PurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// UserToken is a single-use token sent to the user by email. Only its
+// SHA-256 hash is stored.
+type UserToken struct {
+	ID        int64        `db:"id"`
+	UserID    int64        `db:"user_id"`
+	Purpose   TokenPurpose `db:"purpose"`
+	TokenHash string       `db:"token_hash"`
+	CreatedAt time.Time    `db:"created_at"`
+	ExpiresAt time.Time    `db:"expires_at"`
+	UsedAt    *time.Time   `db:"used_at"`
+}
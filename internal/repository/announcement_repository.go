@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type announcementRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAnnouncementRepo(pool *pgxpool.Pool) AnnouncementRepository {
+	return &announcementRepo{db: pool}
+}
+
+const selectAnnouncements = `
+	SELECT a.id, a.status_page_id, a.kind, a.title, a.status, a.impact,
+		ARRAY(SELECT component_id FROM announcement_components
+			WHERE announcement_id = a.id ORDER BY component_id),
+		a.scheduled_for, a.scheduled_until, a.created_by, a.created_at, a.closed_at
+	FROM announcements a`
+
+// CreateAnnouncement stores an announcement together with its first update,
+// which has to be the only entry of announcement.Updates.
+func (r *announcementRepo) CreateAnnouncement(ctx context.Context, announcement models.Announcement) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	first := announcement.Updates[0]
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO announcements (status_page_id, kind, title, status, impact,
+			scheduled_for, scheduled_until, created_by, closed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $9 THEN now() END)
+		RETURNING id`, announcement.StatusPageID, announcement.Kind, announcement.Title,
+		first.Status, announcement.Impact, announcement.ScheduledFor, announcement.ScheduledUntil,
+		announcement.CreatedBy, first.Status.Closes()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(announcement.ComponentIDs) > 0 {
+		var cmdTag pgconn.CommandTag
+		cmdTag, err = tx.Exec(ctx, `
+			INSERT INTO announcement_components (announcement_id, component_id)
+			SELECT $1, id FROM status_page_components
+			WHERE id = ANY($2) AND status_page_id = $3`,
+			id, announcement.ComponentIDs, announcement.StatusPageID)
+		if err != nil {
+			return 0, err
+		}
+
+		if int(cmdTag.RowsAffected()) != len(announcement.ComponentIDs) {
+			err = errs.ErrStatusComponentNotFound
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO announcement_updates (announcement_id, status, message, created_by)
+		VALUES ($1, $2, $3, $4)`, id, first.Status, first.Message, first.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *announcementRepo) GetAnnouncement(ctx context.Context, id int64) (*models.Announcement, error) {
+	announcement, err := scanAnnouncement(r.db.QueryRow(ctx, selectAnnouncements+`
+		WHERE a.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	announcements := []models.Announcement{*announcement}
+	if err := r.loadUpdates(ctx, announcements); err != nil {
+		return nil, err
+	}
+
+	return &announcements[0], nil
+}
+
+// GetPageAnnouncements returns the announcements of a page, newest first.
+// Announcements closed before since and maintenance that ended before since
+// are left out; a zero since returns all of them.
+func (r *announcementRepo) GetPageAnnouncements(ctx context.Context, pageID int64,
+	since time.Time) ([]models.Announcement, error) {
+
+	rows, err := r.db.Query(ctx, selectAnnouncements+`
+		WHERE a.status_page_id = $1
+			AND (a.closed_at IS NULL OR a.closed_at >= $2)
+			AND (a.scheduled_until IS NULL OR a.scheduled_until >= $2)
+		ORDER BY a.created_at DESC`, pageID, since)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := []models.Announcement{}
+	for rows.Next() {
+		announcement, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		announcements = append(announcements, *announcement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	if err := r.loadUpdates(ctx, announcements); err != nil {
+		return nil, err
+	}
+
+	return announcements, nil
+}
+
+// AddUpdate posts an update and moves the announcement to its status. It
+// fails with ErrAnnouncementClosed once an announcement is resolved or
+// completed.
+func (r *announcementRepo) AddUpdate(ctx context.Context, update models.AnnouncementUpdate) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE announcements
+		SET status = $1, closed_at = CASE WHEN $2 THEN now() END
+		WHERE id = $3 AND closed_at IS NULL`, update.Status, update.Status.Closes(), update.AnnouncementID)
+	if err != nil {
+		return 0, err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		err = errs.ErrAnnouncementClosed
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO announcement_updates (announcement_id, status, message, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`, update.AnnouncementID, update.Status, update.Message, update.CreatedBy).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *announcementRepo) DeleteAnnouncement(ctx context.Context, id int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrAnnouncementNotFound
+	}
+
+	return nil
+}
+
+// loadUpdates fills in the updates of the announcements, newest first.
+func (r *announcementRepo) loadUpdates(ctx context.Context, announcements []models.Announcement) error {
+	if len(announcements) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(announcements))
+	ids := make([]int64, len(announcements))
+	for i := range announcements {
+		announcements[i].Updates = []models.AnnouncementUpdate{}
+		index[announcements[i].ID] = i
+		ids[i] = announcements[i].ID
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, announcement_id, status, message, created_by, created_at
+		FROM announcement_updates
+		WHERE announcement_id = ANY($1)
+		ORDER BY created_at DESC, id DESC`, ids)
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var update models.AnnouncementUpdate
+		if err := rows.Scan(
+			&update.ID,
+			&update.AnnouncementID,
+			&update.Status,
+			&update.Message,
+			&update.CreatedBy,
+			&update.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+
+		i := index[update.AnnouncementID]
+		announcements[i].Updates = append(announcements[i].Updates, update)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iteration error: %w", err)
+	}
+
+	return nil
+}
+
+func scanAnnouncement(row pgx.Row) (*models.Announcement, error) {
+	var announcement models.Announcement
+	err := row.Scan(
+		&announcement.ID,
+		&announcement.StatusPageID,
+		&announcement.Kind,
+		&announcement.Title,
+		&announcement.Status,
+		&announcement.Impact,
+		&announcement.ComponentIDs,
+		&announcement.ScheduledFor,
+		&announcement.ScheduledUntil,
+		&announcement.CreatedBy,
+		&announcement.CreatedAt,
+		&announcement.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type auditRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepo(pool *pgxpool.Pool) AuditRepository {
+	return &auditRepo{db: pool}
+}
+
+func (r *auditRepo) CreateEntry(ctx context.Context, entry models.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, organization_id, action, target_type, target_id,
+			changes, details, ip, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	var changes, details any
+	if len(entry.Changes) > 0 {
+		changes = entry.Changes
+	}
+	if len(entry.Details) > 0 {
+		details = entry.Details
+	}
+
+	_, err := r.db.Exec(ctx, query, entry.ActorID, entry.OrganizationID, entry.Action,
+		entry.TargetType, entry.TargetID, changes, details, entry.IP, entry.UserAgent)
+	return err
+}
+
+// GetEntries returns matching entries newest first. Paging goes by id, pass
+// the smallest id of a page as BeforeID to get the next one.
+func (r *auditRepo) GetEntries(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.OrganizationID != nil {
+		add("organization_id = $%d", *filter.OrganizationID)
+	}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID, models.AuditTargetUser)
+		conds = append(conds, fmt.Sprintf("(actor_id = $%d OR (target_type = $%d AND target_id = $%d))",
+			len(args)-1, len(args), len(args)-1))
+	}
+	if filter.ActorID != nil {
+		add("actor_id = $%d", *filter.ActorID)
+	}
+	if prefix, ok := strings.CutSuffix(filter.Action, "*"); ok {
+		add("starts_with(action, $%d)", prefix)
+	} else if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		add("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		add("target_id = $%d", *filter.TargetID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+
+	query := `
+		SELECT id, created_at, actor_id, organization_id, action, target_type, target_id,
+			changes, details, ip, user_agent
+		FROM audit_log`
+	if len(conds) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf("\n\t\tORDER BY id DESC\n\t\tLIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var entry models.AuditEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.CreatedAt,
+			&entry.ActorID,
+			&entry.OrganizationID,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&entry.Changes,
+			&entry.Details,
+			&entry.IP,
+			&entry.UserAgent,
+		); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *auditRepo) DeleteEntriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM audit_log WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmdTag.RowsAffected(), nil
+}
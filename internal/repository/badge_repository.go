@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type badgeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBadgeRepo(pool *pgxpool.Pool) BadgeRepository {
+	return &badgeRepo{db: pool}
+}
+
+const selectBadges = `SELECT monitor_id, token, created_at FROM monitor_badges`
+
+// SaveBadge enables the badges of a monitor, replacing an earlier token.
+func (r *badgeRepo) SaveBadge(ctx context.Context, badge models.Badge) error {
+	query := `
+		INSERT INTO monitor_badges (monitor_id, token)
+		VALUES ($1, $2)
+		ON CONFLICT (monitor_id) DO UPDATE SET token = EXCLUDED.token, created_at = now()`
+
+	_, err := r.db.Exec(ctx, query, badge.MonitorID, badge.Token)
+	return err
+}
+
+func (r *badgeRepo) GetBadge(ctx context.Context, monitorID int64) (*models.Badge, error) {
+	return scanBadge(r.db.QueryRow(ctx, selectBadges+` WHERE monitor_id = $1`, monitorID))
+}
+
+func (r *badgeRepo) GetBadgeByToken(ctx context.Context, token string) (*models.Badge, error) {
+	return scanBadge(r.db.QueryRow(ctx, selectBadges+` WHERE token = $1`, token))
+}
+
+func (r *badgeRepo) DeleteBadge(ctx context.Context, monitorID int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM monitor_badges WHERE monitor_id = $1`, monitorID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrBadgeNotFound
+	}
+
+	return nil
+}
+
+func scanBadge(row pgx.Row) (*models.Badge, error) {
+	var badge models.Badge
+	err := row.Scan(&badge.MonitorID, &badge.Token, &badge.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrBadgeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &badge, nil
+}
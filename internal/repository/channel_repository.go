@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type channelRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewChannelRepo(pool *pgxpool.Pool) ChannelRepository {
+	return &channelRepo{db: pool}
+}
+
+const selectChannels = `
+	SELECT id, organization_id, name, type, config, created_at
+	FROM notification_channels`
+
+func (r *channelRepo) CreateChannel(ctx context.Context, channel models.NotificationChannel) (int64, error) {
+	query := `
+		INSERT INTO notification_channels (organization_id, name, type, config)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	var id int64
+	err := r.db.QueryRow(ctx, query, channel.OrganizationID, channel.Name,
+		channel.Type, channel.Config).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *channelRepo) GetChannel(ctx context.Context, id int64) (*models.NotificationChannel, error) {
+	channel, err := scanChannel(r.db.QueryRow(ctx, selectChannels+`
+		WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func (r *channelRepo) GetOrganizationChannels(ctx context.Context, orgID int64) ([]models.NotificationChannel, error) {
+	return r.queryChannels(ctx, selectChannels+`
+		WHERE organization_id = $1
+		ORDER BY id`, orgID)
+}
+
+// GetChannels returns the channels of the organization among ids. Unknown
+// IDs are skipped.
+func (r *channelRepo) GetChannels(ctx context.Context, orgID int64, ids []int64) ([]models.NotificationChannel, error) {
+	return r.queryChannels(ctx, selectChannels+`
+		WHERE organization_id = $1 AND id = ANY($2)
+		ORDER BY id`, orgID, ids)
+}
+
+func (r *channelRepo) UpdateChannel(ctx context.Context, channel models.NotificationChannel) error {
+	query := `
+		UPDATE notification_channels
+		SET name = $1, type = $2, config = $3
+		WHERE id = $4`
+
+	cmdTag, err := r.db.Exec(ctx, query, channel.Name, channel.Type, channel.Config, channel.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrChannelNotFound
+	}
+
+	return nil
+}
+
+func (r *channelRepo) DeleteChannel(ctx context.Context, id int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM notification_channels WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrChannelNotFound
+	}
+
+	return nil
+}
+
+func (r *channelRepo) queryChannels(ctx context.Context, query string, args ...any) ([]models.NotificationChannel, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []models.NotificationChannel{}
+	for rows.Next() {
+		channel, err := scanChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		channels = append(channels, *channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return channels, nil
+}
+
+func scanChannel(row pgx.Row) (*models.NotificationChannel, error) {
+	var channel models.NotificationChannel
+	err := row.Scan(
+		&channel.ID,
+		&channel.OrganizationID,
+		&channel.Name,
+		&channel.Type,
+		&channel.Config,
+		&channel.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channel, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type checkRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCheckRepo(pool *pgxpool.Pool) CheckRepository {
+	return &checkRepo{db: pool}
+}
+
+func (r *checkRepo) CreateResult(ctx context.Context, result models.CheckResult) (int64, error) {
+	query := `
+		INSERT INTO check_results (monitor_id, checked_at, status, status_code,
+			response_time_ms, error, maintenance)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	var id int64
+	err := r.db.QueryRow(ctx, query, result.MonitorID, result.CheckedAt, result.Status,
+		result.StatusCode, result.ResponseTimeMs, result.Error, result.Maintenance).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetLatestStatuses returns the status of the most recent check of each
+// monitor. Monitors that were never checked are missing from the result.
+func (r *checkRepo) GetLatestStatuses(ctx context.Context, monitorIDs []int64) (map[int64]string, error) {
+	query := `
+		SELECT DISTINCT ON (monitor_id) monitor_id, status
+		FROM check_results
+		WHERE monitor_id = ANY($1)
+		ORDER BY monitor_id, checked_at DESC`
+
+	rows, err := r.db.Query(ctx, query, monitorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := make(map[int64]string, len(monitorIDs))
+	for rows.Next() {
+		var monitorID int64
+		var status string
+		if err := rows.Scan(&monitorID, &status); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		statuses[monitorID] = status
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// CountStateChanges counts how often the monitor switched between up and down
+// in the results checked since then. Unreachable results don't say anything
+// about the monitor itself and are skipped.
+func (r *checkRepo) CountStateChanges(ctx context.Context, monitorID int64, since time.Time) (int, error) {
+	query := `
+		SELECT count(*)
+		FROM (
+			SELECT status, lag(status) OVER (ORDER BY checked_at) AS previous
+			FROM check_results
+			WHERE monitor_id = $1 AND checked_at >= $2 AND status <> $3
+		) r
+		WHERE status <> previous`
+
+	var changes int
+	err := r.db.QueryRow(ctx, query, monitorID, since, models.CheckUnreachable).Scan(&changes)
+	if err != nil {
+		return 0, err
+	}
+
+	return changes, nil
+}
+
+// GetDailyUptime returns the checks of each monitor since then, counted per
+// UTC day in chronological order. Days without checks are left out.
+func (r *checkRepo) GetDailyUptime(ctx context.Context, monitorIDs []int64,
+	since time.Time) (map[int64][]models.DailyUptime, error) {
+
+	query := `
+		SELECT monitor_id, date_trunc('day', checked_at AT TIME ZONE 'UTC') AS day,
+			count(*), count(*) FILTER (WHERE status = $3 OR maintenance)
+		FROM check_results
+		WHERE monitor_id = ANY($1) AND checked_at >= $2
+		GROUP BY monitor_id, day
+		ORDER BY monitor_id, day`
+
+	rows, err := r.db.Query(ctx, query, monitorIDs, since, models.CheckUp)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	uptime := make(map[int64][]models.DailyUptime, len(monitorIDs))
+	for rows.Next() {
+		var monitorID int64
+		var day models.DailyUptime
+		if err := rows.Scan(&monitorID, &day.Day, &day.Checks, &day.Up); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		uptime[monitorID] = append(uptime[monitorID], day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return uptime, nil
+}
+
+// GetAverageResponseTime returns the mean response time of the successful
+// checks of the monitor since then, or nil if there were none.
+func (r *checkRepo) GetAverageResponseTime(ctx context.Context, monitorID int64, since time.Time) (*int64, error) {
+	query := `
+		SELECT round(avg(response_time_ms))::BIGINT
+		FROM check_results
+		WHERE monitor_id = $1 AND checked_at >= $2 AND status = $3`
+
+	var average *int64
+	err := r.db.QueryRow(ctx, query, monitorID, since, models.CheckUp).Scan(&average)
+	if err != nil {
+		return nil, err
+	}
+
+	return average, nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type escalationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewEscalationRepo(pool *pgxpool.Pool) EscalationRepository {
+	return &escalationRepo{db: pool}
+}
+
+const selectPolicies = `
+	SELECT id, organization_id, name, levels, created_at
+	FROM escalation_policies`
+
+func (r *escalationRepo) CreatePolicy(ctx context.Context, policy models.EscalationPolicy) (int64, error) {
+	query := `
+		INSERT INTO escalation_policies (organization_id, name, levels)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	var id int64
+	err := r.db.QueryRow(ctx, query, policy.OrganizationID, policy.Name, policy.Levels).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *escalationRepo) GetPolicy(ctx context.Context, id int64) (*models.EscalationPolicy, error) {
+	policy, err := scanPolicy(r.db.QueryRow(ctx, selectPolicies+`
+		WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrEscalationPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (r *escalationRepo) GetOrganizationPolicies(ctx context.Context, orgID int64) ([]models.EscalationPolicy, error) {
+	rows, err := r.db.Query(ctx, selectPolicies+`
+		WHERE organization_id = $1
+		ORDER BY id`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []models.EscalationPolicy{}
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		policies = append(policies, *policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (r *escalationRepo) UpdatePolicy(ctx context.Context, policy models.EscalationPolicy) error {
+	query := `
+		UPDATE escalation_policies
+		SET name = $1, levels = $2
+		WHERE id = $3`
+
+	cmdTag, err := r.db.Exec(ctx, query, policy.Name, policy.Levels, policy.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrEscalationPolicyNotFound
+	}
+
+	return nil
+}
+
+func (r *escalationRepo) DeletePolicy(ctx context.Context, id int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM escalation_policies WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrEscalationPolicyNotFound
+	}
+
+	return nil
+}
+
+// StartEscalation schedules the levels of policy for an open incident, with
+// delays counted from startedAt.
+func (r *escalationRepo) StartEscalation(ctx context.Context, incidentID, policyID int64, startedAt time.Time) error {
+	query := `
+		UPDATE incidents i
+		SET escalation_policy_id = p.id, escalation_level = 0, escalation_started_at = $1,
+			next_escalation_at = $1 + make_interval(mins => (p.levels -> 0 ->> 'delay_minutes')::int)
+		FROM escalation_policies p
+		WHERE p.id = $2 AND i.id = $3 AND i.resolved_at IS NULL`
+
+	_, err := r.db.Exec(ctx, query, startedAt, policyID, incidentID)
+	return err
+}
+
+// ClaimDueEscalations moves every due incident to its next level and returns
+// the levels that have to be notified now. Claiming and notifying are not
+// atomic: a crash in between skips that level rather than sending it twice.
+func (r *escalationRepo) ClaimDueEscalations(ctx context.Context, now time.Time, limit int) ([]models.EscalationStep, error) {
+	query := `
+		UPDATE incidents i
+		SET escalation_level = i.escalation_level + 1,
+			next_escalation_at = i.escalation_started_at + make_interval(
+				mins => (p.levels -> (i.escalation_level + 1) ->> 'delay_minutes')::int)
+		FROM escalation_policies p
+		WHERE p.id = i.escalation_policy_id
+			AND i.id IN (
+				SELECT id FROM incidents
+				WHERE next_escalation_at <= $1
+					AND resolved_at IS NULL AND acknowledged_at IS NULL
+					AND escalation_policy_id IS NOT NULL
+				ORDER BY next_escalation_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED)
+		RETURNING i.id, i.monitor_id, i.started_at, i.cause, i.escalation_policy_id,
+			i.escalation_level, i.next_escalation_at,
+			COALESCE(p.levels -> (i.escalation_level - 1) -> 'channel_ids', '[]')`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []models.EscalationStep
+	for rows.Next() {
+		var step models.EscalationStep
+		if err := rows.Scan(
+			&step.Incident.ID,
+			&step.Incident.MonitorID,
+			&step.Incident.StartedAt,
+			&step.Incident.Cause,
+			&step.Incident.EscalationPolicyID,
+			&step.Incident.EscalationLevel,
+			&step.Incident.NextEscalationAt,
+			&step.ChannelIDs,
+		); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		step.Level = step.Incident.EscalationLevel - 1
+		steps = append(steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return steps, nil
+}
+
+func scanPolicy(row pgx.Row) (*models.EscalationPolicy, error) {
+	var policy models.EscalationPolicy
+	err := row.Scan(
+		&policy.ID,
+		&policy.OrganizationID,
+		&policy.Name,
+		&policy.Levels,
+		&policy.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type feedTokenRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewFeedTokenRepo(pool *pgxpool.Pool) FeedTokenRepository {
+	return &feedTokenRepo{db: pool}
+}
+
+// SaveFeedToken stores the token of a user, replacing an earlier one.
+func (r *feedTokenRepo) SaveFeedToken(ctx context.Context, userID int64, tokenHash string) error {
+	query := `
+		INSERT INTO feed_tokens (user_id, token_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = now()`
+
+	_, err := r.db.Exec(ctx, query, userID, tokenHash)
+	return err
+}
+
+func (r *feedTokenRepo) GetFeedTokenUser(ctx context.Context, tokenHash string) (int64, error) {
+	var userID int64
+	err := r.db.QueryRow(ctx, `SELECT user_id FROM feed_tokens WHERE token_hash = $1`, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, errs.ErrFeedNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func (r *feedTokenRepo) DeleteFeedToken(ctx context.Context, userID int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM feed_tokens WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrFeedNotFound
+	}
+
+	return nil
+}
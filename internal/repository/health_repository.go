@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type healthRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewHealthRepo(pool *pgxpool.Pool) HealthRepository {
+	return &healthRepo{db: pool}
+}
+
+func (r *healthRepo) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+// SchemaVersion reads the table the migrate tool keeps its state in.
+func (r *healthRepo) SchemaVersion(ctx context.Context) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := r.db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, errs.ErrNoMigrations
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
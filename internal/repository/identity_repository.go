@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type identityRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewIdentityRepo(pool *pgxpool.Pool) IdentityRepository {
+	return &identityRepo{db: pool}
+}
+
+func (r *identityRepo) CreateLoginState(ctx context.Context, state models.OIDCLoginState) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	// abandoned logins are cleaned up whenever a new one starts
+	_, err = tx.Exec(ctx, `DELETE FROM oidc_login_states WHERE expires_at <= now()`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, fingerprint, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		state.StateHash, state.Nonce, state.CodeVerifier, state.Fingerprint, state.ExpiresAt)
+
+	return err
+}
+
+// ConsumeLoginState removes the state and returns it, so every state can
+// complete one login at most.
+func (r *identityRepo) ConsumeLoginState(ctx context.Context, stateHash string) (*models.OIDCLoginState, error) {
+	var state models.OIDCLoginState
+	query := `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1 AND expires_at > now()
+		RETURNING state_hash, nonce, code_verifier, fingerprint, expires_at`
+
+	err := r.db.QueryRow(ctx, query, stateHash).Scan(
+		&state.StateHash,
+		&state.Nonce,
+		&state.CodeVerifier,
+		&state.Fingerprint,
+		&state.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrSSOStateInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (r *identityRepo) GetIdentity(ctx context.Context, issuer, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	query := `
+		SELECT id, user_id, issuer, subject, COALESCE(email, ''), created_at, last_login_at
+		FROM user_identities
+		WHERE issuer = $1 AND subject = $2`
+
+	err := r.db.QueryRow(ctx, query, issuer, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Issuer,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *identityRepo) CreateIdentity(ctx context.Context, identity models.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, issuer, subject, email)
+		VALUES ($1, $2, $3, NULLIF($4, ''))`
+
+	_, err := r.db.Exec(ctx, query, identity.UserID, identity.Issuer, identity.Subject, identity.Email)
+	return err
+}
+
+func (r *identityRepo) TouchIdentity(ctx context.Context, id int64, email string) error {
+	query := `
+		UPDATE user_identities
+		SET last_login_at = now(), email = NULLIF($1, '')
+		WHERE id = $2`
+
+	_, err := r.db.Exec(ctx, query, email, id)
+	return err
+}
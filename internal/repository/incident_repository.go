@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type incidentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewIncidentRepo(pool *pgxpool.Pool) IncidentRepository {
+	return &incidentRepo{db: pool}
+}
+
+const selectIncidents = `
+	SELECT id, monitor_id, started_at, resolved_at, cause, maintenance, flapping,
+		acknowledged_at, acknowledged_by, escalation_policy_id, escalation_level, next_escalation_at
+	FROM incidents`
+
+func (r *incidentRepo) CreateIncident(ctx context.Context, incident models.Incident) (int64, error) {
+	query := `
+		INSERT INTO incidents (monitor_id, started_at, cause, maintenance, flapping)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	var id int64
+	err := r.db.QueryRow(ctx, query, incident.MonitorID, incident.StartedAt,
+		incident.Cause, incident.Maintenance, incident.Flapping).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *incidentRepo) GetIncident(ctx context.Context, id int64) (*models.Incident, error) {
+	incident, err := scanIncident(r.db.QueryRow(ctx, selectIncidents+`
+		WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+func (r *incidentRepo) GetOpenIncident(ctx context.Context, monitorID int64) (*models.Incident, error) {
+	incident, err := scanIncident(r.db.QueryRow(ctx, selectIncidents+`
+		WHERE monitor_id = $1 AND resolved_at IS NULL`, monitorID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+func (r *incidentRepo) GetMonitorIncidents(ctx context.Context, monitorID int64, limit int) ([]models.Incident, error) {
+	rows, err := r.db.Query(ctx, selectIncidents+`
+		WHERE monitor_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2`, monitorID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []models.Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		incidents = append(incidents, *incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// GetRecentIncidents returns the incidents of the monitors that changed
+// last, leaving out those that began during maintenance.
+func (r *incidentRepo) GetRecentIncidents(ctx context.Context, monitorIDs []int64, limit int) ([]models.Incident, error) {
+	rows, err := r.db.Query(ctx, selectIncidents+`
+		WHERE monitor_id = ANY($1) AND NOT maintenance
+		ORDER BY GREATEST(started_at, acknowledged_at, resolved_at) DESC, id DESC
+		LIMIT $2`, monitorIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []models.Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		incidents = append(incidents, *incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return incidents, nil
+}
+
+func (r *incidentRepo) GetOpenIncidents(ctx context.Context, monitorIDs []int64) ([]models.Incident, error) {
+	rows, err := r.db.Query(ctx, selectIncidents+`
+		WHERE monitor_id = ANY($1) AND resolved_at IS NULL
+		ORDER BY started_at DESC`, monitorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []models.Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		incidents = append(incidents, *incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// ResolveIncident closes an open incident and stops its escalation.
+func (r *incidentRepo) ResolveIncident(ctx context.Context, id int64, resolvedAt time.Time) error {
+	query := `
+		UPDATE incidents
+		SET resolved_at = $1, next_escalation_at = NULL
+		WHERE id = $2 AND resolved_at IS NULL`
+
+	cmdTag, err := r.db.Exec(ctx, query, resolvedAt, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrIncidentNotFound
+	}
+
+	return nil
+}
+
+// AcknowledgeIncident stops the escalation of an open incident. It returns
+// ErrIncidentResolved or ErrIncidentAcknowledged when there is nothing to stop.
+func (r *incidentRepo) AcknowledgeIncident(ctx context.Context, id, userID int64, at time.Time) (*models.Incident, error) {
+	incident, err := scanIncident(r.db.QueryRow(ctx, `
+		UPDATE incidents
+		SET acknowledged_at = $1, acknowledged_by = $2, next_escalation_at = NULL
+		WHERE id = $3 AND resolved_at IS NULL AND acknowledged_at IS NULL
+		RETURNING id, monitor_id, started_at, resolved_at, cause, maintenance, flapping,
+			acknowledged_at, acknowledged_by, escalation_policy_id, escalation_level, next_escalation_at`,
+		at, userID, id))
+	if err == nil {
+		return incident, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	current, err := r.GetIncident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.ResolvedAt != nil {
+		return nil, errs.ErrIncidentResolved
+	}
+
+	return nil, errs.ErrIncidentAcknowledged
+}
+
+func (r *incidentRepo) SetIncidentMaintenance(ctx context.Context, id int64, maintenance bool) error {
+	_, err := r.db.Exec(ctx, `UPDATE incidents SET maintenance = $1 WHERE id = $2`, maintenance, id)
+	return err
+}
+
+func scanIncident(row pgx.Row) (*models.Incident, error) {
+	var incident models.Incident
+	err := row.Scan(
+		&incident.ID,
+		&incident.MonitorID,
+		&incident.StartedAt,
+		&incident.ResolvedAt,
+		&incident.Cause,
+		&incident.Maintenance,
+		&incident.Flapping,
+		&incident.AcknowledgedAt,
+		&incident.AcknowledgedBy,
+		&incident.EscalationPolicyID,
+		&incident.EscalationLevel,
+		&incident.NextEscalationAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &incident, nil
+}
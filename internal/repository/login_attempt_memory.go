@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// memoryRetention bounds how long failures are kept by the in-memory store.
+// It has to cover the longest throttling window in use.
+const memoryRetention = 24 * time.Hour
+
+type memoryLoginAttemptRepo struct {
+	mutex     sync.Mutex
+	usernames map[string][]time.Time
+	ips       map[string][]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryLoginAttemptRepo keeps failure history in process memory. It is
+// meant for single-instance deployments; use the Postgres store otherwise.
+func NewMemoryLoginAttemptRepo() LoginAttemptRepository {
+	return &memoryLoginAttemptRepo{
+		usernames: make(map[string][]time.Time),
+		ips:       make(map[string][]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *memoryLoginAttemptRepo) RecordAttempt(ctx context.Context, attempt models.LoginAttempt) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	at := attempt.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if attempt.Success {
+		delete(r.usernames, attempt.Username)
+	} else {
+		r.usernames[attempt.Username] = append(r.usernames[attempt.Username], at)
+		r.ips[attempt.IP] = append(r.ips[attempt.IP], at)
+	}
+
+	if at.Sub(r.lastSweep) > time.Minute {
+		r.sweep(at.Add(-memoryRetention))
+		r.lastSweep = at
+	}
+
+	return nil
+}
+
+func (r *memoryLoginAttemptRepo) GetUsernameFailures(ctx context.Context, username string, since time.Time) (models.FailureStats, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return failureStats(r.usernames[username], since), nil
+}
+
+func (r *memoryLoginAttemptRepo) GetIPFailures(ctx context.Context, ip string, since time.Time) (models.FailureStats, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return failureStats(r.ips[ip], since), nil
+}
+
+func (r *memoryLoginAttemptRepo) DeleteAttemptsBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.sweep(before), nil
+}
+
+// sweep drops failures older than before and returns how many it dropped.
+// Every failure is kept per IP, so those are the ones counted.
+func (r *memoryLoginAttemptRepo) sweep(before time.Time) int64 {
+	prune(r.usernames, before)
+	return prune(r.ips, before)
+}
+
+func prune(m map[string][]time.Time, before time.Time) int64 {
+	var deleted int64
+	for key, failures := range m {
+		kept := failures[:0]
+		for _, at := range failures {
+			if !at.Before(before) {
+				kept = append(kept, at)
+			}
+		}
+		deleted += int64(len(failures) - len(kept))
+
+		if len(kept) == 0 {
+			delete(m, key)
+		} else {
+			m[key] = kept
+		}
+	}
+
+	return deleted
+}
+
+func failureStats(failures []time.Time, since time.Time) models.FailureStats {
+	var stats models.FailureStats
+	for _, at := range failures {
+		if !at.After(since) {
+			continue
+		}
+
+		stats.Count++
+		if at.After(stats.LastFailure) {
+			stats.LastFailure = at
+		}
+	}
+
+	return stats
+}
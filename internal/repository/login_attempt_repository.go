@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type loginAttemptRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginAttemptRepo(pool *pgxpool.Pool) LoginAttemptRepository {
+	return &loginAttemptRepo{db: pool}
+}
+
+func (r *loginAttemptRepo) RecordAttempt(ctx context.Context, attempt models.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (username, ip, success)
+		VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(ctx, query, attempt.Username, attempt.IP, attempt.Success)
+	return err
+}
+
+// GetUsernameFailures counts failures since the later of since and the last
+// successful login, so a correct password resets the counter.
+func (r *loginAttemptRepo) GetUsernameFailures(ctx context.Context, username string, since time.Time) (models.FailureStats, error) {
+	var stats models.FailureStats
+	var last *time.Time
+
+	query := `
+		SELECT count(*), max(created_at)
+		FROM login_attempts
+		WHERE username = $1 AND NOT success
+			AND created_at > GREATEST($2, (
+				SELECT COALESCE(max(created_at), $2)
+				FROM login_attempts
+				WHERE username = $1 AND success
+			))`
+
+	err := r.db.QueryRow(ctx, query, username, since).Scan(&stats.Count, &last)
+	if err != nil {
+		return stats, err
+	}
+
+	if last != nil {
+		stats.LastFailure = *last
+	}
+
+	return stats, nil
+}
+
+func (r *loginAttemptRepo) GetIPFailures(ctx context.Context, ip string, since time.Time) (models.FailureStats, error) {
+	var stats models.FailureStats
+	var last *time.Time
+
+	query := `
+		SELECT count(*), max(created_at)
+		FROM login_attempts
+		WHERE ip = $1 AND NOT success AND created_at > $2`
+
+	err := r.db.QueryRow(ctx, query, ip, since).Scan(&stats.Count, &last)
+	if err != nil {
+		return stats, err
+	}
+
+	if last != nil {
+		stats.LastFailure = *last
+	}
+
+	return stats, nil
+}
+
+func (r *loginAttemptRepo) DeleteAttemptsBefore(ctx context.Context, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM login_attempts WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmdTag.RowsAffected(), nil
+}
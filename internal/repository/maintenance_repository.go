@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type maintenanceRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMaintenanceRepo(pool *pgxpool.Pool) MaintenanceRepository {
+	return &maintenanceRepo{db: pool}
+}
+
+const selectMaintenanceWindows = `
+	SELECT w.id, w.organization_id, w.title, w.description, w.starts_at, w.ends_at,
+		w.recurrence, w.duration_minutes, w.timezone, w.created_by, w.created_at,
+		COALESCE(array_agg(wm.monitor_id ORDER BY wm.monitor_id)
+			FILTER (WHERE wm.monitor_id IS NOT NULL), '{}')
+	FROM maintenance_windows w
+	LEFT JOIN maintenance_window_monitors wm ON wm.window_id = w.id`
+
+func (r *maintenanceRepo) CreateWindow(ctx context.Context, window models.MaintenanceWindow) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO maintenance_windows (organization_id, title, description, starts_at, ends_at,
+			recurrence, duration_minutes, timezone, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`, window.OrganizationID, window.Title, window.Description, window.StartsAt,
+		window.EndsAt, window.Recurrence, window.DurationMinutes, window.Timezone,
+		window.CreatedBy).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	err = linkWindowMonitors(ctx, tx, id, window.OrganizationID, window.MonitorIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *maintenanceRepo) GetWindow(ctx context.Context, id int64) (*models.MaintenanceWindow, error) {
+	row := r.db.QueryRow(ctx, selectMaintenanceWindows+`
+		WHERE w.id = $1
+		GROUP BY w.id`, id)
+
+	window, err := scanMaintenanceWindow(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrMaintenanceWindowNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (r *maintenanceRepo) GetOrganizationWindows(ctx context.Context, orgID int64) ([]models.MaintenanceWindow, error) {
+	return r.queryWindows(ctx, selectMaintenanceWindows+`
+		WHERE w.organization_id = $1
+		GROUP BY w.id
+		ORDER BY w.starts_at DESC`, orgID)
+}
+
+// GetMonitorWindows returns the windows of a monitor that may be in effect at
+// the given time. Windows whose series has already ended are skipped.
+func (r *maintenanceRepo) GetMonitorWindows(ctx context.Context, monitorID int64, at time.Time) ([]models.MaintenanceWindow, error) {
+	return r.queryWindows(ctx, selectMaintenanceWindows+`
+		WHERE w.id IN (SELECT window_id FROM maintenance_window_monitors WHERE monitor_id = $1)
+			AND w.starts_at <= $2
+			AND (w.ends_at IS NULL OR w.ends_at + make_interval(mins => w.duration_minutes) > $2)
+		GROUP BY w.id`, monitorID, at)
+}
+
+func (r *maintenanceRepo) UpdateWindow(ctx context.Context, window models.MaintenanceWindow) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE maintenance_windows
+		SET title = $1, description = $2, starts_at = $3, ends_at = $4,
+			recurrence = $5, duration_minutes = $6, timezone = $7
+		WHERE id = $8`, window.Title, window.Description, window.StartsAt, window.EndsAt,
+		window.Recurrence, window.DurationMinutes, window.Timezone, window.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		err = errs.ErrMaintenanceWindowNotFound
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM maintenance_window_monitors WHERE window_id = $1`, window.ID)
+	if err != nil {
+		return err
+	}
+
+	err = linkWindowMonitors(ctx, tx, window.ID, window.OrganizationID, window.MonitorIDs)
+	return err
+}
+
+func (r *maintenanceRepo) DeleteWindow(ctx context.Context, id int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM maintenance_windows WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrMaintenanceWindowNotFound
+	}
+
+	return nil
+}
+
+// linkWindowMonitors attaches monitors to a window. Monitors of other
+// organizations are not linked and make it fail with ErrMonitorNotFound.
+func linkWindowMonitors(ctx context.Context, tx pgx.Tx, windowID, orgID int64, monitorIDs []int64) error {
+	cmdTag, err := tx.Exec(ctx, `
+		INSERT INTO maintenance_window_monitors (window_id, monitor_id)
+		SELECT $1, id FROM monitors
+		WHERE id = ANY($2) AND organization_id = $3`, windowID, monitorIDs, orgID)
+	if err != nil {
+		return err
+	}
+
+	if int(cmdTag.RowsAffected()) != len(monitorIDs) {
+		return errs.ErrMonitorNotFound
+	}
+
+	return nil
+}
+
+func (r *maintenanceRepo) queryWindows(ctx context.Context, query string, args ...any) ([]models.MaintenanceWindow, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	windows := []models.MaintenanceWindow{}
+	for rows.Next() {
+		window, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		windows = append(windows, *window)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return windows, nil
+}
+
+func scanMaintenanceWindow(row pgx.Row) (*models.MaintenanceWindow, error) {
+	var window models.MaintenanceWindow
+	err := row.Scan(
+		&window.ID,
+		&window.OrganizationID,
+		&window.Title,
+		&window.Description,
+		&window.StartsAt,
+		&window.EndsAt,
+		&window.Recurrence,
+		&window.DurationMinutes,
+		&window.Timezone,
+		&window.CreatedBy,
+		&window.CreatedAt,
+		&window.MonitorIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &window, nil
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	pgx "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 )
 
 type monitorRepo struct {
@@ -33,8 +36,9 @@ func (r *monitorRepo) CreateMonitor(ctx context.Context, monitor models.Monitor)
 	}()
 
 	queryMonitors := `
-		INSERT INTO monitors (user_id, name, type, target, timeout, interval, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO monitors (organization_id, user_id, name, type, target, timeout, interval, is_active,
+			escalation_policy_id, flap_threshold, flap_window_minutes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id`
 
 	queryMonitorSpec := `
@@ -43,11 +47,17 @@ func (r *monitorRepo) CreateMonitor(ctx context.Context, monitor models.Monitor)
 		RETURNING id
 	`
 
+	err = checkEscalationPolicy(ctx, tx, monitor.OrganizationID, monitor.EscalationPolicyID)
+	if err != nil {
+		return 0, err
+	}
+
 	var id int64
 	err = tx.QueryRow(ctx, queryMonitors,
-		monitor.UserID, monitor.Name, monitor.Type,
+		monitor.OrganizationID, monitor.UserID, monitor.Name, monitor.Type,
 		monitor.Target, monitor.Timeout, monitor.Interval,
-		monitor.IsActive).Scan(&id)
+		monitor.IsActive, monitor.EscalationPolicyID,
+		monitor.FlapThreshold, monitor.FlapWindowMinutes).Scan(&id)
 
 	if err != nil {
 		return 0, err
@@ -61,23 +71,30 @@ func (r *monitorRepo) CreateMonitor(ctx context.Context, monitor models.Monitor)
 		return 0, err
 	}
 
+	err = linkParentMonitors(ctx, tx, id, monitor.OrganizationID, monitor.ParentIDs)
+	if err != nil {
+		return 0, err
+	}
+
 	return id, nil
 }
 
 func (r *monitorRepo) GetMonitor(ctx context.Context, id int64) (*models.Monitor, error) {
 
 	queryMonitors := ` 
-		SELECT m.id, m.user_id, m.name, m.type, m.target, m.timeout, m.interval, 
-			m.is_active, m.last_checked_at,
-			s.request, s.expected_response
+		SELECT m.id, m.organization_id, COALESCE(m.user_id, 0), m.name, m.type, m.target, m.timeout, m.interval, 
+			m.is_active, m.last_checked_at, m.escalation_policy_id, m.flap_threshold, m.flap_window_minutes,
+			s.request, s.expected_response,
+			ARRAY(SELECT parent_id FROM monitor_dependencies WHERE monitor_id = m.id ORDER BY parent_id)
 		FROM monitors m
 		JOIN monitor_specs s ON m.id = s.monitor_id
-		WHERE id = $1
+		WHERE m.id = $1
 	`
 
 	var monitor models.Monitor
 	err := r.db.QueryRow(ctx, queryMonitors, id).Scan(
 		&monitor.ID,
+		&monitor.OrganizationID,
 		&monitor.UserID,
 		&monitor.Name,
 		&monitor.Type,
@@ -86,36 +103,43 @@ func (r *monitorRepo) GetMonitor(ctx context.Context, id int64) (*models.Monitor
 		&monitor.Interval,
 		&monitor.IsActive,
 		&monitor.LastCheckedAt,
+		&monitor.EscalationPolicyID,
+		&monitor.FlapThreshold,
+		&monitor.FlapWindowMinutes,
 		&monitor.RequestSpec,
-		&monitor.ExpectedResponse)
+		&monitor.ExpectedResponse,
+		&monitor.ParentIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	return &monitor, nil
 }
-func (r *monitorRepo) GetAllUserMonitors(ctx context.Context, userID int64) ([]models.Monitor, error) {
+func (r *monitorRepo) GetAllOrganizationMonitors(ctx context.Context, orgID int64) ([]models.Monitor, error) {
 
 	query := ` 
 		SELECT 
-			m.id, m.user_id, m.name, m.type, m.target, m.timeout, m.interval, 
-			m.is_active, m.last_checked_at,
-			s.request, s.expected_response
+			m.id, m.organization_id, COALESCE(m.user_id, 0), m.name, m.type, m.target, m.timeout, m.interval, 
+			m.is_active, m.last_checked_at, m.escalation_policy_id, m.flap_threshold, m.flap_window_minutes,
+			s.request, s.expected_response,
+			ARRAY(SELECT parent_id FROM monitor_dependencies WHERE monitor_id = m.id ORDER BY parent_id)
 		FROM monitors m
 		JOIN monitor_specs s ON s.monitor_id = m.id
-		WHERE m.user_id = $1
+		WHERE m.organization_id = $1
 	`
 
 	var monitors []models.Monitor
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := r.db.Query(ctx, query, orgID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var monitor models.Monitor
 		err = rows.Scan(
 			&monitor.ID,
+			&monitor.OrganizationID,
 			&monitor.UserID,
 			&monitor.Name,
 			&monitor.Type,
@@ -124,8 +148,12 @@ func (r *monitorRepo) GetAllUserMonitors(ctx context.Context, userID int64) ([]m
 			&monitor.Interval,
 			&monitor.IsActive,
 			&monitor.LastCheckedAt,
+			&monitor.EscalationPolicyID,
+			&monitor.FlapThreshold,
+			&monitor.FlapWindowMinutes,
 			&monitor.RequestSpec,
-			&monitor.ExpectedResponse)
+			&monitor.ExpectedResponse,
+			&monitor.ParentIDs)
 
 		if err != nil {
 			return nil, err
@@ -134,28 +162,35 @@ func (r *monitorRepo) GetAllUserMonitors(ctx context.Context, userID int64) ([]m
 		monitors = append(monitors, monitor)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
 	return monitors, nil
 }
 func (r *monitorRepo) GetAllActiveMonitors(ctx context.Context) ([]models.Monitor, error) {
 	query := `
-		SELECT m.id, m.user_id, m.name, m.type, m.target, m.timeout, m.interval, 
-			m.is_active, m.last_checked_at,
-			s.request, s.expected_response
+		SELECT m.id, m.organization_id, COALESCE(m.user_id, 0), m.name, m.type, m.target, m.timeout, m.interval, 
+			m.is_active, m.last_checked_at, m.escalation_policy_id, m.flap_threshold, m.flap_window_minutes,
+			s.request, s.expected_response,
+			ARRAY(SELECT parent_id FROM monitor_dependencies WHERE monitor_id = m.id ORDER BY parent_id)
 		FROM monitors m
 		JOIN monitor_specs s ON m.id = s.monitor_id
-		WHERE is_actuve = true 
+		WHERE m.is_active = true
 	`
 
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var monitors []models.Monitor
 	for rows.Next() {
 		var monitor models.Monitor
 		err = rows.Scan(
 			&monitor.ID,
+			&monitor.OrganizationID,
 			&monitor.UserID,
 			&monitor.Name,
 			&monitor.Type,
@@ -164,8 +199,12 @@ func (r *monitorRepo) GetAllActiveMonitors(ctx context.Context) ([]models.Monito
 			&monitor.Interval,
 			&monitor.IsActive,
 			&monitor.LastCheckedAt,
+			&monitor.EscalationPolicyID,
+			&monitor.FlapThreshold,
+			&monitor.FlapWindowMinutes,
 			&monitor.RequestSpec,
-			&monitor.ExpectedResponse)
+			&monitor.ExpectedResponse,
+			&monitor.ParentIDs)
 
 		if err != nil {
 			return nil, err
@@ -174,6 +213,10 @@ func (r *monitorRepo) GetAllActiveMonitors(ctx context.Context) ([]models.Monito
 		monitors = append(monitors, monitor)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
 	return monitors, nil
 }
 
@@ -192,10 +235,21 @@ func (r *monitorRepo) UpdateMonitor(ctx context.Context, monitor models.Monitor)
 
 	updateMonitorQuery := `
 		UPDATE monitors
-		SET name = $1, type = $2, target = $3, timeout = $4, interval = $5, is_active = $6
-		WHERE id = $7
+		SET name = $1, type = $2, target = $3, timeout = $4, interval = $5, is_active = $6,
+			escalation_policy_id = $7, flap_threshold = $8, flap_window_minutes = $9
+		WHERE id = $10
 	`
 
+	err = lockDependencies(ctx, tx, monitor.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	err = checkEscalationPolicy(ctx, tx, monitor.OrganizationID, monitor.EscalationPolicyID)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(ctx, updateMonitorQuery,
 		monitor.Name,
 		monitor.Type,
@@ -203,6 +257,9 @@ func (r *monitorRepo) UpdateMonitor(ctx context.Context, monitor models.Monitor)
 		monitor.Timeout,
 		monitor.Interval,
 		monitor.IsActive,
+		monitor.EscalationPolicyID,
+		monitor.FlapThreshold,
+		monitor.FlapWindowMinutes,
 		monitor.ID,
 	)
 	if err != nil {
@@ -224,7 +281,18 @@ func (r *monitorRepo) UpdateMonitor(ctx context.Context, monitor models.Monitor)
 		return err
 	}
 
-	return nil
+	_, err = tx.Exec(ctx, `DELETE FROM monitor_dependencies WHERE monitor_id = $1`, monitor.ID)
+	if err != nil {
+		return err
+	}
+
+	err = linkParentMonitors(ctx, tx, monitor.ID, monitor.OrganizationID, monitor.ParentIDs)
+	if err != nil {
+		return err
+	}
+
+	err = checkDependencyCycle(ctx, tx, monitor.ID)
+	return err
 }
 
 func (r *monitorRepo) UpdateLastCheckedAt(ctx context.Context, id int64, checkedAt time.Time) error {
@@ -241,3 +309,77 @@ func (r *monitorRepo) DeleteMonitor(ctx context.Context, id int64) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM monitors WHERE id = $1`, id)
 	return err
 }
+
+// linkParentMonitors records the parents of a monitor. Parents of other
+// organizations are not linked and make it fail with ErrMonitorNotFound.
+func linkParentMonitors(ctx context.Context, tx pgx.Tx, monitorID, orgID int64, parentIDs []int64) error {
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	cmdTag, err := tx.Exec(ctx, `
+		INSERT INTO monitor_dependencies (monitor_id, parent_id)
+		SELECT $1, id FROM monitors
+		WHERE id = ANY($2) AND organization_id = $3`, monitorID, parentIDs, orgID)
+	if err != nil {
+		return err
+	}
+
+	if int(cmdTag.RowsAffected()) != len(parentIDs) {
+		return errs.ErrMonitorNotFound
+	}
+
+	return nil
+}
+
+// lockDependencies serializes changes to the dependency graph of an
+// organization, so two updates can't each pass the cycle check and close a
+// cycle together.
+func lockDependencies(ctx context.Context, tx pgx.Tx, orgID int64) error {
+	_, err := tx.Exec(ctx, `SELECT 1 FROM organizations WHERE id = $1 FOR NO KEY UPDATE`, orgID)
+	return err
+}
+
+// checkDependencyCycle fails when following the parents of a monitor leads
+// back to the monitor itself.
+func checkDependencyCycle(ctx context.Context, tx pgx.Tx, monitorID int64) error {
+	var cyclic bool
+	err := tx.QueryRow(ctx, `
+		WITH RECURSIVE ancestors (id) AS (
+			SELECT parent_id FROM monitor_dependencies WHERE monitor_id = $1
+			UNION
+			SELECT d.parent_id FROM monitor_dependencies d JOIN ancestors a ON d.monitor_id = a.id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $1)`, monitorID).Scan(&cyclic)
+	if err != nil {
+		return err
+	}
+
+	if cyclic {
+		return errs.ErrDependencyCycle
+	}
+
+	return nil
+}
+
+// checkEscalationPolicy makes sure a monitor only uses policies of its own
+// organization.
+func checkEscalationPolicy(ctx context.Context, tx pgx.Tx, orgID int64, policyID *int64) error {
+	if policyID == nil {
+		return nil
+	}
+
+	var exists bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM escalation_policies WHERE id = $1 AND organization_id = $2)`,
+		*policyID, orgID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errs.ErrEscalationPolicyNotFound
+	}
+
+	return nil
+}
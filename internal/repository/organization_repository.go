@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+const uniqueViolationCode = "23505"
+
+type organizationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOrganizationRepo(pool *pgxpool.Pool) OrganizationRepository {
+	return &organizationRepo{db: pool}
+}
+
+func (r *organizationRepo) CreateOrganization(ctx context.Context, org models.Organization, ownerID int64) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO organizations (name)
+		VALUES ($1)
+		RETURNING id`, org.Name).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)`, id, ownerID, models.RoleOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *organizationRepo) GetOrganization(ctx context.Context, id int64) (*models.Organization, error) {
+	var org models.Organization
+	query := `
+		SELECT id, name, created_at
+		FROM organizations
+		WHERE id = $1`
+
+	err := r.db.QueryRow(ctx, query, id).Scan(&org.ID, &org.Name, &org.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (r *organizationRepo) GetUserOrganizations(ctx context.Context, userID int64) ([]models.Organization, error) {
+	query := `
+		SELECT o.id, o.name, o.created_at, m.role
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.id`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []models.Organization
+	for rows.Next() {
+		var org models.Organization
+		if err := rows.Scan(&org.ID, &org.Name, &org.CreatedAt, &org.Role); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		orgs = append(orgs, org)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return orgs, nil
+}
+
+func (r *organizationRepo) GetMember(ctx context.Context, orgID, userID int64) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	query := `
+		SELECT m.organization_id, m.user_id, u.username, m.role, m.joined_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND m.user_id = $2`
+
+	err := r.db.QueryRow(ctx, query, orgID, userID).Scan(
+		&member.OrganizationID,
+		&member.UserID,
+		&member.Username,
+		&member.Role,
+		&member.JoinedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *organizationRepo) GetMembers(ctx context.Context, orgID int64) ([]models.OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, u.username, m.role, m.joined_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY m.joined_at`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.OrganizationMember
+	for rows.Next() {
+		var member models.OrganizationMember
+		if err := rows.Scan(
+			&member.OrganizationID,
+			&member.UserID,
+			&member.Username,
+			&member.Role,
+			&member.JoinedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *organizationRepo) UpdateMemberRole(ctx context.Context, orgID, userID int64, role models.OrganizationRole) error {
+	query := `
+		UPDATE organization_members
+		SET role = $1
+		WHERE organization_id = $2 AND user_id = $3`
+
+	cmdTag, err := r.db.Exec(ctx, query, role, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (r *organizationRepo) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	query := `
+		DELETE FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2`
+
+	cmdTag, err := r.db.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (r *organizationRepo) CountOwners(ctx context.Context, orgID int64) (int, error) {
+	var count int
+	query := `
+		SELECT count(*)
+		FROM organization_members
+		WHERE organization_id = $1 AND role = $2`
+
+	err := r.db.QueryRow(ctx, query, orgID, models.RoleOwner).Scan(&count)
+	return count, err
+}
+
+func (r *organizationRepo) CreateInvitation(ctx context.Context, invitation models.OrganizationInvitation) (int64, error) {
+	var id int64
+	query := `
+		INSERT INTO organization_invitations (organization_id, email, username, role, invited_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	err := r.db.QueryRow(ctx, query,
+		invitation.OrganizationID, invitation.Email, invitation.Username,
+		invitation.Role, invitation.InvitedBy).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *organizationRepo) GetInvitation(ctx context.Context, id int64) (*models.OrganizationInvitation, error) {
+	var inv models.OrganizationInvitation
+	query := `
+		SELECT i.id, i.organization_id, o.name, i.email, i.username, i.role,
+			i.invited_by, i.created_at, i.accepted_at
+		FROM organization_invitations i
+		JOIN organizations o ON o.id = i.organization_id
+		WHERE i.id = $1`
+
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&inv.ID,
+		&inv.OrganizationID,
+		&inv.OrganizationName,
+		&inv.Email,
+		&inv.Username,
+		&inv.Role,
+		&inv.InvitedBy,
+		&inv.CreatedAt,
+		&inv.AcceptedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &inv, nil
+}
+
+func (r *organizationRepo) GetPendingInvitations(ctx context.Context, username, email string) ([]models.OrganizationInvitation, error) {
+	query := `
+		SELECT i.id, i.organization_id, o.name, i.email, i.username, i.role,
+			i.invited_by, i.created_at, i.accepted_at
+		FROM organization_invitations i
+		JOIN organizations o ON o.id = i.organization_id
+		WHERE i.accepted_at IS NULL
+			AND (i.username = $1 OR ($2 <> '' AND i.email = $2))
+		ORDER BY i.created_at`
+
+	rows, err := r.db.Query(ctx, query, username, email)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []models.OrganizationInvitation
+	for rows.Next() {
+		var inv models.OrganizationInvitation
+		if err := rows.Scan(
+			&inv.ID,
+			&inv.OrganizationID,
+			&inv.OrganizationName,
+			&inv.Email,
+			&inv.Username,
+			&inv.Role,
+			&inv.InvitedBy,
+			&inv.CreatedAt,
+			&inv.AcceptedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		invitations = append(invitations, inv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (r *organizationRepo) AcceptInvitation(ctx context.Context, invitationID, userID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	var orgID int64
+	var role models.OrganizationRole
+	err = tx.QueryRow(ctx, `
+		UPDATE organization_invitations
+		SET accepted_at = now()
+		WHERE id = $1 AND accepted_at IS NULL
+		RETURNING organization_id, role`, invitationID).Scan(&orgID, &role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errs.ErrInvitationNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)`, orgID, userID, role)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return errs.ErrAlreadyMember
+	}
+
+	return err
+}
+
+func (r *organizationRepo) DeleteInvitation(ctx context.Context, id int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM organization_invitations WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrInvitationNotFound
+	}
+
+	return nil
+}
@@ -5,45 +5,229 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/config"
 	"github.com/mixdone/uptime-monitoring/internal/models"
 )
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, user models.User) (int64, error)
+	CreateUserWithOrganization(ctx context.Context, user models.User, orgName string) (int64, error)
 	GetUser(ctx context.Context, userId int64) (*models.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
-	//UpdateUser(ctx context.Context, user models.User) error
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdateUser(ctx context.Context, user models.User) error
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, userID int64) error
+	SetActiveOrganization(ctx context.Context, userID, orgID int64) error
 	DeleteUser(ctx context.Context, userId int64) error
 }
 
 type SessionRepository interface {
 	CreateSession(ctx context.Context, session models.Session) (int64, error)
-	GetSession(ctx context.Context, userID int64, refreshToken, fingerprint string) (*models.Session, error)
+	GetSession(ctx context.Context, sessionID int64) (*models.Session, error)
 	GetAllUserSessions(ctx context.Context, userID int64) ([]models.Session, error)
+	AddRefreshToken(ctx context.Context, sessionID int64, tokenHash string) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, session models.Session, oldHash, newHash string) error
+	DeleteUserSession(ctx context.Context, userID, sessionID int64) error
 	DeleteSession(ctx context.Context, sessionID int64) error
 	DeleteAllSessions(ctx context.Context, userID int64) error
+	DeleteOtherSessions(ctx context.Context, userID, keepSessionID int64) error
 }
 
 type MonitorsRepository interface {
 	CreateMonitor(ctx context.Context, monitor models.Monitor) (int64, error)
 	GetMonitor(ctx context.Context, id int64) (*models.Monitor, error)
-	GetAllUserMonitors(ctx context.Context, userID int64) ([]models.Monitor, error)
+	GetAllOrganizationMonitors(ctx context.Context, orgID int64) ([]models.Monitor, error)
 	GetAllActiveMonitors(ctx context.Context) ([]models.Monitor, error)
 	UpdateMonitor(ctx context.Context, monitor models.Monitor) error
 	UpdateLastCheckedAt(ctx context.Context, id int64, checkedAt time.Time) error
 	DeleteMonitor(ctx context.Context, id int64) error
 }
 
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, org models.Organization, ownerID int64) (int64, error)
+	GetOrganization(ctx context.Context, id int64) (*models.Organization, error)
+	GetUserOrganizations(ctx context.Context, userID int64) ([]models.Organization, error)
+	GetMember(ctx context.Context, orgID, userID int64) (*models.OrganizationMember, error)
+	GetMembers(ctx context.Context, orgID int64) ([]models.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID int64, role models.OrganizationRole) error
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+	CountOwners(ctx context.Context, orgID int64) (int, error)
+	CreateInvitation(ctx context.Context, invitation models.OrganizationInvitation) (int64, error)
+	GetInvitation(ctx context.Context, id int64) (*models.OrganizationInvitation, error)
+	GetPendingInvitations(ctx context.Context, username, email string) ([]models.OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, invitationID, userID int64) error
+	DeleteInvitation(ctx context.Context, id int64) error
+}
+
+type LoginAttemptRepository interface {
+	RecordAttempt(ctx context.Context, attempt models.LoginAttempt) error
+	GetUsernameFailures(ctx context.Context, username string, since time.Time) (models.FailureStats, error)
+	GetIPFailures(ctx context.Context, ip string, since time.Time) (models.FailureStats, error)
+	DeleteAttemptsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type TwoFactorRepository interface {
+	GetTwoFactor(ctx context.Context, userID int64) (*models.TwoFactor, error)
+	SetPendingSecret(ctx context.Context, userID int64, secret string) error
+	EnableTwoFactor(ctx context.Context, userID, step int64, codeHashes []string) error
+	DisableTwoFactor(ctx context.Context, userID int64) error
+	ConsumeStep(ctx context.Context, userID, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+}
+
+type UserTokenRepository interface {
+	CreateToken(ctx context.Context, token models.UserToken) error
+	ConsumeToken(ctx context.Context, purpose models.TokenPurpose, tokenHash string) (*models.UserToken, error)
+}
+
+type IdentityRepository interface {
+	CreateLoginState(ctx context.Context, state models.OIDCLoginState) error
+	ConsumeLoginState(ctx context.Context, stateHash string) (*models.OIDCLoginState, error)
+	GetIdentity(ctx context.Context, issuer, subject string) (*models.UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity models.UserIdentity) error
+	TouchIdentity(ctx context.Context, id int64, email string) error
+}
+
+type AuditRepository interface {
+	CreateEntry(ctx context.Context, entry models.AuditEntry) error
+	GetEntries(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error)
+	DeleteEntriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type CheckRepository interface {
+	CreateResult(ctx context.Context, result models.CheckResult) (int64, error)
+	GetLatestStatuses(ctx context.Context, monitorIDs []int64) (map[int64]string, error)
+	CountStateChanges(ctx context.Context, monitorID int64, since time.Time) (int, error)
+	GetDailyUptime(ctx context.Context, monitorIDs []int64, since time.Time) (map[int64][]models.DailyUptime, error)
+	GetAverageResponseTime(ctx context.Context, monitorID int64, since time.Time) (*int64, error)
+}
+
+type IncidentRepository interface {
+	CreateIncident(ctx context.Context, incident models.Incident) (int64, error)
+	GetOpenIncident(ctx context.Context, monitorID int64) (*models.Incident, error)
+	GetOpenIncidents(ctx context.Context, monitorIDs []int64) ([]models.Incident, error)
+	GetIncident(ctx context.Context, id int64) (*models.Incident, error)
+	GetMonitorIncidents(ctx context.Context, monitorID int64, limit int) ([]models.Incident, error)
+	GetRecentIncidents(ctx context.Context, monitorIDs []int64, limit int) ([]models.Incident, error)
+	ResolveIncident(ctx context.Context, id int64, resolvedAt time.Time) error
+	AcknowledgeIncident(ctx context.Context, id, userID int64, at time.Time) (*models.Incident, error)
+	SetIncidentMaintenance(ctx context.Context, id int64, maintenance bool) error
+}
+
+type MaintenanceRepository interface {
+	CreateWindow(ctx context.Context, window models.MaintenanceWindow) (int64, error)
+	GetWindow(ctx context.Context, id int64) (*models.MaintenanceWindow, error)
+	GetOrganizationWindows(ctx context.Context, orgID int64) ([]models.MaintenanceWindow, error)
+	GetMonitorWindows(ctx context.Context, monitorID int64, at time.Time) ([]models.MaintenanceWindow, error)
+	UpdateWindow(ctx context.Context, window models.MaintenanceWindow) error
+	DeleteWindow(ctx context.Context, id int64) error
+}
+
+type ChannelRepository interface {
+	CreateChannel(ctx context.Context, channel models.NotificationChannel) (int64, error)
+	GetChannel(ctx context.Context, id int64) (*models.NotificationChannel, error)
+	GetOrganizationChannels(ctx context.Context, orgID int64) ([]models.NotificationChannel, error)
+	GetChannels(ctx context.Context, orgID int64, ids []int64) ([]models.NotificationChannel, error)
+	UpdateChannel(ctx context.Context, channel models.NotificationChannel) error
+	DeleteChannel(ctx context.Context, id int64) error
+}
+
+type EscalationRepository interface {
+	CreatePolicy(ctx context.Context, policy models.EscalationPolicy) (int64, error)
+	GetPolicy(ctx context.Context, id int64) (*models.EscalationPolicy, error)
+	GetOrganizationPolicies(ctx context.Context, orgID int64) ([]models.EscalationPolicy, error)
+	UpdatePolicy(ctx context.Context, policy models.EscalationPolicy) error
+	DeletePolicy(ctx context.Context, id int64) error
+	StartEscalation(ctx context.Context, incidentID, policyID int64, startedAt time.Time) error
+	ClaimDueEscalations(ctx context.Context, now time.Time, limit int) ([]models.EscalationStep, error)
+}
+
+type StatusPageRepository interface {
+	CreatePage(ctx context.Context, page models.StatusPage) (int64, error)
+	GetPage(ctx context.Context, id int64) (*models.StatusPage, error)
+	GetPageBySlug(ctx context.Context, slug string) (*models.StatusPage, error)
+	GetOrganizationPages(ctx context.Context, orgID int64) ([]models.StatusPage, error)
+	UpdatePage(ctx context.Context, page models.StatusPage) error
+	DeletePage(ctx context.Context, id int64) error
+}
+
+type AnnouncementRepository interface {
+	CreateAnnouncement(ctx context.Context, announcement models.Announcement) (int64, error)
+	GetAnnouncement(ctx context.Context, id int64) (*models.Announcement, error)
+	GetPageAnnouncements(ctx context.Context, pageID int64, since time.Time) ([]models.Announcement, error)
+	AddUpdate(ctx context.Context, update models.AnnouncementUpdate) (int64, error)
+	DeleteAnnouncement(ctx context.Context, id int64) error
+}
+
+type BadgeRepository interface {
+	SaveBadge(ctx context.Context, badge models.Badge) error
+	GetBadge(ctx context.Context, monitorID int64) (*models.Badge, error)
+	GetBadgeByToken(ctx context.Context, token string) (*models.Badge, error)
+	DeleteBadge(ctx context.Context, monitorID int64) error
+}
+
+type FeedTokenRepository interface {
+	SaveFeedToken(ctx context.Context, userID int64, tokenHash string) error
+	GetFeedTokenUser(ctx context.Context, tokenHash string) (int64, error)
+	DeleteFeedToken(ctx context.Context, userID int64) error
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	// SchemaVersion returns the version recorded by the last migration and
+	// whether that migration failed halfway.
+	SchemaVersion(ctx context.Context) (version int64, dirty bool, err error)
+}
+
 type Repository struct {
-	Users    UserRepository
-	Sessions SessionRepository
-	Monitors MonitorsRepository
+	Users         UserRepository
+	Sessions      SessionRepository
+	Monitors      MonitorsRepository
+	Organizations OrganizationRepository
+	LoginAttempts LoginAttemptRepository
+	TwoFactor     TwoFactorRepository
+	UserTokens    UserTokenRepository
+	Identities    IdentityRepository
+	Audit         AuditRepository
+	Checks        CheckRepository
+	Incidents     IncidentRepository
+	Maintenance   MaintenanceRepository
+	Channels      ChannelRepository
+	Escalation    EscalationRepository
+	StatusPages   StatusPageRepository
+	Announcements AnnouncementRepository
+	Badges        BadgeRepository
+	FeedTokens    FeedTokenRepository
+	Health        HealthRepository
 }
 
-func NewRepository(db *pgxpool.Pool) *Repository {
+func NewRepository(db *pgxpool.Pool, cfg *config.Config) *Repository {
+	loginAttempts := NewLoginAttemptRepo(db)
+	if cfg.Auth.LoginThrottle.Store == "memory" {
+		loginAttempts = NewMemoryLoginAttemptRepo()
+	}
+
 	return &Repository{
-		Users:    NewUserRepo(db),
-		Sessions: NewSessionRepo(db),
-		Monitors: NewMonitorRepo(db),
+		Users:         NewUserRepo(db),
+		Sessions:      NewSessionRepo(db),
+		Monitors:      NewMonitorRepo(db),
+		Organizations: NewOrganizationRepo(db),
+		LoginAttempts: loginAttempts,
+		TwoFactor:     NewTwoFactorRepo(db),
+		UserTokens:    NewUserTokenRepo(db),
+		Identities:    NewIdentityRepo(db),
+		Audit:         NewAuditRepo(db),
+		Checks:        NewCheckRepo(db),
+		Incidents:     NewIncidentRepo(db),
+		Maintenance:   NewMaintenanceRepo(db),
+		Channels:      NewChannelRepo(db),
+		Escalation:    NewEscalationRepo(db),
+		StatusPages:   NewStatusPageRepo(db),
+		Announcements: NewAnnouncementRepo(db),
+		Badges:        NewBadgeRepo(db),
+		FeedTokens:    NewFeedTokenRepo(db),
+		Health:        NewHealthRepo(db),
 	}
 }
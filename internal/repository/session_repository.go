@@ -20,15 +20,33 @@ func NewSessionRepo(pool *pgxpool.Pool) SessionRepository {
 }
 
 func (s *sessionRepository) CreateSession(ctx context.Context, session models.Session) (int64, error) {
-	var id int64
-	query := `
-		INSERT INTO sessions (user_id, refresh_token, expires_at, fingerprint)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id`
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
 
-	err := s.db.QueryRow(ctx, query, session.UserID,
-		session.RefreshToken, session.ExpiresAt, session.Fingerprint).Scan(&id)
+	// logging in again from the same device starts a new token family, so
+	// tokens of the previous one stop working
+	_, err = tx.Exec(ctx, `
+		DELETE FROM sessions
+		WHERE user_id = $1 AND fingerprint = $2`, session.UserID, session.Fingerprint)
+	if err != nil {
+		return 0, err
+	}
 
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO sessions (user_id, expires_at, fingerprint, ip, user_agent)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`, session.UserID, session.ExpiresAt, session.Fingerprint,
+		session.IP, session.UserAgent).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -36,20 +54,24 @@ func (s *sessionRepository) CreateSession(ctx context.Context, session models.Se
 	return id, nil
 }
 
-func (s *sessionRepository) GetSession(ctx context.Context, userID int64, refreshToken, fingerprint string) (*models.Session, error) {
+func (s *sessionRepository) GetSession(ctx context.Context, sessionID int64) (*models.Session, error) {
 	var session models.Session
 
 	query := `
-		SELECT id, user_id, refresh_token, expires_at, fingerprint
+		SELECT id, user_id, expires_at, fingerprint,
+			created_at, last_used_at, ip, user_agent
 		FROM sessions
-		WHERE user_id = $1 AND refresh_token = $2 AND fingerprint = $3`
+		WHERE id = $1`
 
-	err := s.db.QueryRow(ctx, query, userID, refreshToken, fingerprint).Scan(
+	err := s.db.QueryRow(ctx, query, sessionID).Scan(
 		&session.ID,
 		&session.UserID,
-		&session.RefreshToken,
 		&session.ExpiresAt,
 		&session.Fingerprint,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.IP,
+		&session.UserAgent,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -67,9 +89,11 @@ func (s *sessionRepository) GetAllUserSessions(ctx context.Context, userID int64
 	var sessions []models.Session
 
 	query := `
-		SELECT id, user_id, refresh_token, fingerprint
+		SELECT id, user_id, expires_at, fingerprint,
+			created_at, last_used_at, ip, user_agent
 		FROM sessions
-		WHERE user_id = $1
+		WHERE user_id = $1 AND expires_at > now()
+		ORDER BY last_used_at DESC
 	`
 
 	rows, err := s.db.Query(ctx, query, userID)
@@ -83,9 +107,12 @@ func (s *sessionRepository) GetAllUserSessions(ctx context.Context, userID int64
 		if err := rows.Scan(
 			&session.ID,
 			&session.UserID,
-			&session.RefreshToken,
 			&session.ExpiresAt,
 			&session.Fingerprint,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.IP,
+			&session.UserAgent,
 		); err != nil {
 			return nil, fmt.Errorf("scan error: %w", err)
 		}
@@ -100,9 +127,107 @@ func (s *sessionRepository) GetAllUserSessions(ctx context.Context, userID int64
 	return sessions, nil
 }
 
+func (s *sessionRepository) AddRefreshToken(ctx context.Context, sessionID int64, tokenHash string) error {
+	query := `
+		INSERT INTO refresh_tokens (session_id, token_hash)
+		VALUES ($1, $2)
+	`
+	_, err := s.db.Exec(ctx, query, sessionID, tokenHash)
+
+	return err
+}
+
+func (s *sessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+
+	query := `
+		SELECT id, session_id, token_hash, created_at, rotated_at
+		FROM refresh_tokens
+		WHERE token_hash = $1`
+
+	err := s.db.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.SessionID,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.RotatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrSessionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken marks oldHash as rotated and adds newHash to the same
+// family in one transaction. ErrTokenReused means oldHash had already been
+// rotated, possibly by a concurrent request.
+func (s *sessionRepository) RotateRefreshToken(ctx context.Context, session models.Session, oldHash, newHash string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens
+		SET rotated_at = now()
+		WHERE token_hash = $1 AND session_id = $2 AND rotated_at IS NULL`, oldHash, session.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		err = errs.ErrTokenReused
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash)
+		VALUES ($1, $2)`, session.ID, newHash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE sessions
+		SET expires_at = $1, last_used_at = now(), ip = $2, user_agent = $3
+		WHERE id = $4`, session.ExpiresAt, session.IP, session.UserAgent, session.ID)
+
+	return err
+}
+
+func (s *sessionRepository) DeleteUserSession(ctx context.Context, userID, sessionID int64) error {
+	query := `
+		DELETE FROM sessions
+		WHERE id = $1 AND user_id = $2
+	`
+	cmdTag, err := s.db.Exec(ctx, query, sessionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrSessionNotFound
+	}
+
+	return nil
+}
+
 func (s *sessionRepository) DeleteSession(ctx context.Context, sessionID int64) error {
 	query := `
-		DELETE FROM sessions 
+		DELETE FROM sessions
 		WHERE id = $1
 	`
 	_, err := s.db.Exec(ctx, query, sessionID)
@@ -112,10 +237,20 @@ func (s *sessionRepository) DeleteSession(ctx context.Context, sessionID int64)
 
 func (s *sessionRepository) DeleteAllSessions(ctx context.Context, userID int64) error {
 	query := `
-		DELETE FROM sessions 
+		DELETE FROM sessions
 		WHERE user_id = $1
 	`
 	_, err := s.db.Exec(ctx, query, userID)
 
 	return err
 }
+
+func (s *sessionRepository) DeleteOtherSessions(ctx context.Context, userID, keepSessionID int64) error {
+	query := `
+		DELETE FROM sessions
+		WHERE user_id = $1 AND id <> $2
+	`
+	_, err := s.db.Exec(ctx, query, userID, keepSessionID)
+
+	return err
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type statusPageRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewStatusPageRepo(pool *pgxpool.Pool) StatusPageRepository {
+	return &statusPageRepo{db: pool}
+}
+
+const selectStatusPages = `
+	SELECT id, organization_id, slug, title, description, created_at
+	FROM status_pages`
+
+func (r *statusPageRepo) CreatePage(ctx context.Context, page models.StatusPage) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO status_pages (organization_id, slug, title, description)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`, page.OrganizationID, page.Slug, page.Title, page.Description).Scan(&id)
+	if isUniqueViolation(err) {
+		err = errs.ErrSlugTaken
+		return 0, err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	page.ID = id
+	err = saveComponents(ctx, tx, page)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *statusPageRepo) GetPage(ctx context.Context, id int64) (*models.StatusPage, error) {
+	return r.getPage(ctx, selectStatusPages+`
+		WHERE id = $1`, id)
+}
+
+func (r *statusPageRepo) GetPageBySlug(ctx context.Context, slug string) (*models.StatusPage, error) {
+	return r.getPage(ctx, selectStatusPages+`
+		WHERE slug = $1`, slug)
+}
+
+func (r *statusPageRepo) GetOrganizationPages(ctx context.Context, orgID int64) ([]models.StatusPage, error) {
+	rows, err := r.db.Query(ctx, selectStatusPages+`
+		WHERE organization_id = $1
+		ORDER BY title`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	pages := []models.StatusPage{}
+	for rows.Next() {
+		page, err := scanStatusPage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		pages = append(pages, *page)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	for i := range pages {
+		pages[i].Components, err = r.getComponents(ctx, pages[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pages, nil
+}
+
+// UpdatePage replaces the settings and components of a page. Components with
+// an ID are updated in place, so anything attached to them survives.
+func (r *statusPageRepo) UpdatePage(ctx context.Context, page models.StatusPage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE status_pages
+		SET slug = $1, title = $2, description = $3
+		WHERE id = $4`, page.Slug, page.Title, page.Description, page.ID)
+	if isUniqueViolation(err) {
+		err = errs.ErrSlugTaken
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		err = errs.ErrStatusPageNotFound
+		return err
+	}
+
+	err = saveComponents(ctx, tx, page)
+	return err
+}
+
+func (r *statusPageRepo) DeletePage(ctx context.Context, id int64) error {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM status_pages WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrStatusPageNotFound
+	}
+
+	return nil
+}
+
+func (r *statusPageRepo) getPage(ctx context.Context, query string, arg any) (*models.StatusPage, error) {
+	page, err := scanStatusPage(r.db.QueryRow(ctx, query, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrStatusPageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page.Components, err = r.getComponents(ctx, page.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *statusPageRepo) getComponents(ctx context.Context, pageID int64) ([]models.StatusComponent, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.name, m.monitor_id, m.display_name
+		FROM status_page_components c
+		LEFT JOIN status_page_monitors m ON m.component_id = c.id
+		WHERE c.status_page_id = $1
+		ORDER BY c.position, m.position`, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	components := []models.StatusComponent{}
+	for rows.Next() {
+		var component models.StatusComponent
+		var monitorID *int64
+		var displayName *string
+		if err := rows.Scan(&component.ID, &component.Name, &monitorID, &displayName); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		if n := len(components); n == 0 || components[n-1].ID != component.ID {
+			component.Monitors = []models.StatusPageMonitor{}
+			components = append(components, component)
+		}
+		if monitorID != nil {
+			last := &components[len(components)-1]
+			last.Monitors = append(last.Monitors, models.StatusPageMonitor{
+				MonitorID:   *monitorID,
+				DisplayName: *displayName,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+
+	return components, nil
+}
+
+// saveComponents makes the components of the page match page.Components.
+// Components named by ID must already belong to the page, otherwise it fails
+// with ErrStatusComponentNotFound.
+func saveComponents(ctx context.Context, tx pgx.Tx, page models.StatusPage) error {
+	kept := make([]int64, 0, len(page.Components))
+	for i := range page.Components {
+		component := &page.Components[i]
+		if component.ID == 0 {
+			continue
+		}
+
+		cmdTag, err := tx.Exec(ctx, `
+			UPDATE status_page_components
+			SET name = $1, position = $2
+			WHERE id = $3 AND status_page_id = $4`, component.Name, i, component.ID, page.ID)
+		if err != nil {
+			return err
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return errs.ErrStatusComponentNotFound
+		}
+
+		kept = append(kept, component.ID)
+	}
+
+	_, err := tx.Exec(ctx, `
+		DELETE FROM status_page_components
+		WHERE status_page_id = $1 AND id <> ALL($2)`, page.ID, kept)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM status_page_monitors WHERE component_id = ANY($1)`, kept)
+	if err != nil {
+		return err
+	}
+
+	for i := range page.Components {
+		component := &page.Components[i]
+		if component.ID == 0 {
+			err = tx.QueryRow(ctx, `
+				INSERT INTO status_page_components (status_page_id, name, position)
+				VALUES ($1, $2, $3)
+				RETURNING id`, page.ID, component.Name, i).Scan(&component.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = linkComponentMonitors(ctx, tx, component.ID, page.OrganizationID, component.Monitors)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// linkComponentMonitors adds monitors of the organization to a component.
+// Monitors of other organizations make it fail with ErrMonitorNotFound.
+func linkComponentMonitors(ctx context.Context, tx pgx.Tx, componentID, orgID int64,
+	monitors []models.StatusPageMonitor) error {
+
+	if len(monitors) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(monitors))
+	names := make([]string, len(monitors))
+	for i, monitor := range monitors {
+		ids[i] = monitor.MonitorID
+		names[i] = monitor.DisplayName
+	}
+
+	cmdTag, err := tx.Exec(ctx, `
+		INSERT INTO status_page_monitors (component_id, monitor_id, display_name, position)
+		SELECT $1, m.id, COALESCE(NULLIF(u.display_name, ''), m.name), u.position
+		FROM unnest($2::bigint[], $3::text[]) WITH ORDINALITY AS u(monitor_id, display_name, position)
+		JOIN monitors m ON m.id = u.monitor_id
+		WHERE m.organization_id = $4`, componentID, ids, names, orgID)
+	if err != nil {
+		return err
+	}
+
+	if int(cmdTag.RowsAffected()) != len(monitors) {
+		return errs.ErrMonitorNotFound
+	}
+
+	return nil
+}
+
+func scanStatusPage(row pgx.Row) (*models.StatusPage, error) {
+	var page models.StatusPage
+	err := row.Scan(
+		&page.ID,
+		&page.OrganizationID,
+		&page.Slug,
+		&page.Title,
+		&page.Description,
+		&page.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
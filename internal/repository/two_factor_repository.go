@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type twoFactorRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTwoFactorRepo(pool *pgxpool.Pool) TwoFactorRepository {
+	return &twoFactorRepo{db: pool}
+}
+
+func (r *twoFactorRepo) GetTwoFactor(ctx context.Context, userID int64) (*models.TwoFactor, error) {
+	var tf models.TwoFactor
+	query := `
+		SELECT id, totp_secret, totp_enabled, totp_last_step
+		FROM users
+		WHERE id = $1`
+
+	err := r.db.QueryRow(ctx, query, userID).Scan(&tf.UserID, &tf.Secret, &tf.Enabled, &tf.LastStep)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tf, nil
+}
+
+func (r *twoFactorRepo) SetPendingSecret(ctx context.Context, userID int64, secret string) error {
+	query := `
+		UPDATE users
+		SET totp_secret = $1
+		WHERE id = $2 AND NOT totp_enabled`
+
+	cmdTag, err := r.db.Exec(ctx, query, secret, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrTwoFactorAlreadyEnabled
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepo) EnableTwoFactor(ctx context.Context, userID, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET totp_enabled = true, totp_last_step = $1
+		WHERE id = $2`, step, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO recovery_codes (user_id, code_hash)
+			VALUES ($1, $2)`, userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepo) DisableTwoFactor(ctx context.Context, userID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET totp_secret = NULL, totp_enabled = false, totp_last_step = 0
+		WHERE id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID)
+
+	return err
+}
+
+// ConsumeStep records step as used. It reports false if the same or a later
+// step was already accepted, which stops a code from being replayed.
+func (r *twoFactorRepo) ConsumeStep(ctx context.Context, userID, step int64) (bool, error) {
+	query := `
+		UPDATE users
+		SET totp_last_step = $1
+		WHERE id = $2 AND totp_last_step < $1`
+
+	cmdTag, err := r.db.Exec(ctx, query, step, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return cmdTag.RowsAffected() == 1, nil
+}
+
+func (r *twoFactorRepo) ConsumeRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	query := `
+		UPDATE recovery_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	cmdTag, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	return cmdTag.RowsAffected() == 1, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/models/errs"
@@ -23,7 +24,7 @@ func (u *userRepo) CreateUser(ctx context.Context, user models.User) (int64, err
 	var id int64
 	query := `
 		INSERT INTO users (username, email, telegram_id, password_hash)
-		VALUES ($1, $2, $3, $4)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, 0), $4)
 		RETURNING id`
 
 	err := u.db.QueryRow(ctx, query,
@@ -37,10 +38,61 @@ func (u *userRepo) CreateUser(ctx context.Context, user models.User) (int64, err
 	return id, nil
 }
 
+// CreateUserWithOrganization creates the user together with an organization
+// they own and work in, so a failure can't leave the user without one.
+func (u *userRepo) CreateUserWithOrganization(ctx context.Context, user models.User, orgName string) (int64, error) {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO users (username, email, telegram_id, password_hash)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, 0), $4)
+		RETURNING id`,
+		user.Username, user.Email,
+		user.TelegramID, user.PasswordHash).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	var orgID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO organizations (name)
+		VALUES ($1)
+		RETURNING id`, orgName).Scan(&orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)`, orgID, id, models.RoleOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET active_organization_id = $1 WHERE id = $2`, orgID, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
 func (u *userRepo) GetUser(ctx context.Context, userId int64) (*models.User, error) {
 	var user models.User
 	query := `
-		SELECT id, username, email, telegram_id, password_hash 
+		SELECT id, username, COALESCE(email, ''), COALESCE(telegram_id, 0), password_hash,
+			active_organization_id, email_verified_at, timezone, notification_preferences
 		FROM users
 		WHERE id = $1`
 
@@ -50,6 +102,10 @@ func (u *userRepo) GetUser(ctx context.Context, userId int64) (*models.User, err
 		&user.Email,
 		&user.TelegramID,
 		&user.PasswordHash,
+		&user.ActiveOrganizationID,
+		&user.EmailVerifiedAt,
+		&user.Timezone,
+		&user.NotificationPreferences,
 	)
 
 	if err != nil {
@@ -65,9 +121,10 @@ func (u *userRepo) GetUser(ctx context.Context, userId int64) (*models.User, err
 func (u *userRepo) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
 	query := `
-			SELECT id, username, email, telegram_id, password_hash
-			FROM users
-			WHERE username = $1`
+			SELECT id, username, COALESCE(email, ''), COALESCE(telegram_id, 0), password_hash,
+			active_organization_id, email_verified_at, timezone, notification_preferences
+		FROM users
+		WHERE username = $1`
 
 	err := u.db.QueryRow(ctx, query, username).Scan(
 		&user.ID,
@@ -75,6 +132,10 @@ func (u *userRepo) GetUserByUsername(ctx context.Context, username string) (*mod
 		&user.Email,
 		&user.TelegramID,
 		&user.PasswordHash,
+		&user.ActiveOrganizationID,
+		&user.EmailVerifiedAt,
+		&user.Timezone,
+		&user.NotificationPreferences,
 	)
 
 	if err != nil {
@@ -88,14 +149,66 @@ func (u *userRepo) GetUserByUsername(ctx context.Context, username string) (*mod
 	return &user, nil
 }
 
-func (u *userRepo) DeleteUser(ctx context.Context, userId int64) error {
+func (u *userRepo) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	query := `
+		SELECT id, username, COALESCE(email, ''), COALESCE(telegram_id, 0), password_hash,
+			active_organization_id, email_verified_at, timezone, notification_preferences
+		FROM users
+		WHERE email = $1`
+
+	err := u.db.QueryRow(ctx, query, email).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.TelegramID,
+		&user.PasswordHash,
+		&user.ActiveOrganizationID,
+		&user.EmailVerifiedAt,
+		&user.Timezone,
+		&user.NotificationPreferences,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateUser saves the profile fields of the user. Changing the email clears
+// its verification.
+func (u *userRepo) UpdateUser(ctx context.Context, user models.User) error {
 	query := `
-		DELETE FROM users 
-		WHERE id = $1 
-	`
+		UPDATE users
+		SET username = $1,
+			email_verified_at = CASE
+				WHEN email IS DISTINCT FROM NULLIF($2, '') THEN NULL
+				ELSE email_verified_at
+			END,
+			email = NULLIF($2, ''),
+			telegram_id = NULLIF($3, 0),
+			timezone = $4,
+			notification_preferences = $5
+		WHERE id = $6`
 
-	cmdTag, err := u.db.Exec(ctx, query, userId)
+	cmdTag, err := u.db.Exec(ctx, query, user.Username, user.Email, user.TelegramID,
+		user.Timezone, user.NotificationPreferences, user.ID)
 
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		switch pgErr.ConstraintName {
+		case "users_username_key":
+			return errs.ErrUsernameTaken
+		case "users_email_key":
+			return errs.ErrEmailTaken
+		case "users_telegram_id_key":
+			return errs.ErrTelegramIDTaken
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -106,3 +219,116 @@ func (u *userRepo) DeleteUser(ctx context.Context, userId int64) error {
 
 	return nil
 }
+
+func (u *userRepo) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
+	query := `
+		UPDATE users
+		SET password_hash = $1
+		WHERE id = $2`
+
+	cmdTag, err := u.db.Exec(ctx, query, passwordHash, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (u *userRepo) MarkEmailVerified(ctx context.Context, userID int64) error {
+	query := `
+		UPDATE users
+		SET email_verified_at = now()
+		WHERE id = $1 AND email_verified_at IS NULL`
+
+	_, err := u.db.Exec(ctx, query, userID)
+	return err
+}
+
+func (u *userRepo) SetActiveOrganization(ctx context.Context, userID, orgID int64) error {
+	query := `
+		UPDATE users
+		SET active_organization_id = $1
+		WHERE id = $2`
+
+	cmdTag, err := u.db.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// DeleteUser removes the user together with the organizations nobody else
+// belongs to. Organizations the user is the last owner of, while other
+// members remain, would be left without an owner, so ErrLastOwner is returned
+// instead.
+func (u *userRepo) DeleteUser(ctx context.Context, userId int64) error {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	var orphaned bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_members m
+			WHERE m.user_id = $1 AND m.role = 'owner'
+				AND NOT EXISTS (
+					SELECT 1 FROM organization_members o
+					WHERE o.organization_id = m.organization_id
+						AND o.user_id <> $1 AND o.role = 'owner')
+				AND EXISTS (
+					SELECT 1 FROM organization_members o
+					WHERE o.organization_id = m.organization_id AND o.user_id <> $1)
+		)`, userId).Scan(&orphaned)
+	if err != nil {
+		return err
+	}
+
+	if orphaned {
+		err = errs.ErrLastOwner
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM organizations
+		WHERE id IN (
+			SELECT organization_id
+			FROM organization_members
+			GROUP BY organization_id
+			HAVING bool_and(user_id = $1)
+		)`, userId)
+	if err != nil {
+		return err
+	}
+
+	cmdTag, err := tx.Exec(ctx, `
+		DELETE FROM users
+		WHERE id = $1`, userId)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		err = errs.ErrUserNotFound
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+type userTokenRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUserTokenRepo(pool *pgxpool.Pool) UserTokenRepository {
+	return &userTokenRepo{db: pool}
+}
+
+// CreateToken stores a new token and invalidates earlier unused tokens of
+// the same purpose, so only the latest email link works.
+func (r *userTokenRepo) CreateToken(ctx context.Context, token models.UserToken) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			tx.Commit(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `
+		UPDATE user_tokens
+		SET used_at = now()
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`, token.UserID, token.Purpose)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_tokens (user_id, purpose, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)`, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt)
+
+	return err
+}
+
+// ConsumeToken marks a valid token as used and returns it. Expired, used or
+// unknown tokens all yield ErrUserTokenInvalid.
+func (r *userTokenRepo) ConsumeToken(ctx context.Context, purpose models.TokenPurpose, tokenHash string) (*models.UserToken, error) {
+	var token models.UserToken
+	query := `
+		UPDATE user_tokens
+		SET used_at = now()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING id, user_id, purpose, token_hash, created_at, expires_at, used_at`
+
+	err := r.db.QueryRow(ctx, query, tokenHash, purpose).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.UsedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrUserTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
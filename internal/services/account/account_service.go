@@ -0,0 +1,202 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/internal/services/session"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/mailer"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type accountService struct {
+	users     repository.UserRepository
+	tokens    repository.UserTokenRepository
+	session   session.SessionService
+	mailer    mailer.Mailer
+	publicURL string
+	logger    logger.Logger
+}
+
+func NewAccountService(users repository.UserRepository, tokens repository.UserTokenRepository,
+	session session.SessionService, mail mailer.Mailer, publicURL string, log logger.Logger) AccountService {
+	return &accountService{
+		users:     users,
+		tokens:    tokens,
+		session:   session,
+		mailer:    mail,
+		publicURL: publicURL,
+		logger:    log.WithField("component", "accountService"),
+	}
+}
+
+func (s *accountService) SendVerificationEmail(ctx context.Context, userID int64) error {
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Email == "" {
+		return errs.ErrEmailMissing
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return errs.ErrEmailVerified
+	}
+
+	token, err := s.issueToken(ctx, userID, models.PurposeEmailVerification, constants.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hello, %s!\n\nConfirm your email address by opening the link below:\n%s\n\n"+
+			"The link is valid for %s.\n",
+			user.Username, s.link("/verify-email", token), constants.EmailVerificationTTL),
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to send verification email")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Verification email sent to user_id=%d", userID)
+	return nil
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	userToken, err := s.tokens.ConsumeToken(ctx, models.PurposeEmailVerification, hashToken(token))
+	if err != nil {
+		return err
+	}
+
+	if err := s.users.MarkEmailVerified(ctx, userToken.UserID); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userToken.UserID).
+			WithError(err).
+			Error("Failed to mark email verified")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Email verified for user_id=%d", userToken.UserID)
+	return nil
+}
+
+// RequestPasswordReset mails a reset link if the address belongs to a user.
+// Unknown addresses are not reported, so the endpoint can't be used to find
+// out which emails are registered.
+func (s *accountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		s.logger.WithContext(ctx).Debug("Password reset requested for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(ctx, user.ID, models.PurposePasswordReset, constants.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello, %s!\n\nSomeone asked to reset the password of your account. "+
+			"If it was you, open the link below:\n%s\n\n"+
+			"The link is valid for %s. If you didn't ask for it, ignore this email.\n",
+			user.Username, s.link("/reset-password", token), constants.PasswordResetTTL),
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", user.ID).
+			WithError(err).
+			Error("Failed to send password reset email")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Password reset email sent to user_id=%d", user.ID)
+	return nil
+}
+
+// ResetPassword sets a new password and signs the user out everywhere, since
+// whoever held the old password may still have a session.
+func (s *accountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userToken, err := s.tokens.ConsumeToken(ctx, models.PurposePasswordReset, hashToken(token))
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to hash password")
+		return errs.ErrHashingFailed
+	}
+
+	if err := s.users.UpdatePassword(ctx, userToken.UserID, string(hash)); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userToken.UserID).
+			WithError(err).
+			Error("Failed to update password")
+		return err
+	}
+
+	if err := s.session.DeleteAllUserSessions(ctx, userToken.UserID); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"event":   "password_reset",
+		"user_id": userToken.UserID,
+	}).Warn("Password reset, all sessions revoked")
+
+	return nil
+}
+
+func (s *accountService) issueToken(ctx context.Context, userID int64, purpose models.TokenPurpose, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	err := s.tokens.CreateToken(ctx, models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"userID":  userID,
+			"purpose": purpose,
+		}).WithError(err).Error("Failed to store token")
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *accountService) link(path, token string) string {
+	return s.publicURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
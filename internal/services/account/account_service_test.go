@@ -0,0 +1,215 @@
+package account_test
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/account"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/pkg/mailer"
+)
+
+const userID = int64(1)
+
+var linkToken = regexp.MustCompile(`token=(\S+)`)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockUserRepository, *mocks.MockUserTokenRepository, *mocks.MockSessionService, *mocks.MockMailer, account.AccountService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockUserTokenRepository(ctrl)
+	mockSession := mocks.NewMockSessionService(ctrl)
+	mockMailer := mocks.NewMockMailer(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := account.NewAccountService(mockUsers, mockTokens, mockSession, mockMailer, "https://uptime.example.com", mockLogger)
+	return context.Background(), ctrl, mockUsers, mockTokens, mockSession, mockMailer, svc
+}
+
+// tokenStore backs the token repository with a map that follows the rules
+// of the SQL query: a token is consumed once and only before it expires.
+func tokenStore(mockTokens *mocks.MockUserTokenRepository) map[string]*models.UserToken {
+	store := map[string]*models.UserToken{}
+
+	mockTokens.EXPECT().CreateToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token models.UserToken) error {
+			store[token.TokenHash] = &token
+			return nil
+		}).AnyTimes()
+
+	mockTokens.EXPECT().ConsumeToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, purpose models.TokenPurpose, hash string) (*models.UserToken, error) {
+			token, ok := store[hash]
+			if !ok || token.Purpose != purpose || token.UsedAt != nil || !time.Now().Before(token.ExpiresAt) {
+				return nil, errs.ErrUserTokenInvalid
+			}
+			now := time.Now()
+			token.UsedAt = &now
+			return token, nil
+		}).AnyTimes()
+
+	return store
+}
+
+// expectMail captures the token from the link of the next sent email.
+func expectMail(t *testing.T, mockMailer *mocks.MockMailer, to string, token *string) {
+	t.Helper()
+
+	mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg mailer.Message) error {
+			assert.Equal(t, to, msg.To)
+			match := linkToken.FindStringSubmatch(msg.Body)
+			require.Len(t, match, 2)
+			value, err := url.QueryUnescape(match[1])
+			require.NoError(t, err)
+			*token = value
+			return nil
+		})
+}
+
+func TestSendVerificationEmail_Rejected(t *testing.T) {
+	verified := time.Now()
+
+	tests := []struct {
+		name string
+		user *models.User
+		err  error
+	}{
+		{name: "no email", user: &models.User{ID: userID}, err: errs.ErrEmailMissing},
+		{name: "already verified", user: &models.User{ID: userID, Email: "a@example.com", EmailVerifiedAt: &verified}, err: errs.ErrEmailVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockUsers, mockTokens, _, mockMailer, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockUsers.EXPECT().GetUser(ctx, userID).Return(tt.user, nil)
+			mockTokens.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Times(0)
+			mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+
+			err := svc.SendVerificationEmail(ctx, userID)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx, ctrl, mockUsers, mockTokens, _, mockMailer, svc := setup(t)
+	defer ctrl.Finish()
+
+	store := tokenStore(mockTokens)
+	mockUsers.EXPECT().GetUser(ctx, userID).Return(&models.User{ID: userID, Username: "alice", Email: "a@example.com"}, nil)
+
+	var token string
+	expectMail(t, mockMailer, "a@example.com", &token)
+
+	require.NoError(t, svc.SendVerificationEmail(ctx, userID))
+	require.Len(t, store, 1)
+	for hash, stored := range store {
+		assert.NotEqual(t, token, hash)
+		assert.Equal(t, models.PurposeEmailVerification, stored.Purpose)
+		assert.WithinDuration(t, time.Now().Add(constants.EmailVerificationTTL), stored.ExpiresAt, time.Minute)
+	}
+
+	mockUsers.EXPECT().MarkEmailVerified(ctx, userID).Return(nil)
+
+	assert.NoError(t, svc.VerifyEmail(ctx, token))
+	assert.ErrorIs(t, svc.VerifyEmail(ctx, token), errs.ErrUserTokenInvalid)
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	ctx, ctrl, mockUsers, mockTokens, _, mockMailer, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUsers.EXPECT().GetUserByEmail(ctx, "nobody@example.com").Return(nil, errs.ErrUserNotFound)
+	mockTokens.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Times(0)
+	mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.RequestPasswordReset(ctx, "nobody@example.com")
+	assert.NoError(t, err)
+}
+
+func TestResetPassword_SingleUse(t *testing.T) {
+	ctx, ctrl, mockUsers, mockTokens, mockSession, mockMailer, svc := setup(t)
+	defer ctrl.Finish()
+
+	tokenStore(mockTokens)
+	mockUsers.EXPECT().GetUserByEmail(ctx, "a@example.com").
+		Return(&models.User{ID: userID, Username: "alice", Email: "a@example.com"}, nil)
+
+	var token string
+	expectMail(t, mockMailer, "a@example.com", &token)
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, "a@example.com"))
+
+	mockUsers.EXPECT().UpdatePassword(ctx, userID, gomock.Any()).Return(nil)
+	mockSession.EXPECT().DeleteAllUserSessions(ctx, userID).Return(nil)
+
+	assert.NoError(t, svc.ResetPassword(ctx, token, "new-password"))
+	assert.ErrorIs(t, svc.ResetPassword(ctx, token, "other-password"), errs.ErrUserTokenInvalid)
+}
+
+func TestResetPassword_Expired(t *testing.T) {
+	ctx, ctrl, mockUsers, mockTokens, mockSession, mockMailer, svc := setup(t)
+	defer ctrl.Finish()
+
+	store := tokenStore(mockTokens)
+	mockUsers.EXPECT().GetUserByEmail(ctx, "a@example.com").
+		Return(&models.User{ID: userID, Username: "alice", Email: "a@example.com"}, nil)
+
+	var token string
+	expectMail(t, mockMailer, "a@example.com", &token)
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, "a@example.com"))
+	for _, stored := range store {
+		assert.WithinDuration(t, time.Now().Add(constants.PasswordResetTTL), stored.ExpiresAt, time.Minute)
+		stored.ExpiresAt = time.Now().Add(-time.Second)
+	}
+
+	mockUsers.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockSession.EXPECT().DeleteAllUserSessions(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.ResetPassword(ctx, token, "new-password")
+	assert.ErrorIs(t, err, errs.ErrUserTokenInvalid)
+}
+
+func TestResetPassword_WrongPurpose(t *testing.T) {
+	ctx, ctrl, mockUsers, mockTokens, mockSession, mockMailer, svc := setup(t)
+	defer ctrl.Finish()
+
+	tokenStore(mockTokens)
+	mockUsers.EXPECT().GetUser(ctx, userID).Return(&models.User{ID: userID, Username: "alice", Email: "a@example.com"}, nil)
+
+	var token string
+	expectMail(t, mockMailer, "a@example.com", &token)
+
+	require.NoError(t, svc.SendVerificationEmail(ctx, userID))
+
+	mockUsers.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockSession.EXPECT().DeleteAllUserSessions(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.ResetPassword(ctx, token, "new-password")
+	assert.ErrorIs(t, err, errs.ErrUserTokenInvalid)
+}
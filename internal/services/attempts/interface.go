@@ -0,0 +1,12 @@
+package attempts
+
+import "context"
+
+type LoginGuard interface {
+	Check(ctx context.Context, username, ip string) error
+	RegisterFailure(ctx context.Context, username, ip string) error
+	RegisterSuccess(ctx context.Context, username, ip string) error
+	// RunRetention deletes attempts older than the throttling window
+	// periodically until ctx is done.
+	RunRetention(ctx context.Context)
+}
@@ -0,0 +1,150 @@
+package attempts
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+const retentionInterval = time.Hour
+
+type loginGuard struct {
+	repo   repository.LoginAttemptRepository
+	policy config.LoginThrottle
+	logger logger.Logger
+	now    func() time.Time
+}
+
+func NewLoginGuard(repo repository.LoginAttemptRepository, policy config.LoginThrottle, log logger.Logger) LoginGuard {
+	return &loginGuard{
+		repo:   repo,
+		policy: policy,
+		logger: log.WithField("component", "loginGuard"),
+		now:    time.Now,
+	}
+}
+
+// Check returns a *errs.RetryAfterError wrapping ErrTooManyAttempts while the
+// username or the IP has to wait before the next login attempt.
+func (g *loginGuard) Check(ctx context.Context, username, ip string) error {
+	now := g.now()
+	since := now.Add(-g.policy.Window)
+
+	userStats, err := g.repo.GetUsernameFailures(ctx, username, since)
+	if err != nil {
+		g.logger.WithContext(ctx).WithError(err).Error("Failed to read username login failures")
+		return err
+	}
+
+	ipStats, err := g.repo.GetIPFailures(ctx, ip, since)
+	if err != nil {
+		g.logger.WithContext(ctx).WithError(err).Error("Failed to read IP login failures")
+		return err
+	}
+
+	wait := max(
+		g.retryAfter(userStats, g.policy.FreeAttempts, g.policy.LockoutThreshold, now),
+		g.retryAfter(ipStats, g.policy.IPFreeAttempts, g.policy.IPLockoutThreshold, now),
+	)
+
+	if wait > 0 {
+		g.logger.WithContext(ctx).WithFields(map[string]any{
+			"username":    username,
+			"ip":          ip,
+			"retry_after": wait.String(),
+		}).Warn("Login attempt throttled")
+		return &errs.RetryAfterError{Err: errs.ErrTooManyAttempts, RetryAfter: wait}
+	}
+
+	return nil
+}
+
+func (g *loginGuard) RegisterFailure(ctx context.Context, username, ip string) error {
+	g.logger.WithContext(ctx).WithFields(map[string]any{
+		"event":    "login_failed",
+		"username": username,
+		"ip":       ip,
+	}).Warn("Failed login attempt")
+
+	return g.record(ctx, username, ip, false)
+}
+
+func (g *loginGuard) RegisterSuccess(ctx context.Context, username, ip string) error {
+	return g.record(ctx, username, ip, true)
+}
+
+func (g *loginGuard) record(ctx context.Context, username, ip string, success bool) error {
+	err := g.repo.RecordAttempt(ctx, models.LoginAttempt{
+		Username:  username,
+		IP:        ip,
+		Success:   success,
+		CreatedAt: g.now(),
+	})
+	if err != nil {
+		g.logger.WithContext(ctx).WithError(err).Error("Failed to record login attempt")
+	}
+
+	return err
+}
+
+func (g *loginGuard) RunRetention(ctx context.Context) {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		g.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge deletes attempts that no longer count towards any throttle.
+func (g *loginGuard) purge(ctx context.Context) {
+	deleted, err := g.repo.DeleteAttemptsBefore(ctx, g.now().Add(-g.policy.Window))
+	if err != nil {
+		g.logger.WithContext(ctx).WithError(err).Error("Failed to delete expired login attempts")
+		return
+	}
+
+	if deleted > 0 {
+		g.logger.WithContext(ctx).Infof("Deleted %d expired login attempts", deleted)
+	}
+}
+
+// retryAfter applies the policy to one failure counter: free attempts pass,
+// each further failure doubles the delay up to MaxDelay, and the lockout
+// threshold blocks for LockoutDuration after the last failure.
+func (g *loginGuard) retryAfter(stats models.FailureStats, free, lockout int, now time.Time) time.Duration {
+	if stats.Count == 0 {
+		return 0
+	}
+
+	var delay time.Duration
+	switch {
+	case lockout > 0 && stats.Count >= lockout:
+		delay = g.policy.LockoutDuration
+	case stats.Count >= free:
+		delay = g.policy.BaseDelay << min(stats.Count-free, 30)
+		if delay > g.policy.MaxDelay || delay <= 0 {
+			delay = g.policy.MaxDelay
+		}
+	default:
+		return 0
+	}
+
+	wait := stats.LastFailure.Add(delay).Sub(now)
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
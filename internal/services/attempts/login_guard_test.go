@@ -0,0 +1,139 @@
+package attempts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+)
+
+const (
+	username = "alice"
+	ip       = "10.0.0.1"
+)
+
+var policy = config.LoginThrottle{
+	Window:             time.Hour,
+	FreeAttempts:       3,
+	BaseDelay:          time.Second,
+	MaxDelay:           time.Minute,
+	LockoutThreshold:   6,
+	LockoutDuration:    15 * time.Minute,
+	IPFreeAttempts:     10,
+	IPLockoutThreshold: 20,
+}
+
+func setup(t *testing.T) (context.Context, *loginGuard, *time.Time) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	guard := NewLoginGuard(repository.NewMemoryLoginAttemptRepo(), policy, mockLogger).(*loginGuard)
+	guard.now = func() time.Time { return now }
+
+	return context.Background(), guard, &now
+}
+
+func retryAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+
+	var retry *errs.RetryAfterError
+	if !errors.As(err, &retry) {
+		t.Fatalf("expected RetryAfterError, got %v", err)
+	}
+	assert.ErrorIs(t, err, errs.ErrTooManyAttempts)
+
+	return retry.RetryAfter
+}
+
+func TestCheck_FreeAttempts(t *testing.T) {
+	ctx, guard, _ := setup(t)
+
+	for i := 0; i < policy.FreeAttempts-1; i++ {
+		assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+	}
+
+	assert.NoError(t, guard.Check(ctx, username, ip))
+}
+
+func TestCheck_ProgressiveDelay(t *testing.T) {
+	ctx, guard, now := setup(t)
+
+	for i := 0; i < policy.FreeAttempts; i++ {
+		assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+	}
+	assert.Equal(t, time.Second, retryAfter(t, guard.Check(ctx, username, ip)))
+
+	*now = now.Add(time.Second)
+	assert.NoError(t, guard.Check(ctx, username, ip))
+
+	assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+	assert.Equal(t, 2*time.Second, retryAfter(t, guard.Check(ctx, username, ip)))
+}
+
+func TestCheck_Lockout(t *testing.T) {
+	ctx, guard, now := setup(t)
+
+	for i := 0; i < policy.LockoutThreshold; i++ {
+		assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+	}
+	assert.Equal(t, policy.LockoutDuration, retryAfter(t, guard.Check(ctx, username, ip)))
+
+	*now = now.Add(policy.LockoutDuration)
+	assert.NoError(t, guard.Check(ctx, username, ip))
+}
+
+func TestCheck_SuccessResetsUsername(t *testing.T) {
+	ctx, guard, _ := setup(t)
+
+	for i := 0; i < policy.FreeAttempts; i++ {
+		assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+	}
+	assert.NoError(t, guard.RegisterSuccess(ctx, username, ip))
+
+	assert.NoError(t, guard.Check(ctx, username, ip))
+}
+
+func TestCheck_IPAcrossUsernames(t *testing.T) {
+	ctx, guard, _ := setup(t)
+
+	for i := 0; i < policy.IPLockoutThreshold; i++ {
+		assert.NoError(t, guard.RegisterFailure(ctx, string(rune('a'+i)), ip))
+	}
+
+	assert.Equal(t, policy.LockoutDuration, retryAfter(t, guard.Check(ctx, "nobody", ip)))
+	assert.NoError(t, guard.Check(ctx, "nobody", "10.0.0.2"))
+}
+
+func TestPurge_DropsAttemptsOutsideWindow(t *testing.T) {
+	ctx, guard, now := setup(t)
+
+	assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+	*now = now.Add(policy.Window / 2)
+	assert.NoError(t, guard.RegisterFailure(ctx, username, ip))
+
+	*now = now.Add(policy.Window * 3 / 4)
+	guard.purge(ctx)
+
+	stats, err := guard.repo.GetIPFailures(ctx, ip, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Count)
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+const (
+	defaultLimit      = 50
+	maxLimit          = 500
+	retentionInterval = time.Hour
+)
+
+type auditService struct {
+	repo      repository.AuditRepository
+	retention time.Duration
+	logger    logger.Logger
+}
+
+// NewAuditService keeps entries for retention. Zero keeps them forever.
+func NewAuditService(repo repository.AuditRepository, retention time.Duration, log logger.Logger) AuditService {
+	return &auditService{
+		repo:      repo,
+		retention: retention,
+		logger:    log.WithField("component", "auditService"),
+	}
+}
+
+func (s *auditService) Record(ctx context.Context, entry models.AuditEntry) {
+	req := fromContext(ctx)
+	if entry.ActorID == nil && req.userID != 0 {
+		entry.ActorID = &req.userID
+	}
+	if entry.IP == "" {
+		entry.IP = req.ip
+	}
+	if entry.UserAgent == "" {
+		entry.UserAgent = req.userAgent
+	}
+
+	// the entry is written even if the request was cancelled right after
+	// the audited change went through
+	if err := s.repo.CreateEntry(context.WithoutCancel(ctx), entry); err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"action":      entry.Action,
+			"target_type": entry.TargetType,
+			"target_id":   entry.TargetID,
+		}).WithError(err).Error("Failed to write audit entry")
+	}
+}
+
+func (s *auditService) GetEntries(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+
+	entries, err := s.repo.GetEntries(ctx, filter)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to fetch audit entries")
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *auditService) RunRetention(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		s.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *auditService) purge(ctx context.Context) {
+	deleted, err := s.repo.DeleteEntriesBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to delete expired audit entries")
+		return
+	}
+
+	if deleted > 0 {
+		s.logger.WithContext(ctx).Infof("Deleted %d expired audit entries", deleted)
+	}
+}
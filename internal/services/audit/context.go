@@ -0,0 +1,33 @@
+package audit
+
+import "context"
+
+type contextKey int
+
+const requestKey contextKey = iota
+
+// request is who is calling, as far as the transport layer knows it.
+type request struct {
+	userID    int64
+	ip        string
+	userAgent string
+}
+
+// WithClient stores the client address of the current request.
+func WithClient(ctx context.Context, ip, userAgent string) context.Context {
+	r := fromContext(ctx)
+	r.ip, r.userAgent = ip, userAgent
+	return context.WithValue(ctx, requestKey, r)
+}
+
+// WithActor stores the authenticated user of the current request.
+func WithActor(ctx context.Context, userID int64) context.Context {
+	r := fromContext(ctx)
+	r.userID = userID
+	return context.WithValue(ctx, requestKey, r)
+}
+
+func fromContext(ctx context.Context) request {
+	r, _ := ctx.Value(requestKey).(request)
+	return r
+}
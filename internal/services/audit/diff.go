@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// Diff compares two states of an object by their JSON form and returns the
+// fields that differ. A nil before or after describes creation or deletion.
+// Fields listed in ignore are left out. A null and an empty list or object
+// count as the same value, since they differ only in how the state was loaded.
+func Diff(before, after any, ignore ...string) map[string]models.AuditChange {
+	old := toMap(before)
+	cur := toMap(after)
+
+	skip := make(map[string]bool, len(ignore))
+	for _, field := range ignore {
+		skip[field] = true
+	}
+
+	changes := map[string]models.AuditChange{}
+	for field, value := range old {
+		if skip[field] {
+			continue
+		}
+		if newValue, ok := cur[field]; !ok || !equal(value, newValue) {
+			changes[field] = models.AuditChange{Old: value, New: cur[field]}
+		}
+	}
+	for field, value := range cur {
+		if _, ok := old[field]; !ok && !skip[field] {
+			changes[field] = models.AuditChange{New: value}
+		}
+	}
+
+	return changes
+}
+
+func equal(a, b any) bool {
+	if isEmpty(a) && isEmpty(b) {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func toMap(v any) map[string]any {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// Redacted stands in for values that must not be stored in the audit log.
+const Redacted = "[REDACTED]"
+
+// Mask replaces the values of the listed fields with Redacted, so changes
+// still show that a field was edited without keeping what it held.
+func Mask(changes map[string]models.AuditChange, fields ...string) map[string]models.AuditChange {
+	for _, field := range fields {
+		change, ok := changes[field]
+		if !ok {
+			continue
+		}
+		if change.Old != nil {
+			change.Old = Redacted
+		}
+		if change.New != nil {
+			change.New = Redacted
+		}
+		changes[field] = change
+	}
+	return changes
+}
@@ -0,0 +1,95 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	before := &models.Monitor{ID: 1, Name: "api", Target: "https://a.example", Interval: 60, IsActive: true}
+	after := &models.Monitor{ID: 1, Name: "api", Target: "https://b.example", Interval: 60, IsActive: false}
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		ignore []string
+		want   map[string]models.AuditChange
+	}{
+		{
+			name:   "update",
+			before: before,
+			after:  after,
+			want: map[string]models.AuditChange{
+				"target":    {Old: "https://a.example", New: "https://b.example"},
+				"is_active": {Old: true, New: false},
+			},
+		},
+		{
+			name:   "ignored field",
+			before: before,
+			after:  after,
+			ignore: []string{"is_active"},
+			want: map[string]models.AuditChange{
+				"target": {Old: "https://a.example", New: "https://b.example"},
+			},
+		},
+		{
+			name:   "no changes",
+			before: before,
+			after:  before,
+			want:   map[string]models.AuditChange{},
+		},
+		{
+			name:   "nil and empty list",
+			before: &models.Monitor{ID: 1, Name: "api"},
+			after:  &models.Monitor{ID: 1, Name: "api", ParentIDs: []int64{}},
+			want:   map[string]models.AuditChange{},
+		},
+		{
+			name:   "parents removed",
+			before: &models.Monitor{ID: 1, Name: "api", ParentIDs: []int64{2}},
+			after:  &models.Monitor{ID: 1, Name: "api", ParentIDs: []int64{}},
+			want: map[string]models.AuditChange{
+				"parent_ids": {Old: []any{float64(2)}, New: []any{}},
+			},
+		},
+		{
+			name:   "deletion",
+			before: map[string]any{"name": "api"},
+			after:  (*models.Monitor)(nil),
+			want: map[string]models.AuditChange{
+				"name": {Old: "api"},
+			},
+		},
+		{
+			name:   "creation",
+			before: nil,
+			after:  map[string]any{"name": "api"},
+			want: map[string]models.AuditChange{
+				"name": {New: "api"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, audit.Diff(tt.before, tt.after, tt.ignore...))
+		})
+	}
+}
+
+func TestMask(t *testing.T) {
+	before := map[string]any{"name": "api", "request": map[string]any{"headers": map[string]any{"Authorization": "Bearer old"}}}
+	after := map[string]any{"name": "api", "request": map[string]any{"headers": map[string]any{"Authorization": "Bearer new"}}, "expected_response": map[string]any{"status": 200}}
+
+	changes := audit.Mask(audit.Diff(before, after), "request", "expected_response")
+
+	assert.Equal(t, map[string]models.AuditChange{
+		"request":           {Old: audit.Redacted, New: audit.Redacted},
+		"expected_response": {New: audit.Redacted},
+	}, changes)
+}
@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type AuditService interface {
+	// Record appends an entry. Actor, IP and user agent are taken from the
+	// request context when not set. Failures are logged, never returned, so
+	// auditing can't break the audited operation.
+	Record(ctx context.Context, entry models.AuditEntry)
+	GetEntries(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error)
+	// RunRetention deletes expired entries periodically until ctx is done.
+	RunRetention(ctx context.Context)
+}
@@ -5,57 +5,183 @@ import (
 	"errors"
 	"time"
 
+	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/models/dto"
 	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/account"
+	"github.com/mixdone/uptime-monitoring/internal/services/attempts"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
 	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/internal/services/oidc"
 	"github.com/mixdone/uptime-monitoring/internal/services/session"
 	"github.com/mixdone/uptime-monitoring/internal/services/token"
+	"github.com/mixdone/uptime-monitoring/internal/services/twofactor"
 	"github.com/mixdone/uptime-monitoring/internal/services/user"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
 	"github.com/mixdone/uptime-monitoring/pkg/logger"
 )
 
+// dummyPasswordHash is compared against when the username doesn't exist, so
+// unknown and known usernames take the same time to reject.
+const dummyPasswordHash = "$2a$10$Zfo9w.Lx7nkOStmOYJRkZOMnr4okFwssTQ3RuUkDuIkfcc4VZtEJ2"
+
 type authService struct {
-	logger  logger.Logger
-	user    user.UserService
-	session session.SessionService
-	token   token.TokenService
+	logger    logger.Logger
+	user      user.UserService
+	session   session.SessionService
+	token     token.TokenService
+	guard     attempts.LoginGuard
+	twoFactor twofactor.TwoFactorService
+	account   account.AccountService
+	oidc      oidc.OIDCService
+	audit     audit.AuditService
 }
 
-func NewAuthService(user user.UserService, session session.SessionService, token token.TokenService, log logger.Logger) AuthenticationService {
+func NewAuthService(user user.UserService, session session.SessionService, token token.TokenService,
+	guard attempts.LoginGuard, twoFactor twofactor.TwoFactorService, account account.AccountService,
+	oidc oidc.OIDCService, audit audit.AuditService, log logger.Logger) AuthenticationService {
 	return &authService{
-		logger:  log,
-		user:    user,
-		session: session,
-		token:   token,
+		logger:    log,
+		user:      user,
+		session:   session,
+		token:     token,
+		guard:     guard,
+		twoFactor: twoFactor,
+		account:   account,
+		oidc:      oidc,
+		audit:     audit,
 	}
 }
 
 func (a *authService) Register(ctx context.Context, userDTO dto.RegisterRequest) (*dto.AuthResult, error) {
+	ctx, span := tracing.Start(ctx, "authService.Register")
+	defer span.End()
+
 	id, err := a.user.RegisterUser(ctx, userDTO)
 	if err != nil {
 		return nil, err
 	}
 
-	return a.createAuthResult(ctx, id, userDTO.Fingerprint)
+	// the account is usable right away, a lost email can be resent later
+	if userDTO.Email != "" {
+		if err := a.account.SendVerificationEmail(ctx, id); err != nil {
+			a.logger.WithContext(ctx).WithField("userID", id).
+				WithError(err).
+				Warn("Verification email not sent")
+		}
+	}
 
+	a.audit.Record(ctx, models.AuditEntry{
+		ActorID:    &id,
+		Action:     models.AuditRegister,
+		TargetType: models.AuditTargetUser,
+		TargetID:   &id,
+	})
+
+	return a.createAuthResult(ctx, id, userDTO.Fingerprint, userDTO.Client, "register")
 }
 
 func (a *authService) Login(ctx context.Context, userDTO dto.LoginRequest) (*dto.AuthResult, error) {
+	ctx, span := tracing.Start(ctx, "authService.Login")
+	defer span.End()
+
+	if err := a.guard.Check(ctx, userDTO.Username, userDTO.Client.IP); err != nil {
+		return nil, err
+	}
+
 	user, err := a.user.GetByUsername(ctx, userDTO.Username)
-	if err != nil {
+	if errors.Is(err, errs.ErrUserNotFound) {
+		a.user.VerifyPassword(dummyPasswordHash, userDTO.Password)
+		a.guard.RegisterFailure(ctx, userDTO.Username, userDTO.Client.IP)
+		a.recordLoginFailure(ctx, nil, userDTO.Username, "unknown_user")
+		return nil, errs.ErrInvalidCredentials
+	} else if err != nil {
 		return nil, err
 	}
 
 	if !a.user.VerifyPassword(user.PasswordHash, userDTO.Password) {
-		return nil, errors.New("wrong password")
+		a.guard.RegisterFailure(ctx, userDTO.Username, userDTO.Client.IP)
+		a.recordLoginFailure(ctx, &user.ID, userDTO.Username, "invalid_password")
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	enabled, err := a.twoFactor.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the attempt counts as successful only after the second factor, so
+	// guessing codes is throttled like guessing passwords
+	if enabled {
+		challenge, err := a.token.GenerateChallenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &dto.AuthResult{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+		}, nil
+	}
+
+	a.guard.RegisterSuccess(ctx, userDTO.Username, userDTO.Client.IP)
+
+	return a.createAuthResult(ctx, user.ID, userDTO.Fingerprint, userDTO.Client, "password")
+}
+
+func (a *authService) CompleteTwoFactorLogin(ctx context.Context, userDTO dto.TwoFactorLoginRequest) (*dto.AuthResult, error) {
+	ctx, span := tracing.Start(ctx, "authService.CompleteTwoFactorLogin")
+	defer span.End()
+
+	userID, err := a.token.ValidateChallenge(userDTO.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.guard.Check(ctx, user.Username, userDTO.Client.IP); err != nil {
+		return nil, err
+	}
+
+	err = a.twoFactor.Verify(ctx, userID, userDTO.Code, userDTO.RecoveryCode)
+	if errors.Is(err, errs.ErrInvalidTwoFactorCode) {
+		a.guard.RegisterFailure(ctx, user.Username, userDTO.Client.IP)
+		a.recordLoginFailure(ctx, &userID, user.Username, "invalid_two_factor_code")
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	a.guard.RegisterSuccess(ctx, user.Username, userDTO.Client.IP)
+
+	return a.createAuthResult(ctx, userID, userDTO.Fingerprint, userDTO.Client, "two_factor")
+}
+
+// CompleteOIDCLogin issues tokens for a login confirmed by the identity
+// provider. The second factor is left to the provider.
+func (a *authService) CompleteOIDCLogin(ctx context.Context, userDTO dto.OIDCCallbackRequest) (*dto.AuthResult, error) {
+	ctx, span := tracing.Start(ctx, "authService.CompleteOIDCLogin")
+	defer span.End()
+
+	if a.oidc == nil {
+		return nil, errs.ErrSSODisabled
+	}
+
+	userID, fingerprint, err := a.oidc.Complete(ctx, userDTO.Code, userDTO.State)
+	if err != nil {
+		return nil, err
 	}
 
-	return a.createAuthResult(ctx, user.ID, userDTO.Fingerprint)
+	return a.createAuthResult(ctx, userID, fingerprint, userDTO.Client, "oidc")
 }
 
 func (a *authService) Logout(ctx context.Context, userID int64, userDTO dto.LogoutRequest) error {
-	session, err := a.session.GetSession(ctx, userID, userDTO.RefreshToken, userDTO.Fingerprint)
-	if errors.Is(err, errs.ErrSessionNotFound) {
+	session, err := a.session.ResolveRefreshToken(ctx, userID, userDTO.RefreshToken, userDTO.Fingerprint)
+	if errors.Is(err, errs.ErrSessionNotFound) || errors.Is(err, errs.ErrTokenReused) {
 		return nil
 	} else if err != nil {
 		return err
@@ -65,33 +191,94 @@ func (a *authService) Logout(ctx context.Context, userID int64, userDTO dto.Logo
 		return err
 	}
 
+	a.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditLogout,
+		TargetType: models.AuditTargetSession,
+		TargetID:   &session.ID,
+	})
+
 	return nil
 }
 
+func (a *authService) LogoutAll(ctx context.Context, userID int64) error {
+	return a.session.DeleteAllUserSessions(ctx, userID)
+}
+
+// RefreshTokens rotates the refresh token inside its session. The presented
+// token stays on record as rotated, so a second use of it revokes the family.
 func (a *authService) RefreshTokens(ctx context.Context, userID int64, userDTO dto.RefreshRequest) (*dto.AuthResult, error) {
+	ctx, span := tracing.Start(ctx, "authService.RefreshTokens")
+	defer span.End()
 
-	err := a.Logout(ctx, userID, dto.LogoutRequest(userDTO))
+	session, err := a.session.ResolveRefreshToken(ctx, userID, userDTO.RefreshToken, userDTO.Fingerprint)
 	if err != nil {
 		return nil, err
 	}
 
-	return a.createAuthResult(ctx, userID, userDTO.Fingerprint)
+	accessToken, refreshToken, err := a.token.Generate(session.UserID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.ExpiresAt = time.Now().Add(constants.RefreshTokenTTL)
+	session.IP = userDTO.Client.IP
+	session.UserAgent = userDTO.Client.UserAgent
+
+	if err := a.session.RotateRefreshToken(ctx, *session, userDTO.RefreshToken, refreshToken); err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthResult{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
-func (a *authService) createAuthResult(ctx context.Context, userID int64, fingerprint string) (*dto.AuthResult, error) {
-	accessToken, refreshToken, err := a.token.Generate(userID)
+func (a *authService) createAuthResult(ctx context.Context, userID int64, fingerprint string,
+	client dto.ClientInfo, method string) (*dto.AuthResult, error) {
+	session := models.Session{
+		UserID:      userID,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(constants.RefreshTokenTTL),
+		IP:          client.IP,
+		UserAgent:   client.UserAgent,
+	}
+
+	id, err := a.session.CreateSession(ctx, session)
 	if err != nil {
 		return nil, err
 	}
 
-	expireAt := time.Now().Add(constants.RefreshTokenTTL)
-	_, err = a.session.CreateSession(ctx, userID, refreshToken, fingerprint, expireAt)
+	accessToken, refreshToken, err := a.token.Generate(userID, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := a.session.StoreRefreshToken(ctx, id, refreshToken); err != nil {
+		return nil, err
+	}
+
+	a.audit.Record(ctx, models.AuditEntry{
+		ActorID:    &userID,
+		Action:     models.AuditLogin,
+		TargetType: models.AuditTargetUser,
+		TargetID:   &userID,
+		Details:    map[string]any{"method": method, "session_id": id},
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+	})
+
 	return &dto.AuthResult{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
+
+func (a *authService) recordLoginFailure(ctx context.Context, userID *int64, username, reason string) {
+	a.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditLoginFailed,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID,
+		Details:    map[string]any{"username": username, "reason": reason},
+	})
+}
@@ -9,6 +9,9 @@ import (
 type AuthenticationService interface {
 	Register(ctx context.Context, userDTO dto.RegisterRequest) (*dto.AuthResult, error)
 	Login(ctx context.Context, userDTO dto.LoginRequest) (*dto.AuthResult, error)
+	CompleteTwoFactorLogin(ctx context.Context, userDTO dto.TwoFactorLoginRequest) (*dto.AuthResult, error)
+	CompleteOIDCLogin(ctx context.Context, userDTO dto.OIDCCallbackRequest) (*dto.AuthResult, error)
 	Logout(ctx context.Context, userID int64, userDTO dto.LogoutRequest) error
+	LogoutAll(ctx context.Context, userID int64) error
 	RefreshTokens(ctx context.Context, userID int64, userDTO dto.RefreshRequest) (*dto.AuthResult, error)
 }
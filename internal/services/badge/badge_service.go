@@ -0,0 +1,286 @@
+package badge
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/mixdone/uptime-monitoring/internal/services/maintenance"
+	"github.com/mixdone/uptime-monitoring/internal/services/monitors"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+const (
+	// DefaultPeriod is used by the uptime badge when no period is asked for.
+	DefaultPeriod = 30 * 24 * time.Hour
+	// MaxPeriod matches the uptime history kept for status pages.
+	MaxPeriod = 90 * 24 * time.Hour
+
+	latencyPeriod = 24 * time.Hour
+)
+
+var periodPattern = regexp.MustCompile(`^([1-9][0-9]{0,3})([hd])$`)
+
+type badgeService struct {
+	repo        repository.BadgeRepository
+	checks      repository.CheckRepository
+	monitors    monitors.MonitorService
+	maintenance maintenance.MaintenanceService
+	audit       audit.AuditService
+	logger      logger.Logger
+}
+
+func NewBadgeService(repo repository.BadgeRepository, checks repository.CheckRepository,
+	monitors monitors.MonitorService, maintenance maintenance.MaintenanceService,
+	audit audit.AuditService, log logger.Logger) BadgeService {
+
+	return &badgeService{
+		repo:        repo,
+		checks:      checks,
+		monitors:    monitors,
+		maintenance: maintenance,
+		audit:       audit,
+		logger:      log.WithField("component", "badgeService"),
+	}
+}
+
+func (s *badgeService) EnableBadge(ctx context.Context, orgID, monitorID int64) (*models.Badge, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	badge := models.Badge{
+		MonitorID: monitorID,
+		Token:     base64.RawURLEncoding.EncodeToString(b),
+	}
+
+	if err := s.repo.SaveBadge(ctx, badge); err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", monitorID).
+			WithError(err).
+			Error("Failed to save badge")
+		return nil, err
+	}
+	badge.CreatedAt = time.Now()
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditBadgeEnable,
+		TargetType:     models.AuditTargetMonitor,
+		TargetID:       &monitorID,
+	})
+
+	s.logger.WithContext(ctx).WithField("monitor_id", monitorID).Info("Badge enabled")
+	return &badge, nil
+}
+
+func (s *badgeService) GetBadge(ctx context.Context, monitorID int64) (*models.Badge, error) {
+	badge, err := s.repo.GetBadge(ctx, monitorID)
+	if err != nil && !errors.Is(err, errs.ErrBadgeNotFound) {
+		s.logger.WithContext(ctx).WithField("monitor_id", monitorID).
+			WithError(err).
+			Error("Failed to fetch badge")
+	}
+	return badge, err
+}
+
+func (s *badgeService) DisableBadge(ctx context.Context, orgID, monitorID int64) error {
+	if err := s.repo.DeleteBadge(ctx, monitorID); err != nil {
+		if !errors.Is(err, errs.ErrBadgeNotFound) {
+			s.logger.WithContext(ctx).WithField("monitor_id", monitorID).
+				WithError(err).
+				Error("Failed to delete badge")
+		}
+		return err
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditBadgeDisable,
+		TargetType:     models.AuditTargetMonitor,
+		TargetID:       &monitorID,
+	})
+
+	s.logger.WithContext(ctx).WithField("monitor_id", monitorID).Info("Badge disabled")
+	return nil
+}
+
+// StatusBadge shows whether the monitor is up as of its latest check.
+// Maintenance and paused monitors are shown as such instead.
+func (s *badgeService) StatusBadge(ctx context.Context, token string) ([]byte, error) {
+	badge, err := s.lookup(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor, err := s.monitors.GetMonitor(ctx, badge.MonitorID)
+	if err != nil {
+		return nil, err
+	}
+	if !monitor.IsActive {
+		return Render("status", "paused", ColorGrey), nil
+	}
+
+	active, err := s.maintenance.MonitorMaintenance(ctx, badge.MonitorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(active) > 0 {
+		return Render("status", "maintenance", ColorBlue), nil
+	}
+
+	statuses, err := s.checks.GetLatestStatuses(ctx, []int64{badge.MonitorID})
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", badge.MonitorID).
+			WithError(err).
+			Error("Failed to fetch monitor status")
+		return nil, err
+	}
+
+	switch statuses[badge.MonitorID] {
+	case models.CheckUp:
+		return Render("status", "up", ColorGreen), nil
+	case "":
+		return Render("status", "unknown", ColorGrey), nil
+	default:
+		return Render("status", "down", ColorRed), nil
+	}
+}
+
+// UptimeBadge shows the share of successful checks within the period.
+// Checks during maintenance count as successful, as on status pages.
+func (s *badgeService) UptimeBadge(ctx context.Context, token string, period time.Duration) ([]byte, error) {
+	badge, err := s.lookup(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.checks.GetDailyUptime(ctx, []int64{badge.MonitorID}, time.Now().Add(-period))
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", badge.MonitorID).
+			WithError(err).
+			Error("Failed to fetch uptime")
+		return nil, err
+	}
+
+	var checks, up int
+	for _, day := range daily[badge.MonitorID] {
+		checks += day.Checks
+		up += day.Up
+	}
+
+	label := "uptime " + FormatPeriod(period)
+	if checks == 0 {
+		return Render(label, "no data", ColorGrey), nil
+	}
+
+	uptime := float64(up) * 100 / float64(checks)
+	return Render(label, formatUptime(uptime), uptimeColor(uptime)), nil
+}
+
+// LatencyBadge shows the mean response time of the successful checks of
+// the last day.
+func (s *badgeService) LatencyBadge(ctx context.Context, token string) ([]byte, error) {
+	badge, err := s.lookup(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	average, err := s.checks.GetAverageResponseTime(ctx, badge.MonitorID, time.Now().Add(-latencyPeriod))
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", badge.MonitorID).
+			WithError(err).
+			Error("Failed to fetch response time")
+		return nil, err
+	}
+
+	if average == nil {
+		return Render("latency", "no data", ColorGrey), nil
+	}
+
+	return Render("latency", fmt.Sprintf("%dms", *average), latencyColor(*average)), nil
+}
+
+func (s *badgeService) lookup(ctx context.Context, token string) (*models.Badge, error) {
+	badge, err := s.repo.GetBadgeByToken(ctx, token)
+	if err != nil && !errors.Is(err, errs.ErrBadgeNotFound) {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to fetch badge")
+	}
+	return badge, err
+}
+
+// ParsePeriod reads periods such as 24h or 30d. An empty string is the
+// default period.
+func ParsePeriod(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultPeriod, nil
+	}
+
+	match := periodPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("%w: use hours or days such as 24h or 30d", errs.ErrInvalidBadgePeriod)
+	}
+
+	n, _ := strconv.Atoi(match[1])
+	period := time.Duration(n) * time.Hour
+	if match[2] == "d" {
+		period *= 24
+	}
+
+	if period > MaxPeriod {
+		return 0, fmt.Errorf("%w: at most %s", errs.ErrInvalidBadgePeriod, FormatPeriod(MaxPeriod))
+	}
+
+	return period, nil
+}
+
+// FormatPeriod writes a period the way ParsePeriod reads it, in days unless
+// it is a day or less or not a whole number of days.
+func FormatPeriod(period time.Duration) string {
+	hours := int(period / time.Hour)
+	if hours > 24 && hours%24 == 0 {
+		return strconv.Itoa(hours/24) + "d"
+	}
+	return strconv.Itoa(hours) + "h"
+}
+
+// formatUptime keeps two decimals but never rounds up to a perfect 100%.
+func formatUptime(uptime float64) string {
+	value := strconv.FormatFloat(uptime, 'f', 2, 64)
+	if value == "100.00" && uptime < 100 {
+		value = "99.99"
+	}
+	return value + "%"
+}
+
+func uptimeColor(uptime float64) string {
+	switch {
+	case uptime >= 99.9:
+		return ColorGreen
+	case uptime >= 99:
+		return ColorYellow
+	case uptime >= 95:
+		return ColorOrange
+	default:
+		return ColorRed
+	}
+}
+
+func latencyColor(ms int64) string {
+	switch {
+	case ms < 300:
+		return ColorGreen
+	case ms < 1000:
+		return ColorYellow
+	default:
+		return ColorRed
+	}
+}
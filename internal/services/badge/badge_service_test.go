@@ -0,0 +1,163 @@
+package badge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/badge"
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockBadgeRepository, *mocks.MockCheckRepository, *mocks.MockMonitorService, *mocks.MockMaintenanceService, *mocks.MockAuditService, badge.BadgeService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockBadgeRepository(ctrl)
+	mockChecks := mocks.NewMockCheckRepository(ctrl)
+	mockMonitors := mocks.NewMockMonitorService(ctrl)
+	mockMaintenance := mocks.NewMockMaintenanceService(ctrl)
+	mockAudit := mocks.NewMockAuditService(ctrl)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := badge.NewBadgeService(mockRepo, mockChecks, mockMonitors, mockMaintenance, mockAudit, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockChecks, mockMonitors, mockMaintenance, mockAudit, svc
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   bool
+	}{
+		{"", badge.DefaultPeriod, false},
+		{"24h", 24 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"90d", badge.MaxPeriod, false},
+		{"91d", 0, true},
+		{"0d", 0, true},
+		{"30", 0, true},
+		{"1w", 0, true},
+		{"-1d", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := badge.ParsePeriod(tt.value)
+			if tt.err {
+				assert.ErrorIs(t, err, errs.ErrInvalidBadgePeriod)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.value != "" {
+				assert.Equal(t, tt.value, badge.FormatPeriod(got))
+			}
+		})
+	}
+}
+
+func TestEnableBadge_IssuesToken(t *testing.T) {
+	ctx, ctrl, mockRepo, _, _, _, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	var saved models.Badge
+	mockRepo.EXPECT().SaveBadge(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, b models.Badge) error {
+		saved = b
+		return nil
+	})
+	mockAudit.EXPECT().Record(ctx, gomock.Any())
+
+	b, err := svc.EnableBadge(ctx, 1, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), saved.MonitorID)
+	assert.Len(t, saved.Token, 32)
+	assert.Equal(t, saved.Token, b.Token)
+}
+
+func TestStatusBadge(t *testing.T) {
+	tests := []struct {
+		name        string
+		active      bool
+		maintenance []models.MaintenanceOccurrence
+		status      string
+		want        string
+	}{
+		{"up", true, nil, models.CheckUp, "status: up"},
+		{"down", true, nil, models.CheckDown, "status: down"},
+		{"never checked", true, nil, "", "status: unknown"},
+		{"maintenance", true, []models.MaintenanceOccurrence{{WindowID: 1}}, models.CheckDown, "status: maintenance"},
+		{"paused", false, nil, models.CheckUp, "status: paused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, mockChecks, mockMonitors, mockMaintenance, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().GetBadgeByToken(ctx, "tok").Return(&models.Badge{MonitorID: 7, Token: "tok"}, nil)
+			mockMonitors.EXPECT().GetMonitor(ctx, int64(7)).Return(&models.Monitor{ID: 7, IsActive: tt.active}, nil)
+			mockMaintenance.EXPECT().MonitorMaintenance(ctx, int64(7), gomock.Any()).Return(tt.maintenance, nil).AnyTimes()
+			mockChecks.EXPECT().GetLatestStatuses(ctx, []int64{7}).
+				Return(map[int64]string{7: tt.status}, nil).AnyTimes()
+
+			svg, err := svc.StatusBadge(ctx, "tok")
+
+			require.NoError(t, err)
+			assert.Contains(t, string(svg), "<title>"+tt.want+"</title>")
+		})
+	}
+}
+
+func TestUptimeBadge(t *testing.T) {
+	ctx, ctrl, mockRepo, mockChecks, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetBadgeByToken(ctx, "tok").Return(&models.Badge{MonitorID: 7, Token: "tok"}, nil)
+	mockChecks.EXPECT().GetDailyUptime(ctx, []int64{7}, gomock.Any()).Return(map[int64][]models.DailyUptime{
+		7: {{Checks: 10000, Up: 9999}, {Checks: 10000, Up: 10000}},
+	}, nil)
+
+	svg, err := svc.UptimeBadge(ctx, "tok", 7*24*time.Hour)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<title>uptime 7d: 99.99%</title>")
+	assert.Contains(t, string(svg), badge.ColorGreen)
+}
+
+func TestLatencyBadge_NoData(t *testing.T) {
+	ctx, ctrl, mockRepo, mockChecks, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetBadgeByToken(ctx, "tok").Return(&models.Badge{MonitorID: 7, Token: "tok"}, nil)
+	mockChecks.EXPECT().GetAverageResponseTime(ctx, int64(7), gomock.Any()).Return(nil, nil)
+
+	svg, err := svc.LatencyBadge(ctx, "tok")
+
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<title>latency: no data</title>")
+}
+
+func TestBadge_UnknownToken(t *testing.T) {
+	ctx, ctrl, mockRepo, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetBadgeByToken(ctx, "nope").Return(nil, errs.ErrBadgeNotFound)
+
+	_, err := svc.LatencyBadge(ctx, "nope")
+
+	assert.ErrorIs(t, err, errs.ErrBadgeNotFound)
+}
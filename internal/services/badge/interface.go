@@ -0,0 +1,22 @@
+package badge
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type BadgeService interface {
+	// EnableBadge issues a new token for the monitor, which invalidates the
+	// URLs of an earlier one.
+	EnableBadge(ctx context.Context, orgID, monitorID int64) (*models.Badge, error)
+	GetBadge(ctx context.Context, monitorID int64) (*models.Badge, error)
+	DisableBadge(ctx context.Context, orgID, monitorID int64) error
+
+	// StatusBadge, UptimeBadge and LatencyBadge render the SVG badges of
+	// the monitor the token belongs to.
+	StatusBadge(ctx context.Context, token string) ([]byte, error)
+	UptimeBadge(ctx context.Context, token string, period time.Duration) ([]byte, error)
+	LatencyBadge(ctx context.Context, token string) ([]byte, error)
+}
@@ -0,0 +1,87 @@
+package badge
+
+import (
+	"bytes"
+	"math"
+	"text/template"
+)
+
+// Badge colors, the same as the usual flat README badges.
+const (
+	ColorGreen  = "#4c1"
+	ColorYellow = "#dfb317"
+	ColorOrange = "#fe7d37"
+	ColorRed    = "#e05d44"
+	ColorBlue   = "#007ec6"
+	ColorGrey   = "#9f9f9f"
+)
+
+var badgeTemplate = template.Must(template.New("badge").Funcs(template.FuncMap{
+	"half": func(v float64) float64 { return v / 2 },
+}).Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="#555"/><rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{half .LabelWidth}}" y="15" fill="#010101" fill-opacity=".3">{{.Label}}</text><text x="{{half .LabelWidth}}" y="14">{{.Label}}</text>
+<text x="{{.MessageX}}" y="15" fill="#010101" fill-opacity=".3">{{.Message}}</text><text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
+
+type badgeData struct {
+	Label, Message, Color    string
+	LabelWidth, MessageWidth float64
+	Width, MessageX          float64
+}
+
+// Render draws a flat two-part badge. Label and message are escaped, color
+// must be one of the Color constants.
+func Render(label, message, color string) []byte {
+	data := badgeData{
+		Label:        escape(label),
+		Message:      escape(message),
+		Color:        color,
+		LabelWidth:   textWidth(label) + 10,
+		MessageWidth: textWidth(message) + 10,
+	}
+	data.Width = data.LabelWidth + data.MessageWidth
+	data.MessageX = data.LabelWidth + data.MessageWidth/2
+
+	var buf bytes.Buffer
+	// the template only fails on writer errors, which bytes.Buffer has none of
+	_ = badgeTemplate.Execute(&buf, data)
+	return buf.Bytes()
+}
+
+// textWidth estimates the width of s in 11px Verdana, rounded up to whole
+// pixels. It doesn't need to be exact, only never too narrow.
+func textWidth(s string) float64 {
+	var width float64
+	for _, r := range s {
+		switch {
+		case r == ' ':
+			width += 3.9
+		case r == 'i' || r == 'l' || r == 'j' || r == 'I' || r == '.' || r == ',' || r == ':' || r == '\'' || r == '|' || r == '!':
+			width += 3.5
+		case r == 'f' || r == 'r' || r == 't' || r == '(' || r == ')':
+			width += 4.7
+		case r == 'm' || r == 'w' || r == '%':
+			width += 11.8
+		case r >= 'A' && r <= 'Z':
+			width += 8
+		case r >= '0' && r <= '9':
+			width += 7
+		default:
+			width += 6.9
+		}
+	}
+	return math.Ceil(width)
+}
+
+func escape(s string) string {
+	var buf bytes.Buffer
+	template.HTMLEscape(&buf, []byte(s))
+	return buf.String()
+}
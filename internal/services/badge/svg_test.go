@@ -0,0 +1,47 @@
+package badge_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/services/badge"
+)
+
+func TestRender_WellFormed(t *testing.T) {
+	svg := badge.Render("status", "up", badge.ColorGreen)
+
+	var doc struct {
+		XMLName xml.Name
+		Width   int    `xml:"width,attr"`
+		Title   string `xml:"title"`
+	}
+	require.NoError(t, xml.Unmarshal(svg, &doc))
+	assert.Equal(t, "svg", doc.XMLName.Local)
+	assert.Equal(t, "status: up", doc.Title)
+	assert.Positive(t, doc.Width)
+}
+
+func TestRender_EscapesText(t *testing.T) {
+	svg := string(badge.Render("a<b>", `"&"`, badge.ColorGrey))
+
+	assert.NotContains(t, svg, "a<b>")
+	assert.Contains(t, svg, "a&lt;b&gt;")
+	assert.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})))
+}
+
+func TestRender_WidthGrowsWithText(t *testing.T) {
+	short := badge.Render("uptime", "99%", badge.ColorGreen)
+	long := badge.Render("uptime", "99.95%", badge.ColorGreen)
+
+	width := func(svg []byte) int {
+		var doc struct {
+			Width int `xml:"width,attr"`
+		}
+		require.NoError(t, xml.Unmarshal(svg, &doc))
+		return doc.Width
+	}
+	assert.Less(t, width(short), width(long))
+}
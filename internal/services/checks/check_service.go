@@ -0,0 +1,154 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services/incidents"
+	"github.com/mixdone/uptime-monitoring/internal/services/monitors"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/message"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const checkQueue = "monitor.checks"
+
+type checkService struct {
+	monitors     monitors.MonitorService
+	incidents    incidents.IncidentService
+	checker      Checker
+	mq           message.MQ
+	metrics      Metrics
+	workers      int
+	pollInterval time.Duration
+	logger       logger.Logger
+
+	// heartbeat holds the Unix nanoseconds of the last dispatch
+	heartbeat atomic.Int64
+}
+
+func NewCheckService(monitors monitors.MonitorService, incidents incidents.IncidentService, checker Checker,
+	mq message.MQ, metrics Metrics, workers int, pollInterval time.Duration, log logger.Logger) CheckService {
+
+	return &checkService{
+		monitors:     monitors,
+		incidents:    incidents,
+		checker:      checker,
+		mq:           mq,
+		metrics:      metrics,
+		workers:      max(workers, 1),
+		pollInterval: pollInterval,
+		logger:       log.WithField("component", "checkService"),
+	}
+}
+
+func (s *checkService) Run(ctx context.Context) {
+	for range s.workers {
+		if err := s.mq.Consume(ctx, checkQueue, func(body []byte) error {
+			return s.handle(ctx, body)
+		}); err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to start check worker")
+			return
+		}
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		s.heartbeat.Store(now.UnixNano())
+		s.dispatch(ctx, now)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *checkService) Heartbeat() time.Time {
+	nanos := s.heartbeat.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// dispatch queues every monitor whose interval has passed. last_checked_at is
+// moved forward on dispatch so a slow check isn't queued twice.
+func (s *checkService) dispatch(ctx context.Context, now time.Time) {
+	monitors, err := s.monitors.GetAllActiveMonitors(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, monitor := range monitors {
+		interval := time.Duration(monitor.Interval) * time.Second
+		if monitor.LastCheckedAt != nil && now.Sub(*monitor.LastCheckedAt) < interval {
+			continue
+		}
+		if monitor.LastCheckedAt != nil {
+			s.metrics.ObserveDispatch(now.Sub(monitor.LastCheckedAt.Add(interval)))
+		}
+
+		if err := s.monitors.UpdateLastCheckedAt(ctx, monitor.ID, now); err != nil {
+			continue
+		}
+
+		body, err := json.Marshal(monitor)
+		if err != nil {
+			s.logger.WithContext(ctx).WithField("monitor_id", monitor.ID).WithError(err).Error("Failed to encode check")
+			continue
+		}
+
+		if err := s.mq.Publish(checkQueue, body); err != nil {
+			s.logger.WithContext(ctx).WithField("monitor_id", monitor.ID).WithError(err).Error("Failed to queue check")
+		}
+	}
+}
+
+// handle runs one check in a trace of its own, so the probe and the
+// incident bookkeeping after it show up together per monitor.
+func (s *checkService) handle(ctx context.Context, body []byte) (err error) {
+	var monitor models.Monitor
+	if err := json.Unmarshal(body, &monitor); err != nil {
+		return err
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, "check",
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(tracing.MonitorID(monitor.ID), attribute.String("monitor.type", monitor.Type)),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logger.WithMonitorID(ctx, monitor.ID)
+	if span.SpanContext().IsValid() {
+		ctx = logger.WithTraceID(ctx, span.SpanContext().TraceID().String())
+	}
+
+	result := s.checker.Check(ctx, monitor)
+	s.metrics.ObserveCheck(monitor, result)
+
+	span.SetAttributes(
+		attribute.String("check.status", result.Status),
+		attribute.Int64("check.response_time_ms", result.ResponseTimeMs),
+	)
+	if result.Error != "" {
+		span.SetAttributes(attribute.String("check.error", result.Error))
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"status":           result.Status,
+		"response_time_ms": result.ResponseTimeMs,
+	}).Debug("Monitor checked")
+
+	return s.incidents.ProcessResult(ctx, monitor, result)
+}
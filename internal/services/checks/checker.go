@@ -0,0 +1,150 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	maxBodyRead    = 1 << 20
+)
+
+// Checker probes a monitor's target once.
+type Checker interface {
+	Check(ctx context.Context, monitor models.Monitor) models.CheckResult
+}
+
+// requestSpec is the request part of an http monitor.
+type requestSpec struct {
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// expectedResponse describes a healthy answer. Without a status any code
+// below 400 counts as up.
+type expectedResponse struct {
+	Status       int    `json:"status"`
+	BodyContains string `json:"body_contains"`
+}
+
+type httpChecker struct {
+	client *http.Client
+}
+
+// NewHTTPChecker traces the requests it sends, which also passes the trace
+// context on to targets that take part in tracing themselves.
+func NewHTTPChecker(client *http.Client) Checker {
+	traced := &http.Client{}
+	if client != nil {
+		*traced = *client
+	}
+
+	transport := traced.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	traced.Transport = otelhttp.NewTransport(transport)
+
+	return &httpChecker{client: traced}
+}
+
+func (c *httpChecker) Check(ctx context.Context, monitor models.Monitor) models.CheckResult {
+	result := models.CheckResult{
+		MonitorID: monitor.ID,
+		CheckedAt: time.Now(),
+		Status:    models.CheckDown,
+	}
+
+	if monitor.Type != "http" {
+		result.Error = fmt.Sprintf("unsupported monitor type %q", monitor.Type)
+		return result
+	}
+
+	var spec requestSpec
+	var expected expectedResponse
+	if err := unmarshalOptional(monitor.RequestSpec, &spec); err != nil {
+		result.Error = "invalid request spec: " + err.Error()
+		return result
+	}
+	if err := unmarshalOptional(monitor.ExpectedResponse, &expected); err != nil {
+		result.Error = "invalid expected response: " + err.Error()
+		return result
+	}
+
+	timeout := time.Duration(monitor.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), monitor.Target, bytes.NewBufferString(spec.Body))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	for name, value := range spec.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.client.Do(req)
+	result.ResponseTimeMs = time.Since(result.CheckedAt).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		expires := resp.TLS.PeerCertificates[0].NotAfter
+		result.CertificateExpiresAt = &expires
+	}
+
+	switch {
+	case expected.Status != 0 && resp.StatusCode != expected.Status:
+		result.Error = fmt.Sprintf("expected status %d, got %d", expected.Status, resp.StatusCode)
+		return result
+	case expected.Status == 0 && resp.StatusCode >= http.StatusBadRequest:
+		result.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		return result
+	}
+
+	if expected.BodyContains != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyRead))
+		if err != nil {
+			result.Error = "failed to read body: " + err.Error()
+			return result
+		}
+		if !strings.Contains(string(body), expected.BodyContains) {
+			result.Error = fmt.Sprintf("body does not contain %q", expected.BodyContains)
+			return result
+		}
+	}
+
+	result.Status = models.CheckUp
+	return result
+}
+
+func unmarshalOptional(data json.RawMessage, v any) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,118 @@
+package checks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services/checks"
+)
+
+func TestHTTPChecker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Write([]byte(`{"status":"ok"}`))
+		case "/created":
+			if r.Method != http.MethodPost || r.Header.Get("X-Token") != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		request  string
+		expected string
+		status   string
+		code     int
+	}{
+		{name: "up", path: "/health", status: models.CheckUp, code: http.StatusOK},
+		{name: "server error", path: "/down", status: models.CheckDown, code: http.StatusServiceUnavailable},
+		{
+			name:     "expected status",
+			path:     "/created",
+			request:  `{"method":"post","headers":{"X-Token":"secret"}}`,
+			expected: `{"status":201}`,
+			status:   models.CheckUp,
+			code:     http.StatusCreated,
+		},
+		{name: "unexpected status", path: "/health", expected: `{"status":204}`, status: models.CheckDown, code: http.StatusOK},
+		{name: "body matches", path: "/health", expected: `{"body_contains":"ok"}`, status: models.CheckUp, code: http.StatusOK},
+		{name: "body differs", path: "/health", expected: `{"body_contains":"degraded"}`, status: models.CheckDown, code: http.StatusOK},
+	}
+
+	checker := checks.NewHTTPChecker(srv.Client())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := models.Monitor{ID: 1, Type: "http", Target: srv.URL + tt.path, Timeout: 5}
+			if tt.request != "" {
+				monitor.RequestSpec = json.RawMessage(tt.request)
+			}
+			if tt.expected != "" {
+				monitor.ExpectedResponse = json.RawMessage(tt.expected)
+			}
+
+			res := checker.Check(context.Background(), monitor)
+
+			assert.Equal(t, tt.status, res.Status, res.Error)
+			assert.Equal(t, tt.code, res.StatusCode)
+			assert.Equal(t, int64(1), res.MonitorID)
+		})
+	}
+}
+
+func TestHTTPChecker_UnsupportedType(t *testing.T) {
+	res := checks.NewHTTPChecker(nil).Check(context.Background(), models.Monitor{Type: "icmp"})
+
+	assert.Equal(t, models.CheckDown, res.Status)
+	assert.NotEmpty(t, res.Error)
+}
+
+func TestHTTPChecker_CertificateExpiry(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	res := checks.NewHTTPChecker(srv.Client()).Check(context.Background(),
+		models.Monitor{Type: "http", Target: srv.URL})
+
+	assert.Equal(t, models.CheckUp, res.Status, res.Error)
+	if assert.NotNil(t, res.CertificateExpiresAt) {
+		assert.Equal(t, srv.Certificate().NotAfter, *res.CertificateExpiresAt)
+	}
+}
+
+func TestHTTPChecker_PropagatesTraceContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer srv.Close()
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "check")
+	defer span.End()
+
+	res := checks.NewHTTPChecker(srv.Client()).Check(ctx, models.Monitor{Type: "http", Target: srv.URL})
+
+	assert.Equal(t, models.CheckUp, res.Status)
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+}
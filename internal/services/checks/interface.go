@@ -0,0 +1,24 @@
+package checks
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type CheckService interface {
+	// Run dispatches due monitors to the check workers until ctx is done.
+	Run(ctx context.Context)
+	// Heartbeat is when the scheduler last looked for due monitors, zero
+	// before it first did.
+	Heartbeat() time.Time
+}
+
+// Metrics records what the scheduler and the workers do.
+type Metrics interface {
+	// ObserveDispatch records how long after it became due a monitor was
+	// queued.
+	ObserveDispatch(lag time.Duration)
+	ObserveCheck(monitor models.Monitor, result models.CheckResult)
+}
@@ -5,4 +5,13 @@ import "time"
 const (
 	RefreshTokenTTL = 7 * 24 * time.Hour
 	AccessTokenTTL  = 15 * time.Minute
+
+	TwoFactorChallengeTTL = 5 * time.Minute
+	TwoFactorIssuer       = "Uptime Monitoring"
+	RecoveryCodesCount    = 10
+
+	EmailVerificationTTL = 48 * time.Hour
+	PasswordResetTTL     = time.Hour
+
+	OIDCLoginStateTTL = 10 * time.Minute
 )
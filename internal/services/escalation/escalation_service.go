@@ -0,0 +1,372 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/mixdone/uptime-monitoring/internal/services/monitors"
+	"github.com/mixdone/uptime-monitoring/internal/services/notify"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+const claimBatch = 100
+
+type escalationService struct {
+	repo         repository.EscalationRepository
+	incidents    repository.IncidentRepository
+	channels     repository.ChannelRepository
+	monitors     monitors.MonitorService
+	sender       notify.Sender
+	fallback     notify.Notifier
+	audit        audit.AuditService
+	pollInterval time.Duration
+	logger       logger.Logger
+}
+
+func NewEscalationService(repo repository.EscalationRepository, incidents repository.IncidentRepository,
+	channels repository.ChannelRepository, monitors monitors.MonitorService, sender notify.Sender,
+	fallback notify.Notifier, audit audit.AuditService, pollInterval time.Duration, log logger.Logger) EscalationService {
+
+	return &escalationService{
+		repo:         repo,
+		incidents:    incidents,
+		channels:     channels,
+		monitors:     monitors,
+		sender:       sender,
+		fallback:     fallback,
+		audit:        audit,
+		pollInterval: pollInterval,
+		logger:       log.WithField("component", "escalationService"),
+	}
+}
+
+func (s *escalationService) CreatePolicy(ctx context.Context, policy models.EscalationPolicy) (int64, error) {
+	if err := s.validatePolicy(ctx, policy); err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.CreatePolicy(ctx, policy)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", policy.OrganizationID).
+			WithError(err).
+			Error("Failed to create escalation policy")
+		return 0, err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"organization_id": policy.OrganizationID,
+		"policy_id":       id,
+	}).Info("Escalation policy created")
+
+	return id, nil
+}
+
+func (s *escalationService) GetPolicy(ctx context.Context, orgID, id int64) (*models.EscalationPolicy, error) {
+	policy, err := s.repo.GetPolicy(ctx, id)
+	if errors.Is(err, errs.ErrEscalationPolicyNotFound) {
+		return nil, err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("policy_id", id).
+			WithError(err).
+			Error("Failed to fetch escalation policy")
+		return nil, err
+	}
+
+	if policy.OrganizationID != orgID {
+		return nil, errs.ErrEscalationPolicyNotFound
+	}
+
+	return policy, nil
+}
+
+func (s *escalationService) GetOrganizationPolicies(ctx context.Context, orgID int64) ([]models.EscalationPolicy, error) {
+	policies, err := s.repo.GetOrganizationPolicies(ctx, orgID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", orgID).
+			WithError(err).
+			Error("Failed to fetch escalation policies")
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (s *escalationService) UpdatePolicy(ctx context.Context, policy models.EscalationPolicy) error {
+	if _, err := s.GetPolicy(ctx, policy.OrganizationID, policy.ID); err != nil {
+		return err
+	}
+
+	if err := s.validatePolicy(ctx, policy); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdatePolicy(ctx, policy); err != nil {
+		if !errors.Is(err, errs.ErrEscalationPolicyNotFound) {
+			s.logger.WithContext(ctx).WithField("policy_id", policy.ID).
+				WithError(err).
+				Error("Failed to update escalation policy")
+		}
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithField("policy_id", policy.ID).Info("Escalation policy updated")
+	return nil
+}
+
+func (s *escalationService) DeletePolicy(ctx context.Context, orgID, id int64) error {
+	if _, err := s.GetPolicy(ctx, orgID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePolicy(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrEscalationPolicyNotFound) {
+			s.logger.WithContext(ctx).WithField("policy_id", id).
+				WithError(err).
+				Error("Failed to delete escalation policy")
+		}
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithField("policy_id", id).Info("Escalation policy deleted")
+	return nil
+}
+
+func (s *escalationService) Acknowledge(ctx context.Context, orgID, incidentID, userID int64) (*models.Incident, error) {
+	incident, err := s.incidents.GetIncident(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor, err := s.monitors.GetMonitor(ctx, incident.MonitorID)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.OrganizationID != orgID {
+		return nil, errs.ErrIncidentNotFound
+	}
+
+	incident, err = s.incidents.AcknowledgeIncident(ctx, incidentID, userID, time.Now())
+	if err != nil {
+		if !errors.Is(err, errs.ErrIncidentResolved) && !errors.Is(err, errs.ErrIncidentAcknowledged) {
+			s.logger.WithContext(ctx).WithField("incident_id", incidentID).
+				WithError(err).
+				Error("Failed to acknowledge incident")
+		}
+		return nil, err
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditIncidentAcknowledge,
+		TargetType:     models.AuditTargetIncident,
+		TargetID:       &incidentID,
+		Details:        map[string]any{"monitor_id": monitor.ID},
+	})
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"incident_id": incidentID,
+		"user_id":     userID,
+	}).Info("Incident acknowledged")
+
+	s.notifyPaged(ctx, models.Alert{Kind: models.AlertIncidentAcknowledged, Monitor: *monitor, Incident: *incident})
+	return incident, nil
+}
+
+func (s *escalationService) Notify(ctx context.Context, alert models.Alert) {
+	log := s.logger.WithContext(ctx).WithFields(map[string]any{
+		"alert":       alert.Kind,
+		"monitor_id":  alert.Monitor.ID,
+		"incident_id": alert.Incident.ID,
+	})
+
+	switch {
+	case alert.Monitor.EscalationPolicyID == nil && alert.Incident.EscalationLevel == 0:
+		// monitors without a policy alert the way they did before policies
+		// existed
+		s.fallback.Notify(ctx, alert)
+
+	case alert.Incident.Flapping:
+		s.notifyFirstLevel(ctx, alert)
+
+	case alert.Kind == models.AlertIncidentOpened:
+		now := time.Now()
+		err := s.repo.StartEscalation(ctx, alert.Incident.ID, *alert.Monitor.EscalationPolicyID, now)
+		if err != nil {
+			log.WithError(err).Error("Failed to start escalation")
+			return
+		}
+		log.Info("Escalation started")
+
+		// an immediate first level goes out now instead of on the next tick
+		s.escalate(ctx, now)
+
+	default:
+		s.notifyPaged(ctx, alert)
+	}
+}
+
+func (s *escalationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.escalate(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// escalate notifies every level that became due. Claimed levels are
+// persisted first, so after a restart escalation continues where it stopped.
+func (s *escalationService) escalate(ctx context.Context, now time.Time) {
+	for {
+		steps, err := s.repo.ClaimDueEscalations(ctx, now, claimBatch)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to claim due escalations")
+			return
+		}
+
+		for _, step := range steps {
+			monitor, err := s.monitors.GetMonitor(ctx, step.Incident.MonitorID)
+			if err != nil {
+				continue
+			}
+
+			kind := models.AlertIncidentEscalated
+			if step.Level == 0 {
+				kind = models.AlertIncidentOpened
+			}
+
+			s.send(ctx, step.ChannelIDs, models.Alert{
+				Kind:     kind,
+				Monitor:  *monitor,
+				Incident: step.Incident,
+				Level:    step.Level,
+			})
+		}
+
+		if len(steps) < claimBatch {
+			return
+		}
+	}
+}
+
+// notifyFirstLevel sends alert to the first level of the monitor's policy
+// only. Flapping monitors are reported this way instead of escalating.
+func (s *escalationService) notifyFirstLevel(ctx context.Context, alert models.Alert) {
+	if alert.Monitor.EscalationPolicyID == nil {
+		return
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, *alert.Monitor.EscalationPolicyID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", alert.Monitor.ID).
+			WithError(err).
+			Error("Failed to fetch escalation policy")
+		return
+	}
+	if len(policy.Levels) == 0 {
+		return
+	}
+
+	s.send(ctx, policy.Levels[0].ChannelIDs, alert)
+}
+
+// notifyPaged sends alert to the channels of every level that has already
+// been notified for the incident.
+func (s *escalationService) notifyPaged(ctx context.Context, alert models.Alert) {
+	incident := alert.Incident
+	if incident.EscalationPolicyID == nil || incident.EscalationLevel == 0 {
+		return
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, *incident.EscalationPolicyID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("incident_id", incident.ID).
+			WithError(err).
+			Error("Failed to fetch escalation policy")
+		return
+	}
+
+	var channelIDs []int64
+	for _, level := range policy.Levels[:min(incident.EscalationLevel, len(policy.Levels))] {
+		channelIDs = append(channelIDs, level.ChannelIDs...)
+	}
+	slices.Sort(channelIDs)
+
+	s.send(ctx, slices.Compact(channelIDs), alert)
+}
+
+func (s *escalationService) send(ctx context.Context, channelIDs []int64, alert models.Alert) {
+	log := s.logger.WithContext(ctx).WithFields(map[string]any{
+		"alert":       alert.Kind,
+		"monitor_id":  alert.Monitor.ID,
+		"incident_id": alert.Incident.ID,
+	})
+
+	channels, err := s.channels.GetChannels(ctx, alert.Monitor.OrganizationID, channelIDs)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch notification channels")
+		return
+	}
+
+	for _, channel := range channels {
+		if err := s.sender.Send(ctx, channel, alert); err != nil {
+			log.WithField("channel_id", channel.ID).
+				WithError(err).
+				Error("Failed to send notification")
+			continue
+		}
+
+		log.WithField("channel_id", channel.ID).Info("Notification sent")
+	}
+}
+
+// validatePolicy requires at least one level, delays that never decrease and
+// channels of the policy's own organization.
+func (s *escalationService) validatePolicy(ctx context.Context, policy models.EscalationPolicy) error {
+	if len(policy.Levels) == 0 {
+		return fmt.Errorf("%w: at least one level is required", errs.ErrInvalidEscalationPolicy)
+	}
+
+	var channelIDs []int64
+	for i, level := range policy.Levels {
+		if level.DelayMinutes < 0 {
+			return fmt.Errorf("%w: level %d has a negative delay", errs.ErrInvalidEscalationPolicy, i+1)
+		}
+		if i > 0 && level.DelayMinutes < policy.Levels[i-1].DelayMinutes {
+			return fmt.Errorf("%w: level %d fires before level %d", errs.ErrInvalidEscalationPolicy, i+1, i)
+		}
+		if len(level.ChannelIDs) == 0 {
+			return fmt.Errorf("%w: level %d has no channels", errs.ErrInvalidEscalationPolicy, i+1)
+		}
+		channelIDs = append(channelIDs, level.ChannelIDs...)
+	}
+
+	slices.Sort(channelIDs)
+	channelIDs = slices.Compact(channelIDs)
+
+	channels, err := s.channels.GetChannels(ctx, policy.OrganizationID, channelIDs)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", policy.OrganizationID).
+			WithError(err).
+			Error("Failed to fetch notification channels")
+		return err
+	}
+	if len(channels) != len(channelIDs) {
+		return errs.ErrChannelNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package escalation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/escalation"
+)
+
+const (
+	orgID     = int64(5)
+	monitorID = int64(7)
+	policyID  = int64(9)
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockEscalationRepository, *mocks.MockIncidentRepository, *mocks.MockChannelRepository, *mocks.MockMonitorService, *mocks.MockSender, *mocks.MockNotifier, *mocks.MockAuditService, escalation.EscalationService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockEscalationRepository(ctrl)
+	mockIncidents := mocks.NewMockIncidentRepository(ctrl)
+	mockChannels := mocks.NewMockChannelRepository(ctrl)
+	mockMonitors := mocks.NewMockMonitorService(ctrl)
+	mockSender := mocks.NewMockSender(ctrl)
+	mockFallback := mocks.NewMockNotifier(ctrl)
+	mockAudit := mocks.NewMockAuditService(ctrl)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := escalation.NewEscalationService(mockRepo, mockIncidents, mockChannels, mockMonitors,
+		mockSender, mockFallback, mockAudit, time.Minute, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockIncidents, mockChannels, mockMonitors, mockSender, mockFallback, mockAudit, svc
+}
+
+func channels(ids ...int64) []models.NotificationChannel {
+	var res []models.NotificationChannel
+	for _, id := range ids {
+		res = append(res, models.NotificationChannel{ID: id, OrganizationID: orgID, Type: models.ChannelWebhook})
+	}
+	return res
+}
+
+func monitor() *models.Monitor {
+	policy := policyID
+	return &models.Monitor{ID: monitorID, OrganizationID: orgID, EscalationPolicyID: &policy}
+}
+
+func TestCreatePolicy_Valid(t *testing.T) {
+	ctx, ctrl, mockRepo, _, mockChannels, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	policy := models.EscalationPolicy{
+		OrganizationID: orgID,
+		Name:           "on-call",
+		Levels: []models.EscalationLevel{
+			{DelayMinutes: 0, ChannelIDs: []int64{1}},
+			{DelayMinutes: 10, ChannelIDs: []int64{2, 1}},
+			{DelayMinutes: 30, ChannelIDs: []int64{3}},
+		},
+	}
+
+	mockChannels.EXPECT().GetChannels(ctx, orgID, []int64{1, 2, 3}).Return(channels(1, 2, 3), nil)
+	mockRepo.EXPECT().CreatePolicy(ctx, policy).Return(policyID, nil)
+
+	id, err := svc.CreatePolicy(ctx, policy)
+
+	require.NoError(t, err)
+	assert.Equal(t, policyID, id)
+}
+
+func TestCreatePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []models.EscalationLevel
+	}{
+		{"no levels", nil},
+		{"negative delay", []models.EscalationLevel{{DelayMinutes: -1, ChannelIDs: []int64{1}}}},
+		{"decreasing delay", []models.EscalationLevel{
+			{DelayMinutes: 10, ChannelIDs: []int64{1}},
+			{DelayMinutes: 5, ChannelIDs: []int64{2}},
+		}},
+		{"no channels", []models.EscalationLevel{{DelayMinutes: 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, _, _, _, _, _, _, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			_, err := svc.CreatePolicy(ctx, models.EscalationPolicy{OrganizationID: orgID, Name: "p", Levels: tt.levels})
+
+			assert.ErrorIs(t, err, errs.ErrInvalidEscalationPolicy)
+		})
+	}
+}
+
+func TestCreatePolicy_ForeignChannel(t *testing.T) {
+	ctx, ctrl, _, _, mockChannels, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockChannels.EXPECT().GetChannels(ctx, orgID, []int64{1, 2}).Return(channels(1), nil)
+
+	_, err := svc.CreatePolicy(ctx, models.EscalationPolicy{
+		OrganizationID: orgID,
+		Name:           "p",
+		Levels:         []models.EscalationLevel{{ChannelIDs: []int64{1, 2}}},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrChannelNotFound)
+}
+
+func TestNotify_OpenedStartsEscalation(t *testing.T) {
+	ctx, ctrl, mockRepo, _, mockChannels, mockMonitors, mockSender, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	incident := models.Incident{ID: 3, MonitorID: monitorID}
+	gomock.InOrder(
+		mockRepo.EXPECT().StartEscalation(ctx, int64(3), policyID, gomock.Any()).Return(nil),
+		mockRepo.EXPECT().ClaimDueEscalations(ctx, gomock.Any(), gomock.Any()).Return([]models.EscalationStep{
+			{Incident: incident, Level: 0, ChannelIDs: []int64{1}},
+		}, nil),
+	)
+	mockMonitors.EXPECT().GetMonitor(ctx, monitorID).Return(monitor(), nil)
+	mockChannels.EXPECT().GetChannels(ctx, orgID, []int64{1}).Return(channels(1), nil)
+	mockSender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.NotificationChannel, alert models.Alert) error {
+			assert.Equal(t, models.AlertIncidentOpened, alert.Kind)
+			return nil
+		})
+
+	svc.Notify(ctx, models.Alert{
+		Kind:     models.AlertIncidentOpened,
+		Monitor:  *monitor(),
+		Incident: models.Incident{ID: 3, MonitorID: monitorID},
+	})
+}
+
+func TestNotify_WithoutPolicy(t *testing.T) {
+	for _, kind := range []string{models.AlertIncidentOpened, models.AlertIncidentResolved} {
+		t.Run(kind, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, _, _, _, mockFallback, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			alert := models.Alert{
+				Kind:     kind,
+				Monitor:  models.Monitor{ID: monitorID, OrganizationID: orgID},
+				Incident: models.Incident{ID: 3, MonitorID: monitorID},
+			}
+			mockFallback.EXPECT().Notify(ctx, alert)
+			mockRepo.EXPECT().StartEscalation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			svc.Notify(ctx, alert)
+		})
+	}
+}
+
+func TestNotify_ResolvedReachesPagedLevels(t *testing.T) {
+	ctx, ctrl, mockRepo, _, mockChannels, _, mockSender, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	policy := policyID
+	mockRepo.EXPECT().GetPolicy(ctx, policyID).Return(&models.EscalationPolicy{
+		ID: policyID,
+		Levels: []models.EscalationLevel{
+			{ChannelIDs: []int64{1}},
+			{DelayMinutes: 10, ChannelIDs: []int64{2, 1}},
+			{DelayMinutes: 30, ChannelIDs: []int64{3}},
+		},
+	}, nil)
+	mockChannels.EXPECT().GetChannels(ctx, orgID, []int64{1, 2}).Return(channels(1, 2), nil)
+	mockSender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	svc.Notify(ctx, models.Alert{
+		Kind:    models.AlertIncidentResolved,
+		Monitor: *monitor(),
+		Incident: models.Incident{
+			ID:                 3,
+			MonitorID:          monitorID,
+			EscalationPolicyID: &policy,
+			EscalationLevel:    2,
+		},
+	})
+}
+
+func TestRun_SendsClaimedLevels(t *testing.T) {
+	ctx, ctrl, mockRepo, _, mockChannels, mockMonitors, mockSender, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	incident := models.Incident{ID: 3, MonitorID: monitorID}
+	mockRepo.EXPECT().ClaimDueEscalations(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.EscalationStep{
+		{Incident: incident, Level: 0, ChannelIDs: []int64{1}},
+		{Incident: incident, Level: 1, ChannelIDs: []int64{2}},
+	}, nil)
+	mockMonitors.EXPECT().GetMonitor(gomock.Any(), monitorID).Return(monitor(), nil).Times(2)
+	mockChannels.EXPECT().GetChannels(gomock.Any(), orgID, []int64{1}).Return(channels(1), nil)
+	mockChannels.EXPECT().GetChannels(gomock.Any(), orgID, []int64{2}).Return(channels(2), nil)
+
+	var kinds []string
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.NotificationChannel, alert models.Alert) error {
+			kinds = append(kinds, alert.Kind)
+			return nil
+		}).Times(2)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	svc.Run(ctx)
+
+	assert.Equal(t, []string{models.AlertIncidentOpened, models.AlertIncidentEscalated}, kinds)
+}
+
+func TestAcknowledge(t *testing.T) {
+	ctx, ctrl, mockRepo, mockIncidents, mockChannels, mockMonitors, mockSender, _, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	policy := policyID
+	now := time.Now()
+	userID := int64(11)
+	acked := &models.Incident{
+		ID:                 3,
+		MonitorID:          monitorID,
+		AcknowledgedAt:     &now,
+		AcknowledgedBy:     &userID,
+		EscalationPolicyID: &policy,
+		EscalationLevel:    1,
+	}
+
+	mockIncidents.EXPECT().GetIncident(ctx, int64(3)).Return(&models.Incident{ID: 3, MonitorID: monitorID}, nil)
+	mockMonitors.EXPECT().GetMonitor(ctx, monitorID).Return(monitor(), nil)
+	mockIncidents.EXPECT().AcknowledgeIncident(ctx, int64(3), userID, gomock.Any()).Return(acked, nil)
+	mockAudit.EXPECT().Record(ctx, gomock.Any())
+	mockRepo.EXPECT().GetPolicy(ctx, policyID).Return(&models.EscalationPolicy{
+		ID:     policyID,
+		Levels: []models.EscalationLevel{{ChannelIDs: []int64{1}}, {DelayMinutes: 10, ChannelIDs: []int64{2}}},
+	}, nil)
+	mockChannels.EXPECT().GetChannels(ctx, orgID, []int64{1}).Return(channels(1), nil)
+	mockSender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.NotificationChannel, alert models.Alert) error {
+			assert.Equal(t, models.AlertIncidentAcknowledged, alert.Kind)
+			return nil
+		})
+
+	incident, err := svc.Acknowledge(ctx, orgID, 3, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, acked, incident)
+}
+
+func TestAcknowledge_OtherOrganization(t *testing.T) {
+	ctx, ctrl, _, mockIncidents, _, mockMonitors, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockIncidents.EXPECT().GetIncident(ctx, int64(3)).Return(&models.Incident{ID: 3, MonitorID: monitorID}, nil)
+	mockMonitors.EXPECT().GetMonitor(ctx, monitorID).Return(monitor(), nil)
+
+	_, err := svc.Acknowledge(ctx, orgID+1, 3, 11)
+
+	assert.ErrorIs(t, err, errs.ErrIncidentNotFound)
+}
+
+func TestNotify_FlappingReachesFirstLevelOnly(t *testing.T) {
+	ctx, ctrl, mockRepo, _, mockChannels, _, mockSender, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetPolicy(ctx, policyID).Return(&models.EscalationPolicy{
+		ID:     policyID,
+		Levels: []models.EscalationLevel{{ChannelIDs: []int64{1}}, {DelayMinutes: 10, ChannelIDs: []int64{2}}},
+	}, nil)
+	mockChannels.EXPECT().GetChannels(ctx, orgID, []int64{1}).Return(channels(1), nil)
+	mockSender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().StartEscalation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	svc.Notify(ctx, models.Alert{
+		Kind:     models.AlertIncidentFlapping,
+		Monitor:  *monitor(),
+		Incident: models.Incident{ID: 3, MonitorID: monitorID, Flapping: true},
+	})
+}
@@ -0,0 +1,25 @@
+package escalation
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type EscalationService interface {
+	CreatePolicy(ctx context.Context, policy models.EscalationPolicy) (int64, error)
+	GetPolicy(ctx context.Context, orgID, id int64) (*models.EscalationPolicy, error)
+	GetOrganizationPolicies(ctx context.Context, orgID int64) ([]models.EscalationPolicy, error)
+	UpdatePolicy(ctx context.Context, policy models.EscalationPolicy) error
+	DeletePolicy(ctx context.Context, orgID, id int64) error
+	// Acknowledge stops the escalation of an open incident and tells the
+	// channels that were already notified.
+	Acknowledge(ctx context.Context, orgID, incidentID, userID int64) (*models.Incident, error)
+	// Notify starts escalation of opened incidents and reports resolved ones
+	// to the channels that were paged. Flapping incidents only reach the
+	// first level and never escalate. Monitors without a policy go to the
+	// fallback notifier. It implements notify.Notifier.
+	Notify(ctx context.Context, alert models.Alert)
+	// Run notifies due escalation levels until ctx is done.
+	Run(ctx context.Context)
+}
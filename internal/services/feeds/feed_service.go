@@ -0,0 +1,306 @@
+package feeds
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/pkg/feed"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+const (
+	// MaxEntries bounds the length of every feed.
+	MaxEntries = 50
+	// announcementHistory is how far back page feeds list announcements.
+	announcementHistory = 90 * 24 * time.Hour
+
+	timeFormat = "2006-01-02 15:04 UTC"
+)
+
+type feedService struct {
+	tokens        repository.FeedTokenRepository
+	organizations repository.OrganizationRepository
+	monitors      repository.MonitorsRepository
+	incidents     repository.IncidentRepository
+	pages         repository.StatusPageRepository
+	announcements repository.AnnouncementRepository
+	publicURL     string
+	logger        logger.Logger
+}
+
+func NewFeedService(tokens repository.FeedTokenRepository, organizations repository.OrganizationRepository,
+	monitors repository.MonitorsRepository, incidents repository.IncidentRepository,
+	pages repository.StatusPageRepository, announcements repository.AnnouncementRepository,
+	publicURL string, log logger.Logger) FeedService {
+
+	return &feedService{
+		tokens:        tokens,
+		organizations: organizations,
+		monitors:      monitors,
+		incidents:     incidents,
+		pages:         pages,
+		announcements: announcements,
+		publicURL:     strings.TrimRight(publicURL, "/"),
+		logger:        log.WithField("component", "feedService"),
+	}
+}
+
+func (s *feedService) CreateToken(ctx context.Context, userID int64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	if err := s.tokens.SaveFeedToken(ctx, userID, hashToken(token)); err != nil {
+		s.logger.WithContext(ctx).WithField("user_id", userID).
+			WithError(err).
+			Error("Failed to store feed token")
+		return "", err
+	}
+
+	s.logger.WithContext(ctx).WithField("user_id", userID).Info("Feed token issued")
+	return token, nil
+}
+
+func (s *feedService) RevokeToken(ctx context.Context, userID int64) error {
+	if err := s.tokens.DeleteFeedToken(ctx, userID); err != nil {
+		if !errors.Is(err, errs.ErrFeedNotFound) {
+			s.logger.WithContext(ctx).WithField("user_id", userID).
+				WithError(err).
+				Error("Failed to revoke feed token")
+		}
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithField("user_id", userID).Info("Feed token revoked")
+	return nil
+}
+
+func (s *feedService) UserFeed(ctx context.Context, token string) (*feed.Feed, error) {
+	userID, err := s.tokens.GetFeedTokenUser(ctx, hashToken(token))
+	if err != nil {
+		if !errors.Is(err, errs.ErrFeedNotFound) {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to look up feed token")
+		}
+		return nil, err
+	}
+
+	// membership is checked on every request, so leaving an organization
+	// also removes its incidents from the feed
+	orgs, err := s.organizations.GetUserOrganizations(ctx, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("user_id", userID).
+			WithError(err).
+			Error("Failed to fetch organizations")
+		return nil, err
+	}
+
+	names := make(map[int64]string)
+	var ids []int64
+	for _, org := range orgs {
+		monitors, err := s.monitors.GetAllOrganizationMonitors(ctx, org.ID)
+		if err != nil {
+			s.logger.WithContext(ctx).WithField("organization_id", org.ID).
+				WithError(err).
+				Error("Failed to fetch monitors")
+			return nil, err
+		}
+
+		for _, monitor := range monitors {
+			names[monitor.ID] = monitor.Name
+			if len(orgs) > 1 {
+				names[monitor.ID] = org.Name + " / " + monitor.Name
+			}
+			ids = append(ids, monitor.ID)
+		}
+	}
+
+	f := &feed.Feed{
+		ID:      fmt.Sprintf("urn:uptime-monitoring:user:%d:incidents", userID),
+		Title:   "Incidents",
+		Link:    s.publicURL,
+		Entries: []feed.Entry{},
+	}
+	if len(ids) == 0 {
+		return f, nil
+	}
+
+	incidents, err := s.incidents.GetRecentIncidents(ctx, ids, MaxEntries)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("user_id", userID).
+			WithError(err).
+			Error("Failed to fetch incidents")
+		return nil, err
+	}
+
+	for _, incident := range incidents {
+		f.Entries = append(f.Entries, IncidentEntry(incident, names[incident.MonitorID], true))
+	}
+
+	return f, nil
+}
+
+func (s *feedService) PageFeed(ctx context.Context, slug string) (*feed.Feed, error) {
+	page, err := s.pages.GetPageBySlug(ctx, slug)
+	if err != nil {
+		if !errors.Is(err, errs.ErrStatusPageNotFound) {
+			s.logger.WithContext(ctx).WithField("slug", slug).
+				WithError(err).
+				Error("Failed to fetch status page")
+		}
+		return nil, err
+	}
+
+	link := s.publicURL + "/status/" + page.Slug
+	f := &feed.Feed{
+		ID:      fmt.Sprintf("urn:uptime-monitoring:status-page:%d", page.ID),
+		Title:   page.Title,
+		Link:    link,
+		Entries: []feed.Entry{},
+	}
+
+	// only the display names chosen for the page are shown, as on the page
+	names := make(map[int64]string)
+	var ids []int64
+	for _, component := range page.Components {
+		for _, monitor := range component.Monitors {
+			if _, ok := names[monitor.MonitorID]; !ok {
+				names[monitor.MonitorID] = component.Name + " / " + monitor.DisplayName
+				ids = append(ids, monitor.MonitorID)
+			}
+		}
+	}
+
+	if len(ids) > 0 {
+		incidents, err := s.incidents.GetRecentIncidents(ctx, ids, MaxEntries)
+		if err != nil {
+			s.logger.WithContext(ctx).WithField("status_page_id", page.ID).
+				WithError(err).
+				Error("Failed to fetch incidents")
+			return nil, err
+		}
+
+		for _, incident := range incidents {
+			entry := IncidentEntry(incident, names[incident.MonitorID], false)
+			entry.Link = link
+			f.Entries = append(f.Entries, entry)
+		}
+	}
+
+	announcements, err := s.announcements.GetPageAnnouncements(ctx, page.ID, time.Now().Add(-announcementHistory))
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("status_page_id", page.ID).
+			WithError(err).
+			Error("Failed to fetch announcements")
+		return nil, err
+	}
+
+	for _, announcement := range announcements {
+		entry := AnnouncementEntry(announcement)
+		entry.Link = link
+		f.Entries = append(f.Entries, entry)
+	}
+
+	slices.SortStableFunc(f.Entries, func(a, b feed.Entry) int {
+		return b.Updated.Compare(a.Updated)
+	})
+	if len(f.Entries) > MaxEntries {
+		f.Entries = f.Entries[:MaxEntries]
+	}
+
+	return f, nil
+}
+
+// IncidentEntry describes an incident and what happened to it so far. The
+// entry ID stays the same while the incident is updated. The cause can hold
+// internal addresses and is left out of public feeds.
+func IncidentEntry(incident models.Incident, name string, withCause bool) feed.Entry {
+	entry := feed.Entry{
+		ID:        fmt.Sprintf("urn:uptime-monitoring:incident:%d", incident.ID),
+		Title:     name + " is down",
+		Published: incident.StartedAt,
+		Updated:   incident.StartedAt,
+	}
+
+	started := "Down"
+	if incident.Flapping {
+		entry.Title = name + " is flapping"
+		started = "Flapping between up and down"
+	}
+	if withCause && incident.Cause != "" {
+		started += ": " + incident.Cause
+	}
+
+	lines := []string{incident.StartedAt.UTC().Format(timeFormat) + "  " + started}
+	if incident.AcknowledgedAt != nil {
+		lines = append(lines, incident.AcknowledgedAt.UTC().Format(timeFormat)+"  Acknowledged")
+		entry.Updated = *incident.AcknowledgedAt
+	}
+	if incident.ResolvedAt != nil {
+		entry.Title = "Resolved: " + entry.Title
+		lines = append(lines, incident.ResolvedAt.UTC().Format(timeFormat)+"  Resolved after "+
+			incident.ResolvedAt.Sub(incident.StartedAt).Round(time.Second).String())
+		if incident.ResolvedAt.After(entry.Updated) {
+			entry.Updated = *incident.ResolvedAt
+		}
+	}
+
+	entry.Content = strings.Join(lines, "\n")
+	return entry
+}
+
+// AnnouncementEntry lists the updates of an announcement, newest first as
+// on the status page.
+func AnnouncementEntry(announcement models.Announcement) feed.Entry {
+	entry := feed.Entry{
+		ID:        fmt.Sprintf("urn:uptime-monitoring:announcement:%d", announcement.ID),
+		Title:     announcement.Title,
+		Published: announcement.CreatedAt,
+		Updated:   announcement.CreatedAt,
+	}
+	if announcement.Kind == models.AnnouncementMaintenance {
+		entry.Title = "Maintenance: " + entry.Title
+	}
+
+	var lines []string
+	if announcement.ScheduledFor != nil && announcement.ScheduledUntil != nil {
+		lines = append(lines, "Scheduled from "+announcement.ScheduledFor.UTC().Format(timeFormat)+
+			" until "+announcement.ScheduledUntil.UTC().Format(timeFormat))
+	}
+	for _, update := range announcement.Updates {
+		lines = append(lines, update.CreatedAt.UTC().Format(timeFormat)+"  "+
+			statusLabel(update.Status)+": "+update.Message)
+		if update.CreatedAt.After(entry.Updated) {
+			entry.Updated = update.CreatedAt
+		}
+	}
+
+	entry.Content = strings.Join(lines, "\n")
+	return entry
+}
+
+// statusLabel turns in_progress into In progress.
+func statusLabel(status models.AnnouncementStatus) string {
+	label := strings.ReplaceAll(string(status), "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,146 @@
+package feeds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/feeds"
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockFeedTokenRepository, *mocks.MockOrganizationRepository, *mocks.MockMonitorsRepository, *mocks.MockIncidentRepository, *mocks.MockStatusPageRepository, *mocks.MockAnnouncementRepository, feeds.FeedService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockTokens := mocks.NewMockFeedTokenRepository(ctrl)
+	mockOrganizations := mocks.NewMockOrganizationRepository(ctrl)
+	mockMonitors := mocks.NewMockMonitorsRepository(ctrl)
+	mockIncidents := mocks.NewMockIncidentRepository(ctrl)
+	mockPages := mocks.NewMockStatusPageRepository(ctrl)
+	mockAnnouncements := mocks.NewMockAnnouncementRepository(ctrl)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := feeds.NewFeedService(mockTokens, mockOrganizations, mockMonitors, mockIncidents, mockPages,
+		mockAnnouncements, "https://status.example.com/", mockLogger)
+	return context.Background(), ctrl, mockTokens, mockOrganizations, mockMonitors, mockIncidents, mockPages, mockAnnouncements, svc
+}
+
+var started = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+func TestIncidentEntry(t *testing.T) {
+	acknowledged := started.Add(5 * time.Minute)
+	resolved := started.Add(12 * time.Minute)
+	incident := models.Incident{
+		ID:             42,
+		StartedAt:      started,
+		Cause:          "dial tcp 10.0.0.7:443: connection refused",
+		AcknowledgedAt: &acknowledged,
+		ResolvedAt:     &resolved,
+	}
+
+	entry := feeds.IncidentEntry(incident, "API", true)
+
+	assert.Equal(t, "urn:uptime-monitoring:incident:42", entry.ID)
+	assert.Equal(t, "Resolved: API is down", entry.Title)
+	assert.Equal(t, started, entry.Published)
+	assert.Equal(t, resolved, entry.Updated)
+	assert.Equal(t, "2026-10-19 10:00 UTC  Down: dial tcp 10.0.0.7:443: connection refused\n"+
+		"2026-10-19 10:05 UTC  Acknowledged\n"+
+		"2026-10-19 10:12 UTC  Resolved after 12m0s", entry.Content)
+
+	public := feeds.IncidentEntry(incident, "API", false)
+	assert.Equal(t, entry.ID, public.ID)
+	assert.NotContains(t, public.Content, "10.0.0.7")
+}
+
+func TestAnnouncementEntry(t *testing.T) {
+	entry := feeds.AnnouncementEntry(models.Announcement{
+		ID:        3,
+		Kind:      models.AnnouncementIncident,
+		Title:     "Slow API",
+		CreatedAt: started,
+		Updates: []models.AnnouncementUpdate{
+			{Status: models.AnnouncementResolved, Message: "Fixed", CreatedAt: started.Add(time.Hour)},
+			{Status: models.AnnouncementInvestigating, Message: "Looking into it", CreatedAt: started},
+		},
+	})
+
+	assert.Equal(t, "urn:uptime-monitoring:announcement:3", entry.ID)
+	assert.Equal(t, started.Add(time.Hour), entry.Updated)
+	assert.Equal(t, "2026-10-19 11:00 UTC  Resolved: Fixed\n2026-10-19 10:00 UTC  Investigating: Looking into it",
+		entry.Content)
+}
+
+func TestUserFeed_UnknownToken(t *testing.T) {
+	ctx, ctrl, mockTokens, _, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockTokens.EXPECT().GetFeedTokenUser(ctx, gomock.Any()).Return(int64(0), errs.ErrFeedNotFound)
+
+	_, err := svc.UserFeed(ctx, "nope")
+
+	assert.ErrorIs(t, err, errs.ErrFeedNotFound)
+}
+
+func TestUserFeed_AllOrganizations(t *testing.T) {
+	ctx, ctrl, mockTokens, mockOrganizations, mockMonitors, mockIncidents, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockTokens.EXPECT().GetFeedTokenUser(ctx, gomock.Any()).Return(int64(9), nil)
+	mockOrganizations.EXPECT().GetUserOrganizations(ctx, int64(9)).Return([]models.Organization{
+		{ID: 1, Name: "Acme"}, {ID: 2, Name: "Beta"},
+	}, nil)
+	mockMonitors.EXPECT().GetAllOrganizationMonitors(ctx, int64(1)).Return([]models.Monitor{{ID: 10, Name: "API"}}, nil)
+	mockMonitors.EXPECT().GetAllOrganizationMonitors(ctx, int64(2)).Return([]models.Monitor{{ID: 20, Name: "Web"}}, nil)
+	mockIncidents.EXPECT().GetRecentIncidents(ctx, []int64{10, 20}, feeds.MaxEntries).Return([]models.Incident{
+		{ID: 5, MonitorID: 20, StartedAt: started},
+	}, nil)
+
+	f, err := svc.UserFeed(ctx, "token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "urn:uptime-monitoring:user:9:incidents", f.ID)
+	require.Len(t, f.Entries, 1)
+	assert.Equal(t, "Beta / Web is down", f.Entries[0].Title)
+}
+
+func TestPageFeed_MergesIncidentsAndAnnouncements(t *testing.T) {
+	ctx, ctrl, _, _, _, mockIncidents, mockPages, mockAnnouncements, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockPages.EXPECT().GetPageBySlug(ctx, "acme").Return(&models.StatusPage{
+		ID: 1, Slug: "acme", Title: "Acme status",
+		Components: []models.StatusComponent{{Name: "API", Monitors: []models.StatusPageMonitor{
+			{MonitorID: 7, DisplayName: "Public API"},
+		}}},
+	}, nil)
+	mockIncidents.EXPECT().GetRecentIncidents(ctx, []int64{7}, feeds.MaxEntries).Return([]models.Incident{
+		{ID: 5, MonitorID: 7, StartedAt: started, Cause: "secret host"},
+	}, nil)
+	mockAnnouncements.EXPECT().GetPageAnnouncements(ctx, int64(1), gomock.Any()).Return([]models.Announcement{
+		{ID: 3, Title: "Slow API", CreatedAt: started.Add(time.Hour)},
+	}, nil)
+
+	f, err := svc.PageFeed(ctx, "acme")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://status.example.com/status/acme", f.Link)
+	require.Len(t, f.Entries, 2)
+	assert.Equal(t, "Slow API", f.Entries[0].Title)
+	assert.Equal(t, "API / Public API is down", f.Entries[1].Title)
+	assert.NotContains(t, f.Entries[1].Content, "secret host")
+	assert.Equal(t, f.Link, f.Entries[1].Link)
+}
@@ -0,0 +1,21 @@
+package feeds
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/pkg/feed"
+)
+
+type FeedService interface {
+	// CreateToken issues a new personal feed token, which replaces the
+	// earlier one. Only a hash is kept, so the token can't be shown again.
+	CreateToken(ctx context.Context, userID int64) (string, error)
+	RevokeToken(ctx context.Context, userID int64) error
+
+	// UserFeed lists the incidents of all organizations the owner of the
+	// token belongs to.
+	UserFeed(ctx context.Context, token string) (*feed.Feed, error)
+	// PageFeed lists the incidents and announcements of a status page as
+	// anonymous visitors see them.
+	PageFeed(ctx context.Context, slug string) (*feed.Feed, error)
+}
@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/checks"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/message"
+)
+
+const (
+	ComponentPostgres   = "postgres"
+	ComponentMigrations = "migrations"
+	ComponentScheduler  = "scheduler"
+	ComponentQueues     = "queues"
+
+	// checkTimeout bounds each database round trip so a hung pool fails the
+	// probe instead of outliving it.
+	checkTimeout = 2 * time.Second
+	// missedTicks is how many poll intervals the scheduler may skip before
+	// it counts as stuck.
+	missedTicks = 3
+)
+
+type healthService struct {
+	repo          repository.HealthRepository
+	checks        checks.CheckService
+	mq            message.MQ
+	schemaVersion int64
+	pollInterval  time.Duration
+	startedAt     time.Time
+	logger        logger.Logger
+}
+
+func NewHealthService(repo repository.HealthRepository, checks checks.CheckService, mq message.MQ,
+	schemaVersion int64, pollInterval time.Duration, log logger.Logger) HealthService {
+
+	return &healthService{
+		repo:          repo,
+		checks:        checks,
+		mq:            mq,
+		schemaVersion: schemaVersion,
+		pollInterval:  pollInterval,
+		startedAt:     time.Now(),
+		logger:        log.WithField("component", "healthService"),
+	}
+}
+
+func (s *healthService) Readiness(ctx context.Context) *models.Readiness {
+	r := &models.Readiness{
+		Status: models.HealthOK,
+		Components: map[string]models.ComponentHealth{
+			ComponentPostgres:   s.checkPostgres(ctx),
+			ComponentMigrations: s.checkMigrations(ctx),
+			ComponentScheduler:  s.checkScheduler(time.Now()),
+			ComponentQueues:     s.checkQueues(),
+		},
+	}
+
+	for name, component := range r.Components {
+		if component.Status == models.HealthOK {
+			continue
+		}
+		s.logger.WithContext(ctx).WithField("health_component", name).Warnf("Readiness check failed: %s", component.Error)
+		if component.Critical {
+			r.Status = models.HealthFail
+		}
+	}
+
+	return r
+}
+
+func (s *healthService) checkPostgres(ctx context.Context) models.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := s.repo.Ping(ctx); err != nil {
+		return failed(err)
+	}
+	return healthy(nil)
+}
+
+func (s *healthService) checkMigrations(ctx context.Context) models.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	version, dirty, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return failed(err)
+	}
+
+	details := map[string]any{"version": version, "expected": s.schemaVersion}
+	switch {
+	case dirty:
+		return failedWith(details, fmt.Errorf("migration %d did not finish", version))
+	case version < s.schemaVersion:
+		return failedWith(details, fmt.Errorf("schema is at version %d, expected %d", version, s.schemaVersion))
+	}
+	return healthy(details)
+}
+
+func (s *healthService) checkScheduler(now time.Time) models.ComponentHealth {
+	staleAfter := missedTicks * s.pollInterval
+	heartbeat := s.checks.Heartbeat()
+
+	if heartbeat.IsZero() {
+		// The first dispatch runs as soon as Run starts, give it the same
+		// slack as a later one.
+		if now.Sub(s.startedAt) < staleAfter {
+			return healthy(map[string]any{"starting": true})
+		}
+		return failed(fmt.Errorf("scheduler has not run since startup"))
+	}
+
+	details := map[string]any{"last_dispatch": heartbeat.UTC()}
+	if age := now.Sub(heartbeat); age > staleAfter {
+		return failedWith(details, fmt.Errorf("no dispatch for %s", age.Truncate(time.Second)))
+	}
+	return healthy(details)
+}
+
+func (s *healthService) checkQueues() models.ComponentHealth {
+	if err := s.mq.Ping(); err != nil {
+		return failed(err)
+	}
+
+	depths := make(map[string]any)
+	for queue, depth := range s.mq.Depths() {
+		depths[queue] = depth
+	}
+	return healthy(depths)
+}
+
+func healthy(details map[string]any) models.ComponentHealth {
+	return models.ComponentHealth{Status: models.HealthOK, Critical: true, Details: details}
+}
+
+func failed(err error) models.ComponentHealth {
+	return failedWith(nil, err)
+}
+
+func failedWith(details map[string]any, err error) models.ComponentHealth {
+	return models.ComponentHealth{
+		Status:   models.HealthFail,
+		Critical: true,
+		Error:    err.Error(),
+		Details:  details,
+	}
+}
@@ -0,0 +1,147 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services/health"
+)
+
+const (
+	schemaVersion = 19
+	pollInterval  = time.Minute
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockHealthRepository, *mocks.MockCheckService, *mocks.MockMQ, health.HealthService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockHealthRepository(ctrl)
+	mockChecks := mocks.NewMockCheckService(ctrl)
+	mockMq := mocks.NewMockMQ(ctrl)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	svc := health.NewHealthService(mockRepo, mockChecks, mockMq, schemaVersion, pollInterval, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockChecks, mockMq, svc
+}
+
+// expectHealthy makes every component pass, tests override the one they
+// are about.
+func expectHealthy(mockRepo *mocks.MockHealthRepository, mockChecks *mocks.MockCheckService, mockMq *mocks.MockMQ) {
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).AnyTimes()
+	mockRepo.EXPECT().SchemaVersion(gomock.Any()).Return(int64(schemaVersion), false, nil).AnyTimes()
+	mockChecks.EXPECT().Heartbeat().Return(time.Now()).AnyTimes()
+	mockMq.EXPECT().Ping().Return(nil).AnyTimes()
+	mockMq.EXPECT().Depths().Return(map[string]int{"checks": 2}).AnyTimes()
+}
+
+func TestReadiness_AllHealthy(t *testing.T) {
+	ctx, ctrl, mockRepo, mockChecks, mockMq, svc := setup(t)
+	defer ctrl.Finish()
+
+	expectHealthy(mockRepo, mockChecks, mockMq)
+
+	r := svc.Readiness(ctx)
+
+	assert.True(t, r.Ready())
+	assert.Len(t, r.Components, 4)
+	for name, c := range r.Components {
+		assert.Equal(t, models.HealthOK, c.Status, name)
+	}
+	assert.Equal(t, 2, r.Components[health.ComponentQueues].Details["checks"])
+}
+
+func TestReadiness_PostgresDown(t *testing.T) {
+	ctx, ctrl, mockRepo, mockChecks, mockMq, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+	mockRepo.EXPECT().SchemaVersion(gomock.Any()).Return(int64(0), false, errors.New("connection refused"))
+	expectHealthy(mockRepo, mockChecks, mockMq)
+
+	r := svc.Readiness(ctx)
+
+	assert.False(t, r.Ready())
+	assert.Equal(t, models.HealthFail, r.Components[health.ComponentPostgres].Status)
+	assert.Equal(t, "connection refused", r.Components[health.ComponentPostgres].Error)
+	assert.Equal(t, models.HealthOK, r.Components[health.ComponentScheduler].Status)
+}
+
+func TestReadiness_Migrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		dirty   bool
+		ok      bool
+	}{
+		{"current", schemaVersion, false, true},
+		{"newer", schemaVersion + 1, false, true},
+		{"behind", schemaVersion - 1, false, false},
+		{"dirty", schemaVersion, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, mockChecks, mockMq, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().SchemaVersion(gomock.Any()).Return(tt.version, tt.dirty, nil)
+			expectHealthy(mockRepo, mockChecks, mockMq)
+
+			r := svc.Readiness(ctx)
+
+			assert.Equal(t, tt.ok, r.Ready())
+			assert.Equal(t, tt.version, r.Components[health.ComponentMigrations].Details["version"])
+		})
+	}
+}
+
+func TestReadiness_Scheduler(t *testing.T) {
+	tests := []struct {
+		name      string
+		heartbeat time.Time
+		ok        bool
+	}{
+		{"recent", time.Now().Add(-pollInterval), true},
+		{"stale", time.Now().Add(-4 * pollInterval), false},
+		{"starting", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, mockChecks, mockMq, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockChecks.EXPECT().Heartbeat().Return(tt.heartbeat)
+			expectHealthy(mockRepo, mockChecks, mockMq)
+
+			r := svc.Readiness(ctx)
+
+			assert.Equal(t, tt.ok, r.Ready())
+		})
+	}
+}
+
+func TestReadiness_QueuesClosed(t *testing.T) {
+	ctx, ctrl, mockRepo, mockChecks, mockMq, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockMq.EXPECT().Ping().Return(errors.New("message queues closed"))
+	expectHealthy(mockRepo, mockChecks, mockMq)
+
+	r := svc.Readiness(ctx)
+
+	assert.False(t, r.Ready())
+	assert.Equal(t, models.HealthFail, r.Components[health.ComponentQueues].Status)
+}
@@ -0,0 +1,13 @@
+package health
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type HealthService interface {
+	// Readiness checks the database, the schema version, the check
+	// scheduler and the message queues.
+	Readiness(ctx context.Context) *models.Readiness
+}
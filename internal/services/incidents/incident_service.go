@@ -0,0 +1,291 @@
+package incidents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/maintenance"
+	"github.com/mixdone/uptime-monitoring/internal/services/notify"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type incidentService struct {
+	checks      repository.CheckRepository
+	incidents   repository.IncidentRepository
+	maintenance maintenance.MaintenanceService
+	notifier    notify.Notifier
+	logger      logger.Logger
+}
+
+func NewIncidentService(checks repository.CheckRepository, incidents repository.IncidentRepository,
+	maintenance maintenance.MaintenanceService, notifier notify.Notifier, log logger.Logger) IncidentService {
+
+	return &incidentService{
+		checks:      checks,
+		incidents:   incidents,
+		maintenance: maintenance,
+		notifier:    notifier,
+		logger:      log.WithField("component", "incidentService"),
+	}
+}
+
+// ProcessResult keeps at most one open incident per monitor. Inside a
+// maintenance window results are still stored, but a new incident is marked
+// as maintenance and nothing is sent. An incident that outlasts its window
+// turns into a regular one and alerts from then on. Failures while a parent
+// monitor is down are stored as unreachable and leave incidents alone.
+// A monitor that changes status too often gets a single flapping incident
+// instead, which stays open until the status holds for a whole window.
+func (s *incidentService) ProcessResult(ctx context.Context, monitor models.Monitor, result models.CheckResult) error {
+	ctx, span := tracing.Start(ctx, "incidentService.ProcessResult", tracing.MonitorID(monitor.ID))
+	defer span.End()
+
+	log := s.logger.WithContext(ctx).WithField("monitor_id", monitor.ID)
+
+	active, err := s.maintenance.MonitorMaintenance(ctx, monitor.ID, result.CheckedAt)
+	if err != nil {
+		return err
+	}
+	result.Maintenance = len(active) > 0
+
+	if result.Status == models.CheckDown && len(monitor.ParentIDs) > 0 {
+		parentID, err := s.downParent(ctx, monitor.ParentIDs)
+		if err != nil {
+			log.WithError(err).Error("Failed to fetch parent monitor status")
+			return err
+		}
+		if parentID != 0 {
+			result.Status = models.CheckUnreachable
+			result.Error = fmt.Sprintf("unreachable due to dependency on monitor %d: %s", parentID, result.Error)
+		}
+	}
+
+	if _, err := s.checks.CreateResult(ctx, result); err != nil {
+		log.WithError(err).Error("Failed to store check result")
+		return err
+	}
+
+	open, err := s.incidents.GetOpenIncident(ctx, monitor.ID)
+	if errors.Is(err, errs.ErrIncidentNotFound) {
+		open = nil
+	} else if err != nil {
+		log.WithError(err).Error("Failed to fetch open incident")
+		return err
+	}
+
+	if open != nil && open.Flapping {
+		return s.settleFlapping(ctx, monitor, result, open)
+	}
+
+	if monitor.FlapThreshold > 0 && !result.Maintenance && result.Status != models.CheckUnreachable {
+		changes, err := s.stateChanges(ctx, monitor, result.CheckedAt)
+		if err != nil {
+			log.WithError(err).Error("Failed to count status changes")
+			return err
+		}
+		if changes >= monitor.FlapThreshold {
+			return s.startFlapping(ctx, monitor, result, open, changes)
+		}
+	}
+
+	switch {
+	case result.Status == models.CheckDown && open == nil:
+		return s.openIncident(ctx, monitor, result)
+
+	case result.Status == models.CheckDown && open.Maintenance && !result.Maintenance:
+		if err := s.incidents.SetIncidentMaintenance(ctx, open.ID, false); err != nil {
+			log.WithError(err).Error("Failed to update incident")
+			return err
+		}
+		open.Maintenance = false
+
+		log.WithField("incident_id", open.ID).Info("Maintenance is over but monitor is still down")
+		s.notifier.Notify(ctx, models.Alert{Kind: models.AlertIncidentOpened, Monitor: monitor, Incident: *open})
+
+	case result.Status == models.CheckUp && open != nil:
+		if err := s.incidents.ResolveIncident(ctx, open.ID, result.CheckedAt); err != nil {
+			log.WithError(err).Error("Failed to resolve incident")
+			return err
+		}
+		open.ResolvedAt = &result.CheckedAt
+
+		log.WithField("incident_id", open.ID).Info("Incident resolved")
+
+		// whoever was paged for the incident hears that it's over, even when
+		// a maintenance window has started since
+		if !open.Maintenance {
+			s.notifier.Notify(ctx, models.Alert{Kind: models.AlertIncidentResolved, Monitor: monitor, Incident: *open})
+		}
+	}
+
+	return nil
+}
+
+func (s *incidentService) openIncident(ctx context.Context, monitor models.Monitor, result models.CheckResult) error {
+	incident := models.Incident{
+		MonitorID:   monitor.ID,
+		StartedAt:   result.CheckedAt,
+		Cause:       result.Error,
+		Maintenance: result.Maintenance,
+	}
+
+	var err error
+	incident.ID, err = s.incidents.CreateIncident(ctx, incident)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", monitor.ID).
+			WithError(err).
+			Error("Failed to open incident")
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"monitor_id":  monitor.ID,
+		"incident_id": incident.ID,
+		"maintenance": incident.Maintenance,
+	}).Info("Incident opened")
+
+	if !incident.Maintenance {
+		s.notifier.Notify(ctx, models.Alert{Kind: models.AlertIncidentOpened, Monitor: monitor, Incident: incident})
+	}
+
+	return nil
+}
+
+// startFlapping replaces the open incident, if any, with a flapping one. The
+// replaced incident is resolved towards everyone it paged, then the flapping
+// alert is the only one until the monitor settles down.
+func (s *incidentService) startFlapping(ctx context.Context, monitor models.Monitor,
+	result models.CheckResult, open *models.Incident, changes int) error {
+
+	log := s.logger.WithContext(ctx).WithField("monitor_id", monitor.ID)
+
+	if open != nil {
+		if err := s.incidents.ResolveIncident(ctx, open.ID, result.CheckedAt); err != nil {
+			log.WithError(err).Error("Failed to resolve incident")
+			return err
+		}
+		open.ResolvedAt = &result.CheckedAt
+
+		if !open.Maintenance {
+			s.notifier.Notify(ctx, models.Alert{Kind: models.AlertIncidentResolved, Monitor: monitor, Incident: *open})
+		}
+	}
+
+	incident := models.Incident{
+		MonitorID: monitor.ID,
+		StartedAt: result.CheckedAt,
+		Cause:     fmt.Sprintf("status changed %d times in %d minutes", changes, monitor.FlapWindow()),
+		Flapping:  true,
+	}
+
+	var err error
+	incident.ID, err = s.incidents.CreateIncident(ctx, incident)
+	if err != nil {
+		log.WithError(err).Error("Failed to open flapping incident")
+		return err
+	}
+
+	log.WithFields(map[string]any{
+		"incident_id": incident.ID,
+		"changes":     changes,
+	}).Info("Monitor is flapping")
+
+	s.notifier.Notify(ctx, models.Alert{Kind: models.AlertIncidentFlapping, Monitor: monitor, Incident: incident})
+	return nil
+}
+
+// settleFlapping holds alerts while the status keeps changing. Once it has
+// held for a whole window the flapping incident is resolved, and a monitor
+// that settled down gets a regular incident.
+func (s *incidentService) settleFlapping(ctx context.Context, monitor models.Monitor,
+	result models.CheckResult, open *models.Incident) error {
+
+	log := s.logger.WithContext(ctx).WithFields(map[string]any{
+		"monitor_id":  monitor.ID,
+		"incident_id": open.ID,
+	})
+
+	changes, err := s.stateChanges(ctx, monitor, result.CheckedAt)
+	if err != nil {
+		log.WithError(err).Error("Failed to count status changes")
+		return err
+	}
+	if changes > 0 {
+		return nil
+	}
+
+	if err := s.incidents.ResolveIncident(ctx, open.ID, result.CheckedAt); err != nil {
+		log.WithError(err).Error("Failed to resolve flapping incident")
+		return err
+	}
+	open.ResolvedAt = &result.CheckedAt
+
+	log.WithField("status", result.Status).Info("Monitor stopped flapping")
+
+	if !open.Maintenance {
+		s.notifier.Notify(ctx, models.Alert{Kind: models.AlertIncidentResolved, Monitor: monitor, Incident: *open})
+	}
+
+	if result.Status == models.CheckDown {
+		return s.openIncident(ctx, monitor, result)
+	}
+
+	return nil
+}
+
+// stateChanges counts the status changes within the flapping window that
+// ends at the given time.
+func (s *incidentService) stateChanges(ctx context.Context, monitor models.Monitor, at time.Time) (int, error) {
+	window := time.Duration(monitor.FlapWindow()) * time.Minute
+	return s.checks.CountStateChanges(ctx, monitor.ID, at.Add(-window))
+}
+
+// downParent returns the first parent whose latest check failed, or zero.
+// A parent that is unreachable itself counts as down too.
+func (s *incidentService) downParent(ctx context.Context, parentIDs []int64) (int64, error) {
+	statuses, err := s.checks.GetLatestStatuses(ctx, parentIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, parentID := range parentIDs {
+		if status, ok := statuses[parentID]; ok && status != models.CheckUp {
+			return parentID, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *incidentService) GetMonitorIncidents(ctx context.Context, monitorID int64, limit int) ([]models.Incident, error) {
+	ctx, span := tracing.Start(ctx, "incidentService.GetMonitorIncidents", tracing.MonitorID(monitorID))
+	defer span.End()
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	incidents, err := s.incidents.GetMonitorIncidents(ctx, monitorID, limit)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", monitorID).
+			WithError(err).
+			Error("Failed to fetch incidents")
+		return nil, err
+	}
+
+	return incidents, nil
+}
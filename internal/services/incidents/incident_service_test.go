@@ -0,0 +1,335 @@
+package incidents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/incidents"
+)
+
+const monitorID = int64(7)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockCheckRepository, *mocks.MockIncidentRepository, *mocks.MockMaintenanceService, *mocks.MockNotifier, incidents.IncidentService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockChecks := mocks.NewMockCheckRepository(ctrl)
+	mockIncidents := mocks.NewMockIncidentRepository(ctrl)
+	mockMaintenance := mocks.NewMockMaintenanceService(ctrl)
+	mockNotifier := mocks.NewMockNotifier(ctrl)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := incidents.NewIncidentService(mockChecks, mockIncidents, mockMaintenance, mockNotifier, mockLogger)
+	return context.Background(), ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc
+}
+
+func result(status string) models.CheckResult {
+	return models.CheckResult{MonitorID: monitorID, CheckedAt: time.Now(), Status: status, Error: "timeout"}
+}
+
+// inMaintenance sets whether the monitor is in maintenance and expects the
+// result to be stored with the matching flag.
+func inMaintenance(t *testing.T, mockChecks *mocks.MockCheckRepository, mockMaintenance *mocks.MockMaintenanceService, active bool) {
+	expectStored(t, mockChecks, mockMaintenance, active, "")
+}
+
+// expectStored also checks the stored status unless status is empty.
+func expectStored(t *testing.T, mockChecks *mocks.MockCheckRepository, mockMaintenance *mocks.MockMaintenanceService, active bool, status string) {
+	var occurrences []models.MaintenanceOccurrence
+	if active {
+		occurrences = []models.MaintenanceOccurrence{{WindowID: 1}}
+	}
+	mockMaintenance.EXPECT().MonitorMaintenance(gomock.Any(), monitorID, gomock.Any()).Return(occurrences, nil)
+	mockChecks.EXPECT().CreateResult(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, res models.CheckResult) (int64, error) {
+			assert.Equal(t, active, res.Maintenance)
+			if status != "" {
+				assert.Equal(t, status, res.Status)
+			}
+			return 1, nil
+		})
+}
+
+func TestProcessResult_OpensIncident(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, false)
+
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(nil, errs.ErrIncidentNotFound)
+	mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, incident models.Incident) (int64, error) {
+			assert.False(t, incident.Maintenance)
+			assert.Equal(t, "timeout", incident.Cause)
+			return 3, nil
+		})
+	mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+		Do(func(_ context.Context, alert models.Alert) {
+			assert.Equal(t, models.AlertIncidentOpened, alert.Kind)
+			assert.Equal(t, int64(3), alert.Incident.ID)
+		})
+
+	err := svc.ProcessResult(ctx, models.Monitor{ID: monitorID}, result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_MaintenanceSuppressesAlert(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, true)
+
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(nil, errs.ErrIncidentNotFound)
+	mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, incident models.Incident) (int64, error) {
+			assert.True(t, incident.Maintenance)
+			return 3, nil
+		})
+	mockNotifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.ProcessResult(ctx, models.Monitor{ID: monitorID}, result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_MaintenanceIncidentOutlastsWindow(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, false)
+
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).
+		Return(&models.Incident{ID: 3, MonitorID: monitorID, Maintenance: true}, nil)
+	mockIncidents.EXPECT().SetIncidentMaintenance(ctx, int64(3), false).Return(nil)
+	mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+		Do(func(_ context.Context, alert models.Alert) {
+			assert.Equal(t, models.AlertIncidentOpened, alert.Kind)
+			assert.False(t, alert.Incident.Maintenance)
+		})
+
+	err := svc.ProcessResult(ctx, models.Monitor{ID: monitorID}, result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_Resolves(t *testing.T) {
+	tests := []struct {
+		name        string
+		maintenance bool
+		incident    models.Incident
+		notified    int
+	}{
+		{name: "regular incident", incident: models.Incident{ID: 3}, notified: 1},
+		{name: "maintenance incident", incident: models.Incident{ID: 3, Maintenance: true}},
+		{name: "recovered during maintenance", maintenance: true, incident: models.Incident{ID: 3}, notified: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+			defer ctrl.Finish()
+
+			inMaintenance(t, mockChecks, mockMaintenance, tt.maintenance)
+
+			incident := tt.incident
+			mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(&incident, nil)
+			mockIncidents.EXPECT().ResolveIncident(ctx, int64(3), gomock.Any()).Return(nil)
+			mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+				Do(func(_ context.Context, alert models.Alert) {
+					assert.Equal(t, models.AlertIncidentResolved, alert.Kind)
+				}).Times(tt.notified)
+
+			err := svc.ProcessResult(ctx, models.Monitor{ID: monitorID}, result(models.CheckUp))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProcessResult_ParentDown(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	expectStored(t, mockChecks, mockMaintenance, false, models.CheckUnreachable)
+
+	mockChecks.EXPECT().GetLatestStatuses(ctx, []int64{1, 2}).
+		Return(map[int64]string{1: models.CheckUp, 2: models.CheckDown}, nil)
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(nil, errs.ErrIncidentNotFound)
+	mockIncidents.EXPECT().CreateIncident(gomock.Any(), gomock.Any()).Times(0)
+	mockNotifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(0)
+
+	monitor := models.Monitor{ID: monitorID, ParentIDs: []int64{1, 2}}
+	err := svc.ProcessResult(ctx, monitor, result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_ParentsUp(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	expectStored(t, mockChecks, mockMaintenance, false, models.CheckDown)
+
+	// a parent that was never checked doesn't hide failures
+	mockChecks.EXPECT().GetLatestStatuses(ctx, []int64{1, 2}).
+		Return(map[int64]string{1: models.CheckUp}, nil)
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(nil, errs.ErrIncidentNotFound)
+	mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).Return(int64(3), nil)
+	mockNotifier.EXPECT().Notify(ctx, gomock.Any())
+
+	monitor := models.Monitor{ID: monitorID, ParentIDs: []int64{1, 2}}
+	err := svc.ProcessResult(ctx, monitor, result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func flappy() models.Monitor {
+	return models.Monitor{ID: monitorID, FlapThreshold: 4, FlapWindowMinutes: 10}
+}
+
+func TestProcessResult_StartsFlapping(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, false)
+
+	res := result(models.CheckDown)
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(nil, errs.ErrIncidentNotFound)
+	mockChecks.EXPECT().CountStateChanges(ctx, monitorID, res.CheckedAt.Add(-10*time.Minute)).Return(4, nil)
+	mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, incident models.Incident) (int64, error) {
+			assert.True(t, incident.Flapping)
+			return 3, nil
+		})
+	mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+		Do(func(_ context.Context, alert models.Alert) {
+			assert.Equal(t, models.AlertIncidentFlapping, alert.Kind)
+		})
+
+	err := svc.ProcessResult(ctx, flappy(), res)
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_FlappingReplacesOpenIncident(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, false)
+
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(&models.Incident{ID: 2, MonitorID: monitorID}, nil)
+	mockChecks.EXPECT().CountStateChanges(ctx, monitorID, gomock.Any()).Return(5, nil)
+	gomock.InOrder(
+		mockIncidents.EXPECT().ResolveIncident(ctx, int64(2), gomock.Any()).Return(nil),
+		mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).Return(int64(3), nil),
+	)
+	gomock.InOrder(
+		mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+			Do(func(_ context.Context, alert models.Alert) {
+				assert.Equal(t, models.AlertIncidentResolved, alert.Kind)
+				assert.Equal(t, int64(2), alert.Incident.ID)
+			}),
+		mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+			Do(func(_ context.Context, alert models.Alert) {
+				assert.Equal(t, models.AlertIncidentFlapping, alert.Kind)
+			}),
+	)
+
+	err := svc.ProcessResult(ctx, flappy(), result(models.CheckUp))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_BelowFlapThreshold(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, false)
+
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).Return(nil, errs.ErrIncidentNotFound)
+	mockChecks.EXPECT().CountStateChanges(ctx, monitorID, gomock.Any()).Return(3, nil)
+	mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, incident models.Incident) (int64, error) {
+			assert.False(t, incident.Flapping)
+			return 3, nil
+		})
+	mockNotifier.EXPECT().Notify(ctx, gomock.Any())
+
+	err := svc.ProcessResult(ctx, flappy(), result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_HoldsWhileFlapping(t *testing.T) {
+	ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+	defer ctrl.Finish()
+
+	inMaintenance(t, mockChecks, mockMaintenance, false)
+
+	mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).
+		Return(&models.Incident{ID: 3, MonitorID: monitorID, Flapping: true}, nil)
+	mockChecks.EXPECT().CountStateChanges(ctx, monitorID, gomock.Any()).Return(1, nil)
+	mockIncidents.EXPECT().ResolveIncident(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockNotifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.ProcessResult(ctx, flappy(), result(models.CheckDown))
+	assert.NoError(t, err)
+}
+
+func TestProcessResult_StopsFlapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		maintenance bool
+		opened      bool
+	}{
+		{name: "settled up", status: models.CheckUp},
+		{name: "settled down", status: models.CheckDown, opened: true},
+		{name: "settled up in maintenance", status: models.CheckUp, maintenance: true},
+		{name: "settled down in maintenance", status: models.CheckDown, maintenance: true, opened: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockChecks, mockIncidents, mockMaintenance, mockNotifier, svc := setup(t)
+			defer ctrl.Finish()
+
+			inMaintenance(t, mockChecks, mockMaintenance, tt.maintenance)
+
+			mockIncidents.EXPECT().GetOpenIncident(ctx, monitorID).
+				Return(&models.Incident{ID: 3, MonitorID: monitorID, Flapping: true}, nil)
+			mockChecks.EXPECT().CountStateChanges(ctx, monitorID, gomock.Any()).Return(0, nil)
+			mockIncidents.EXPECT().ResolveIncident(ctx, int64(3), gomock.Any()).Return(nil)
+
+			var kinds []string
+			mockNotifier.EXPECT().Notify(ctx, gomock.Any()).
+				Do(func(_ context.Context, alert models.Alert) {
+					kinds = append(kinds, alert.Kind)
+				}).AnyTimes()
+
+			if tt.opened {
+				mockIncidents.EXPECT().CreateIncident(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, incident models.Incident) (int64, error) {
+						assert.False(t, incident.Flapping)
+						assert.Equal(t, tt.maintenance, incident.Maintenance)
+						return 4, nil
+					})
+			}
+
+			err := svc.ProcessResult(ctx, flappy(), result(tt.status))
+			assert.NoError(t, err)
+
+			expected := []string{models.AlertIncidentResolved}
+			if tt.opened && !tt.maintenance {
+				expected = append(expected, models.AlertIncidentOpened)
+			}
+			assert.Equal(t, expected, kinds)
+		})
+	}
+}
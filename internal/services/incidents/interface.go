@@ -0,0 +1,14 @@
+package incidents
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type IncidentService interface {
+	// ProcessResult records a check result and opens or resolves the
+	// incident of the monitor accordingly.
+	ProcessResult(ctx context.Context, monitor models.Monitor, result models.CheckResult) error
+	GetMonitorIncidents(ctx context.Context, monitorID int64, limit int) ([]models.Incident, error)
+}
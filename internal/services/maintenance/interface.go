@@ -0,0 +1,20 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type MaintenanceService interface {
+	CreateWindow(ctx context.Context, window models.MaintenanceWindow) (int64, error)
+	GetWindow(ctx context.Context, orgID, id int64) (*models.MaintenanceWindow, error)
+	GetOrganizationWindows(ctx context.Context, orgID int64) ([]models.MaintenanceWindow, error)
+	UpdateWindow(ctx context.Context, window models.MaintenanceWindow) error
+	DeleteWindow(ctx context.Context, orgID, id int64) error
+	// MonitorMaintenance returns the windows of a monitor in effect at t.
+	MonitorMaintenance(ctx context.Context, monitorID int64, t time.Time) ([]models.MaintenanceOccurrence, error)
+	// OrganizationMaintenance returns the windows in effect at t by monitor ID.
+	OrganizationMaintenance(ctx context.Context, orgID int64, t time.Time) (map[int64][]models.MaintenanceOccurrence, error)
+}
@@ -0,0 +1,222 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+type maintenanceService struct {
+	repo   repository.MaintenanceRepository
+	audit  audit.AuditService
+	logger logger.Logger
+}
+
+func NewMaintenanceService(repo repository.MaintenanceRepository, audit audit.AuditService, log logger.Logger) MaintenanceService {
+	return &maintenanceService{
+		repo:   repo,
+		audit:  audit,
+		logger: log.WithField("component", "maintenanceService"),
+	}
+}
+
+func (s *maintenanceService) CreateWindow(ctx context.Context, window models.MaintenanceWindow) (int64, error) {
+	window, err := normalize(window)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.CreateWindow(ctx, window)
+	if err != nil {
+		if !errors.Is(err, errs.ErrMonitorNotFound) {
+			s.logger.WithContext(ctx).WithField("organization_id", window.OrganizationID).
+				WithError(err).
+				Error("Failed to create maintenance window")
+		}
+		return 0, err
+	}
+
+	window.ID = id
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &window.OrganizationID,
+		Action:         models.AuditMaintenanceCreate,
+		TargetType:     models.AuditTargetMaintenance,
+		TargetID:       &id,
+		Changes:        audit.Diff(nil, window, "created_at"),
+	})
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"organization_id": window.OrganizationID,
+		"window_id":       id,
+	}).Info("Maintenance window created")
+
+	return id, nil
+}
+
+func (s *maintenanceService) GetWindow(ctx context.Context, orgID, id int64) (*models.MaintenanceWindow, error) {
+	window, err := s.repo.GetWindow(ctx, id)
+	if errors.Is(err, errs.ErrMaintenanceWindowNotFound) {
+		return nil, err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("window_id", id).
+			WithError(err).
+			Error("Failed to fetch maintenance window")
+		return nil, err
+	}
+
+	if window.OrganizationID != orgID {
+		return nil, errs.ErrMaintenanceWindowNotFound
+	}
+
+	return window, nil
+}
+
+func (s *maintenanceService) GetOrganizationWindows(ctx context.Context, orgID int64) ([]models.MaintenanceWindow, error) {
+	windows, err := s.repo.GetOrganizationWindows(ctx, orgID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", orgID).
+			WithError(err).
+			Error("Failed to fetch maintenance windows")
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func (s *maintenanceService) UpdateWindow(ctx context.Context, window models.MaintenanceWindow) error {
+	before, err := s.GetWindow(ctx, window.OrganizationID, window.ID)
+	if err != nil {
+		return err
+	}
+
+	window, err = normalize(window)
+	if err != nil {
+		return err
+	}
+	window.CreatedBy = before.CreatedBy
+	window.CreatedAt = before.CreatedAt
+
+	if err := s.repo.UpdateWindow(ctx, window); err != nil {
+		if !errors.Is(err, errs.ErrMonitorNotFound) && !errors.Is(err, errs.ErrMaintenanceWindowNotFound) {
+			s.logger.WithContext(ctx).WithField("window_id", window.ID).
+				WithError(err).
+				Error("Failed to update maintenance window")
+		}
+		return err
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &window.OrganizationID,
+		Action:         models.AuditMaintenanceUpdate,
+		TargetType:     models.AuditTargetMaintenance,
+		TargetID:       &window.ID,
+		Changes:        audit.Diff(before, window),
+	})
+
+	s.logger.WithContext(ctx).WithField("window_id", window.ID).Info("Maintenance window updated")
+	return nil
+}
+
+func (s *maintenanceService) DeleteWindow(ctx context.Context, orgID, id int64) error {
+	before, err := s.GetWindow(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteWindow(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrMaintenanceWindowNotFound) {
+			s.logger.WithContext(ctx).WithField("window_id", id).
+				WithError(err).
+				Error("Failed to delete maintenance window")
+		}
+		return err
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditMaintenanceDelete,
+		TargetType:     models.AuditTargetMaintenance,
+		TargetID:       &id,
+		Changes:        audit.Diff(before, nil),
+	})
+
+	s.logger.WithContext(ctx).WithField("window_id", id).Info("Maintenance window deleted")
+	return nil
+}
+
+func (s *maintenanceService) MonitorMaintenance(ctx context.Context, monitorID int64, t time.Time) ([]models.MaintenanceOccurrence, error) {
+	windows, err := s.repo.GetMonitorWindows(ctx, monitorID, t)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("monitor_id", monitorID).
+			WithError(err).
+			Error("Failed to fetch monitor maintenance windows")
+		return nil, err
+	}
+
+	var active []models.MaintenanceOccurrence
+	for _, window := range windows {
+		if occurrence, ok := s.occurrenceAt(window, t); ok {
+			active = append(active, occurrence)
+		}
+	}
+
+	return active, nil
+}
+
+func (s *maintenanceService) OrganizationMaintenance(ctx context.Context, orgID int64, t time.Time) (map[int64][]models.MaintenanceOccurrence, error) {
+	windows, err := s.GetOrganizationWindows(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[int64][]models.MaintenanceOccurrence)
+	for _, window := range windows {
+		occurrence, ok := s.occurrenceAt(window, t)
+		if !ok {
+			continue
+		}
+		for _, monitorID := range window.MonitorIDs {
+			active[monitorID] = append(active[monitorID], occurrence)
+		}
+	}
+
+	return active, nil
+}
+
+// occurrenceAt evaluates a stored window. Windows are validated on write, so
+// a failure here means the stored schedule no longer parses and is skipped.
+func (s *maintenanceService) occurrenceAt(window models.MaintenanceWindow, t time.Time) (models.MaintenanceOccurrence, bool) {
+	schedule, err := NewSchedule(window)
+	if err != nil {
+		s.logger.WithField("window_id", window.ID).
+			WithError(err).
+			Warn("Skipping maintenance window with invalid schedule")
+		return models.MaintenanceOccurrence{}, false
+	}
+
+	return schedule.OccurrenceAt(t)
+}
+
+func normalize(window models.MaintenanceWindow) (models.MaintenanceWindow, error) {
+	window.Recurrence = strings.TrimSpace(window.Recurrence)
+	if window.Timezone == "" {
+		window.Timezone = "UTC"
+	}
+
+	slices.Sort(window.MonitorIDs)
+	window.MonitorIDs = slices.Compact(window.MonitorIDs)
+
+	if _, err := NewSchedule(window); err != nil {
+		return window, err
+	}
+
+	return window, nil
+}
@@ -0,0 +1,135 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/robfig/cron/v3"
+	"github.com/teambition/rrule-go"
+)
+
+// Schedule tells when a maintenance window is in effect.
+type Schedule struct {
+	window   models.MaintenanceWindow
+	duration time.Duration
+	// latest returns the last occurrence start in (after, at], zero if none
+	latest func(after, at time.Time) time.Time
+}
+
+// NewSchedule validates the timing of a window. Recurrence is read as an
+// RRULE when it starts with "FREQ=" or "RRULE:", as a cron expression otherwise.
+func NewSchedule(window models.MaintenanceWindow) (*Schedule, error) {
+	loc, err := time.LoadLocation(window.Timezone)
+	if err != nil {
+		return nil, errs.ErrInvalidTimezone
+	}
+
+	s := &Schedule{window: window}
+
+	if window.Recurrence == "" {
+		if window.EndsAt == nil || !window.EndsAt.After(window.StartsAt) {
+			return nil, fmt.Errorf("%w: a one-off window must end after it starts", errs.ErrInvalidSchedule)
+		}
+		if window.DurationMinutes != 0 {
+			return nil, fmt.Errorf("%w: duration only applies to recurring windows", errs.ErrInvalidSchedule)
+		}
+
+		s.duration = window.EndsAt.Sub(window.StartsAt)
+		s.latest = func(after, at time.Time) time.Time {
+			if window.StartsAt.After(after) && !window.StartsAt.After(at) {
+				return window.StartsAt
+			}
+			return time.Time{}
+		}
+		return s, nil
+	}
+
+	if window.DurationMinutes <= 0 {
+		return nil, fmt.Errorf("%w: a recurring window needs a duration", errs.ErrInvalidSchedule)
+	}
+	if window.EndsAt != nil && !window.EndsAt.After(window.StartsAt) {
+		return nil, fmt.Errorf("%w: the series must end after it starts", errs.ErrInvalidSchedule)
+	}
+	s.duration = time.Duration(window.DurationMinutes) * time.Minute
+
+	recurrence := strings.TrimSpace(window.Recurrence)
+	upper := strings.ToUpper(recurrence)
+	if strings.HasPrefix(upper, "FREQ=") || strings.HasPrefix(upper, "RRULE:") {
+		s.latest, err = rruleLatest(recurrence, window.StartsAt.In(loc))
+	} else {
+		s.latest, err = cronLatest(recurrence, window.StartsAt, loc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidSchedule, err)
+	}
+
+	return s, nil
+}
+
+// OccurrenceAt returns the occurrence in effect at t.
+func (s *Schedule) OccurrenceAt(t time.Time) (models.MaintenanceOccurrence, bool) {
+	start := s.latest(t.Add(-s.duration), t)
+	if start.IsZero() {
+		return models.MaintenanceOccurrence{}, false
+	}
+	if s.window.Recurrence != "" && s.window.EndsAt != nil && !start.Before(*s.window.EndsAt) {
+		return models.MaintenanceOccurrence{}, false
+	}
+
+	return models.MaintenanceOccurrence{
+		WindowID: s.window.ID,
+		Title:    s.window.Title,
+		StartsAt: start,
+		EndsAt:   start.Add(s.duration),
+	}, true
+}
+
+func cronLatest(expr string, from time.Time, loc *time.Location) (func(after, at time.Time) time.Time, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, err
+	}
+	// @every counts from whenever it is asked, so the windows would move with
+	// every restart; an RRULE with an INTERVAL is anchored to starts_at
+	if _, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		return nil, errors.New("@every is not supported, use an RRULE such as FREQ=HOURLY;INTERVAL=2")
+	}
+
+	return func(after, at time.Time) time.Time {
+		// Next is exclusive, step back so an occurrence right at the start counts
+		if earliest := from.Add(-time.Second); after.Before(earliest) {
+			after = earliest
+		}
+
+		next := schedule.Next(after.In(loc))
+		if next.IsZero() || next.After(at) {
+			return time.Time{}
+		}
+		return next
+	}, nil
+}
+
+func rruleLatest(expr string, from time.Time) (func(after, at time.Time) time.Time, error) {
+	option, err := rrule.StrToROptionInLocation(expr, from.Location())
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = from
+
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(after, at time.Time) time.Time {
+		last := rule.Before(at, true)
+		if last.IsZero() || !last.After(after) {
+			return time.Time{}
+		}
+		return last
+	}, nil
+}
@@ -0,0 +1,148 @@
+package maintenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/maintenance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestSchedule_OneOff(t *testing.T) {
+	end := at(t, "2026-10-20T12:00:00Z")
+	schedule, err := maintenance.NewSchedule(models.MaintenanceWindow{
+		ID:       1,
+		StartsAt: at(t, "2026-10-20T10:00:00Z"),
+		EndsAt:   &end,
+		Timezone: "UTC",
+	})
+	require.NoError(t, err)
+
+	occurrence, ok := schedule.OccurrenceAt(at(t, "2026-10-20T11:00:00Z"))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), occurrence.WindowID)
+	assert.Equal(t, end, occurrence.EndsAt)
+
+	_, ok = schedule.OccurrenceAt(at(t, "2026-10-20T09:59:59Z"))
+	assert.False(t, ok)
+
+	_, ok = schedule.OccurrenceAt(end)
+	assert.False(t, ok)
+}
+
+func TestSchedule_Recurring(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence string
+	}{
+		{name: "cron", recurrence: "0 2 * * 0"},
+		{name: "rrule", recurrence: "FREQ=WEEKLY;BYDAY=SU;BYHOUR=2;BYMINUTE=0;BYSECOND=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seriesEnd := at(t, "2026-11-01T00:00:00Z")
+			schedule, err := maintenance.NewSchedule(models.MaintenanceWindow{
+				StartsAt:        at(t, "2026-10-01T00:00:00Z"),
+				EndsAt:          &seriesEnd,
+				Recurrence:      tt.recurrence,
+				DurationMinutes: 60,
+				Timezone:        "Europe/Berlin",
+			})
+			require.NoError(t, err)
+
+			// Sunday 02:30 in Berlin, summer time
+			occurrence, ok := schedule.OccurrenceAt(at(t, "2026-10-18T00:30:00Z"))
+			assert.True(t, ok)
+			assert.True(t, occurrence.StartsAt.Equal(at(t, "2026-10-18T00:00:00Z")))
+			assert.True(t, occurrence.EndsAt.Equal(at(t, "2026-10-18T01:00:00Z")))
+
+			_, ok = schedule.OccurrenceAt(at(t, "2026-10-18T01:00:00Z"))
+			assert.False(t, ok)
+
+			_, ok = schedule.OccurrenceAt(at(t, "2026-10-19T00:30:00Z"))
+			assert.False(t, ok)
+
+			// Sunday 02:30 in Berlin, winter time
+			_, ok = schedule.OccurrenceAt(at(t, "2026-10-25T01:30:00Z"))
+			assert.True(t, ok)
+
+			// after the series ended
+			_, ok = schedule.OccurrenceAt(at(t, "2026-11-08T01:30:00Z"))
+			assert.False(t, ok)
+
+			// before the series started
+			_, ok = schedule.OccurrenceAt(at(t, "2026-09-27T00:30:00Z"))
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestSchedule_Invalid(t *testing.T) {
+	start := at(t, "2026-10-20T10:00:00Z")
+	before := start.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		window models.MaintenanceWindow
+		err    error
+	}{
+		{
+			name:   "one-off without end",
+			window: models.MaintenanceWindow{StartsAt: start, Timezone: "UTC"},
+			err:    errs.ErrInvalidSchedule,
+		},
+		{
+			name:   "one-off ending before start",
+			window: models.MaintenanceWindow{StartsAt: start, EndsAt: &before, Timezone: "UTC"},
+			err:    errs.ErrInvalidSchedule,
+		},
+		{
+			name:   "recurring without duration",
+			window: models.MaintenanceWindow{StartsAt: start, Recurrence: "0 2 * * *", Timezone: "UTC"},
+			err:    errs.ErrInvalidSchedule,
+		},
+		{
+			name: "bad cron",
+			window: models.MaintenanceWindow{StartsAt: start, Recurrence: "0 25 * * *",
+				DurationMinutes: 30, Timezone: "UTC"},
+			err: errs.ErrInvalidSchedule,
+		},
+		{
+			name: "cron interval",
+			window: models.MaintenanceWindow{StartsAt: start, Recurrence: "@every 2h",
+				DurationMinutes: 30, Timezone: "UTC"},
+			err: errs.ErrInvalidSchedule,
+		},
+		{
+			name: "bad rrule",
+			window: models.MaintenanceWindow{StartsAt: start, Recurrence: "FREQ=SOMETIMES",
+				DurationMinutes: 30, Timezone: "UTC"},
+			err: errs.ErrInvalidSchedule,
+		},
+		{
+			name: "unknown timezone",
+			window: models.MaintenanceWindow{StartsAt: start, Recurrence: "0 2 * * *",
+				DurationMinutes: 30, Timezone: "Mars/Olympus"},
+			err: errs.ErrInvalidTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := maintenance.NewSchedule(tt.window)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -2,47 +2,83 @@ package monitors
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
 	"github.com/mixdone/uptime-monitoring/pkg/logger"
 )
 
+// auditIgnored fields are not user edits: they change on every check or are
+// only filled in for responses.
+var auditIgnored = []string{"last_checked_at", "active_maintenance"}
+
+// auditMasked fields carry probe headers and bodies, which often hold
+// credentials. The audit log only records that they changed.
+var auditMasked = []string{"request", "expected_response"}
+
 type monitorService struct {
 	repo   repository.MonitorsRepository
+	audit  audit.AuditService
 	logger logger.Logger
 }
 
-func NewMonitorService(repo repository.MonitorsRepository, log logger.Logger) MonitorService {
+func NewMonitorService(repo repository.MonitorsRepository, audit audit.AuditService, log logger.Logger) MonitorService {
 	return &monitorService{
 		repo:   repo,
+		audit:  audit,
 		logger: log.WithField("component", "monitorService"),
 	}
 }
 
 func (s *monitorService) CreateMonitor(ctx context.Context, monitor models.Monitor) (int64, error) {
-	s.logger.Infof("Creating monitor for user_id=%d name=%s", monitor.UserID, monitor.Name)
+	ctx, span := tracing.Start(ctx, "monitorService.CreateMonitor")
+	defer span.End()
+
+	s.logger.WithContext(ctx).Infof("Creating monitor for organization_id=%d user_id=%d name=%s",
+		monitor.OrganizationID, monitor.UserID, monitor.Name)
+
+	monitor, err := s.checkDependencies(monitor)
+	if err != nil {
+		return 0, err
+	}
 
 	id, err := s.repo.CreateMonitor(ctx, monitor)
 	if err != nil {
-		s.logger.WithFields(map[string]any{
-			"userID":      monitor.UserID,
-			"monitorName": monitor.Name,
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"organizationID": monitor.OrganizationID,
+			"userID":         monitor.UserID,
+			"monitorName":    monitor.Name,
 		}).WithError(err).Error("Failed to create monitor")
 		return 0, err
 	}
 
-	s.logger.Infof("Monitor created successfully with id=%d", id)
+	monitor.ID = id
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &monitor.OrganizationID,
+		Action:         models.AuditMonitorCreate,
+		TargetType:     models.AuditTargetMonitor,
+		TargetID:       &id,
+		Changes:        audit.Mask(audit.Diff(nil, monitor, auditIgnored...), auditMasked...),
+	})
+
+	s.logger.WithContext(ctx).Infof("Monitor created successfully with id=%d", id)
 	return id, nil
 }
 
 func (s *monitorService) GetMonitor(ctx context.Context, id int64) (*models.Monitor, error) {
-	s.logger.Debugf("Fetching monitor with id=%d", id)
+	ctx, span := tracing.Start(ctx, "monitorService.GetMonitor", tracing.MonitorID(id))
+	defer span.End()
+
+	s.logger.WithContext(ctx).Debugf("Fetching monitor with id=%d", id)
 
 	monitor, err := s.repo.GetMonitor(ctx, id)
 	if err != nil {
-		s.logger.WithFields(map[string]any{
+		s.logger.WithContext(ctx).WithFields(map[string]any{
 			"monitorID": id,
 		}).WithError(err).Error("Failed to fetch monitor")
 		return nil, err
@@ -51,14 +87,17 @@ func (s *monitorService) GetMonitor(ctx context.Context, id int64) (*models.Moni
 	return monitor, nil
 }
 
-func (s *monitorService) GetAllUserMonitors(ctx context.Context, userID int64) ([]models.Monitor, error) {
-	s.logger.Debugf("Fetching all monitors for user_id=%d", userID)
+func (s *monitorService) GetAllOrganizationMonitors(ctx context.Context, orgID int64) ([]models.Monitor, error) {
+	ctx, span := tracing.Start(ctx, "monitorService.GetAllOrganizationMonitors")
+	defer span.End()
 
-	monitors, err := s.repo.GetAllUserMonitors(ctx, userID)
+	s.logger.WithContext(ctx).Debugf("Fetching all monitors for organization_id=%d", orgID)
+
+	monitors, err := s.repo.GetAllOrganizationMonitors(ctx, orgID)
 	if err != nil {
-		s.logger.WithFields(map[string]any{
-			"userID": userID,
-		}).WithError(err).Error("Failed to fetch user monitors")
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"organizationID": orgID,
+		}).WithError(err).Error("Failed to fetch organization monitors")
 		return nil, err
 	}
 
@@ -66,11 +105,11 @@ func (s *monitorService) GetAllUserMonitors(ctx context.Context, userID int64) (
 }
 
 func (s *monitorService) GetAllActiveMonitors(ctx context.Context) ([]models.Monitor, error) {
-	s.logger.Debug("Fetching all active monitors")
+	s.logger.WithContext(ctx).Debug("Fetching all active monitors")
 
 	monitors, err := s.repo.GetAllActiveMonitors(ctx)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to fetch active monitors")
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to fetch active monitors")
 		return nil, err
 	}
 
@@ -78,43 +117,99 @@ func (s *monitorService) GetAllActiveMonitors(ctx context.Context) ([]models.Mon
 }
 
 func (s *monitorService) UpdateMonitor(ctx context.Context, monitor models.Monitor) error {
-	s.logger.Infof("Updating monitor id=%d", monitor.ID)
+	ctx, span := tracing.Start(ctx, "monitorService.UpdateMonitor", tracing.MonitorID(monitor.ID))
+	defer span.End()
+
+	s.logger.WithContext(ctx).Infof("Updating monitor id=%d", monitor.ID)
+
+	before, err := s.repo.GetMonitor(ctx, monitor.ID)
+	if err != nil {
+		return err
+	}
+
+	monitor, err = s.checkDependencies(monitor)
+	if err != nil {
+		return err
+	}
 
 	if err := s.repo.UpdateMonitor(ctx, monitor); err != nil {
-		s.logger.WithFields(map[string]any{
+		s.logger.WithContext(ctx).WithFields(map[string]any{
 			"monitorID": monitor.ID,
 		}).WithError(err).Error("Failed to update monitor")
 		return err
 	}
 
-	s.logger.Infof("Monitor updated successfully id=%d", monitor.ID)
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &before.OrganizationID,
+		Action:         models.AuditMonitorUpdate,
+		TargetType:     models.AuditTargetMonitor,
+		TargetID:       &monitor.ID,
+		Changes:        audit.Mask(audit.Diff(before, monitor, auditIgnored...), auditMasked...),
+	})
+
+	s.logger.WithContext(ctx).Infof("Monitor updated successfully id=%d", monitor.ID)
 	return nil
 }
 
 func (s *monitorService) UpdateLastCheckedAt(ctx context.Context, id int64, checkedAt time.Time) error {
-	s.logger.Debugf("Updating last_checked_at for monitor id=%d", id)
+	s.logger.WithContext(ctx).Debugf("Updating last_checked_at for monitor id=%d", id)
 
 	if err := s.repo.UpdateLastCheckedAt(ctx, id, checkedAt); err != nil {
-		s.logger.WithFields(map[string]any{
+		s.logger.WithContext(ctx).WithFields(map[string]any{
 			"monitorID": id,
 		}).WithError(err).Error("Failed to update last_checked_at")
 		return err
 	}
 
-	s.logger.Debugf("Updated last_checked_at successfully for monitor id=%d", id)
+	s.logger.WithContext(ctx).Debugf("Updated last_checked_at successfully for monitor id=%d", id)
 	return nil
 }
 
 func (s *monitorService) DeleteMonitor(ctx context.Context, id int64) error {
-	s.logger.Infof("Deleting monitor id=%d", id)
+	ctx, span := tracing.Start(ctx, "monitorService.DeleteMonitor", tracing.MonitorID(id))
+	defer span.End()
+
+	s.logger.WithContext(ctx).Infof("Deleting monitor id=%d", id)
+
+	before, err := s.repo.GetMonitor(ctx, id)
+	if err != nil {
+		return err
+	}
 
 	if err := s.repo.DeleteMonitor(ctx, id); err != nil {
-		s.logger.WithFields(map[string]any{
+		s.logger.WithContext(ctx).WithFields(map[string]any{
 			"monitorID": id,
 		}).WithError(err).Error("Failed to delete monitor")
 		return err
 	}
 
-	s.logger.Infof("Monitor deleted successfully id=%d", id)
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &before.OrganizationID,
+		Action:         models.AuditMonitorDelete,
+		TargetType:     models.AuditTargetMonitor,
+		TargetID:       &id,
+		Changes:        audit.Mask(audit.Diff(before, nil, auditIgnored...), auditMasked...),
+	})
+
+	s.logger.WithContext(ctx).Infof("Monitor deleted successfully id=%d", id)
 	return nil
 }
+
+// checkDependencies deduplicates the parents of a monitor and rejects a
+// monitor that depends on itself. Longer cycles are caught by the repository
+// in the transaction that stores the parents.
+func (s *monitorService) checkDependencies(monitor models.Monitor) (models.Monitor, error) {
+	if len(monitor.ParentIDs) == 0 {
+		return monitor, nil
+	}
+
+	monitor.ParentIDs = slices.Clone(monitor.ParentIDs)
+	slices.Sort(monitor.ParentIDs)
+	monitor.ParentIDs = slices.Compact(monitor.ParentIDs)
+
+	if monitor.ID != 0 && slices.Contains(monitor.ParentIDs, monitor.ID) {
+		return monitor, errs.ErrSelfDependency
+	}
+
+	return monitor, nil
+}
@@ -3,6 +3,7 @@ package monitors_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/mixdone/uptime-monitoring/internal/mocks"
 	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 	"github.com/mixdone/uptime-monitoring/internal/services/monitors"
 )
 
@@ -22,7 +24,11 @@ func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockMonito
 	ctrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockMonitorsRepository(ctrl)
 	mockLogger := mocks.NewMockLogger(ctrl)
+	mockAudit := mocks.NewMockAuditService(ctrl)
 
+	mockAudit.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
@@ -34,7 +40,7 @@ func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockMonito
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
-	svc := monitors.NewMonitorService(mockRepo, mockLogger)
+	svc := monitors.NewMonitorService(mockRepo, mockAudit, mockLogger)
 	return context.Background(), ctrl, mockRepo, mockLogger, svc
 }
 
@@ -155,3 +161,53 @@ func TestGetMonitor_Failure(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateMonitor_Dependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		parentIDs []int64
+		repoErr   error
+		err       error
+	}{
+		{
+			name:      "depends on itself",
+			parentIDs: []int64{2, expectedID},
+			err:       errs.ErrSelfDependency,
+		},
+		{
+			name:      "indirect cycle",
+			parentIDs: []int64{2, 3},
+			repoErr:   errs.ErrDependencyCycle,
+			err:       errs.ErrDependencyCycle,
+		},
+		{
+			name:      "shared parent",
+			parentIDs: []int64{3, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			monitor := models.Monitor{ID: expectedID, OrganizationID: 1, ParentIDs: tt.parentIDs}
+
+			mockRepo.EXPECT().GetMonitor(ctx, expectedID).Return(&models.Monitor{ID: expectedID, OrganizationID: 1}, nil)
+			if !errors.Is(tt.err, errs.ErrSelfDependency) {
+				mockRepo.EXPECT().UpdateMonitor(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, m models.Monitor) error {
+						assert.Equal(t, []int64{2, 3}, m.ParentIDs)
+						return tt.repoErr
+					})
+			}
+
+			err := svc.UpdateMonitor(ctx, monitor)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+type channelService struct {
+	repo   repository.ChannelRepository
+	logger logger.Logger
+}
+
+func NewChannelService(repo repository.ChannelRepository, log logger.Logger) ChannelService {
+	return &channelService{
+		repo:   repo,
+		logger: log.WithField("component", "channelService"),
+	}
+}
+
+func (s *channelService) CreateChannel(ctx context.Context, channel models.NotificationChannel) (int64, error) {
+	channel, err := validateChannel(channel)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.CreateChannel(ctx, channel)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", channel.OrganizationID).
+			WithError(err).
+			Error("Failed to create notification channel")
+		return 0, err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"organization_id": channel.OrganizationID,
+		"channel_id":      id,
+		"type":            channel.Type,
+	}).Info("Notification channel created")
+
+	return id, nil
+}
+
+func (s *channelService) GetChannel(ctx context.Context, orgID, id int64) (*models.NotificationChannel, error) {
+	channel, err := s.repo.GetChannel(ctx, id)
+	if errors.Is(err, errs.ErrChannelNotFound) {
+		return nil, err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("channel_id", id).
+			WithError(err).
+			Error("Failed to fetch notification channel")
+		return nil, err
+	}
+
+	if channel.OrganizationID != orgID {
+		return nil, errs.ErrChannelNotFound
+	}
+
+	return channel, nil
+}
+
+func (s *channelService) GetOrganizationChannels(ctx context.Context, orgID int64) ([]models.NotificationChannel, error) {
+	channels, err := s.repo.GetOrganizationChannels(ctx, orgID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", orgID).
+			WithError(err).
+			Error("Failed to fetch notification channels")
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+func (s *channelService) UpdateChannel(ctx context.Context, channel models.NotificationChannel) error {
+	if _, err := s.GetChannel(ctx, channel.OrganizationID, channel.ID); err != nil {
+		return err
+	}
+
+	channel, err := validateChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateChannel(ctx, channel); err != nil {
+		if !errors.Is(err, errs.ErrChannelNotFound) {
+			s.logger.WithContext(ctx).WithField("channel_id", channel.ID).
+				WithError(err).
+				Error("Failed to update notification channel")
+		}
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithField("channel_id", channel.ID).Info("Notification channel updated")
+	return nil
+}
+
+func (s *channelService) DeleteChannel(ctx context.Context, orgID, id int64) error {
+	if _, err := s.GetChannel(ctx, orgID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteChannel(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrChannelNotFound) {
+			s.logger.WithContext(ctx).WithField("channel_id", id).
+				WithError(err).
+				Error("Failed to delete notification channel")
+		}
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithField("channel_id", id).Info("Notification channel deleted")
+	return nil
+}
+
+// validateChannel checks the settings the channel type needs and drops the
+// ones it doesn't use.
+func validateChannel(channel models.NotificationChannel) (models.NotificationChannel, error) {
+	config := channel.Config
+
+	switch channel.Type {
+	case models.ChannelTelegram:
+		if config.ChatID == 0 {
+			return channel, fmt.Errorf("%w: telegram needs a chat_id", errs.ErrInvalidChannel)
+		}
+		channel.Config = models.ChannelConfig{ChatID: config.ChatID}
+	case models.ChannelEmail:
+		if _, err := mail.ParseAddress(config.Email); err != nil {
+			return channel, fmt.Errorf("%w: invalid email address", errs.ErrInvalidChannel)
+		}
+		channel.Config = models.ChannelConfig{Email: config.Email}
+	case models.ChannelWebhook:
+		u, err := url.Parse(config.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return channel, fmt.Errorf("%w: webhook needs an http or https url", errs.ErrInvalidChannel)
+		}
+		channel.Config = models.ChannelConfig{URL: config.URL, Secret: config.Secret}
+	default:
+		return channel, fmt.Errorf("%w: unknown type %q", errs.ErrInvalidChannel, channel.Type)
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,15 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type ChannelService interface {
+	CreateChannel(ctx context.Context, channel models.NotificationChannel) (int64, error)
+	GetChannel(ctx context.Context, orgID, id int64) (*models.NotificationChannel, error)
+	GetOrganizationChannels(ctx context.Context, orgID int64) ([]models.NotificationChannel, error)
+	UpdateChannel(ctx context.Context, channel models.NotificationChannel) error
+	DeleteChannel(ctx context.Context, orgID, id int64) error
+}
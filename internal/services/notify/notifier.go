@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+// Notifier delivers alerts about incidents. Delivery failures are handled by
+// the notifier itself so they never block incident tracking.
+type Notifier interface {
+	Notify(ctx context.Context, alert models.Alert)
+}
+
+type logNotifier struct {
+	logger logger.Logger
+}
+
+// NewLogNotifier writes alerts to the log.
+func NewLogNotifier(log logger.Logger) Notifier {
+	return &logNotifier{logger: log.WithField("component", "notifier")}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, alert models.Alert) {
+	n.logger.WithContext(ctx).WithFields(map[string]any{
+		"alert":       alert.Kind,
+		"monitor_id":  alert.Monitor.ID,
+		"monitor":     alert.Monitor.Name,
+		"incident_id": alert.Incident.ID,
+		"cause":       alert.Incident.Cause,
+	}).Warn("Monitor alert")
+}
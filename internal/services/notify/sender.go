@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/pkg/mailer"
+)
+
+const (
+	DefaultTelegramAPIURL = "https://api.telegram.org"
+	// SignatureHeader carries the hex HMAC-SHA256 of a webhook body
+	SignatureHeader = "X-Uptime-Signature"
+
+	sendTimeout = 10 * time.Second
+)
+
+// Sender delivers an alert to one channel.
+type Sender interface {
+	Send(ctx context.Context, channel models.NotificationChannel, alert models.Alert) error
+}
+
+type sender struct {
+	mail           mailer.Mailer
+	client         *http.Client
+	telegramToken  string
+	telegramAPIURL string
+}
+
+// NewSender sends Telegram messages through the bot with telegramToken and
+// emails through mail. An empty telegramAPIURL means the public Bot API.
+func NewSender(mail mailer.Mailer, telegramToken, telegramAPIURL string, client *http.Client) Sender {
+	if client == nil {
+		client = &http.Client{Timeout: sendTimeout}
+	}
+	if telegramAPIURL == "" {
+		telegramAPIURL = DefaultTelegramAPIURL
+	}
+
+	return &sender{
+		mail:           mail,
+		client:         client,
+		telegramToken:  telegramToken,
+		telegramAPIURL: strings.TrimRight(telegramAPIURL, "/"),
+	}
+}
+
+func (s *sender) Send(ctx context.Context, channel models.NotificationChannel, alert models.Alert) error {
+	switch channel.Type {
+	case models.ChannelTelegram:
+		return s.sendTelegram(ctx, channel.Config, alert)
+	case models.ChannelEmail:
+		subject, body := formatAlert(alert)
+		return s.mail.Send(ctx, mailer.Message{To: channel.Config.Email, Subject: subject, Body: body})
+	case models.ChannelWebhook:
+		return s.sendWebhook(ctx, channel.Config, alert)
+	default:
+		return fmt.Errorf("unknown channel type %q", channel.Type)
+	}
+}
+
+func (s *sender) sendTelegram(ctx context.Context, config models.ChannelConfig, alert models.Alert) error {
+	if s.telegramToken == "" {
+		return errors.New("telegram bot token is not configured")
+	}
+
+	subject, body := formatAlert(alert)
+	payload, err := json.Marshal(map[string]any{
+		"chat_id": config.ChatID,
+		"text":    subject + "\n\n" + body,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", s.telegramAPIURL, s.telegramToken)
+	return s.post(ctx, url, payload, nil)
+}
+
+// webhookPayload is the JSON body posted to webhook channels.
+type webhookPayload struct {
+	Event    string          `json:"event"`
+	Level    int             `json:"level"`
+	Monitor  webhookMonitor  `json:"monitor"`
+	Incident models.Incident `json:"incident"`
+}
+
+type webhookMonitor struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+func (s *sender) sendWebhook(ctx context.Context, config models.ChannelConfig, alert models.Alert) error {
+	payload, err := json.Marshal(webhookPayload{
+		Event: alert.Kind,
+		Level: alert.Level,
+		Monitor: webhookMonitor{
+			ID:     alert.Monitor.ID,
+			Name:   alert.Monitor.Name,
+			Target: alert.Monitor.Target,
+		},
+		Incident: alert.Incident,
+	})
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if config.Secret != "" {
+		headers[SignatureHeader] = Sign(config.Secret, payload)
+	}
+
+	return s.post(ctx, config.URL, payload, headers)
+}
+
+func (s *sender) post(ctx context.Context, url string, payload []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the signature a webhook receiver can recompute from the body
+// and the shared secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func formatAlert(alert models.Alert) (subject, body string) {
+	name := alert.Monitor.Name
+	since := alert.Incident.StartedAt.UTC().Format(time.RFC1123)
+
+	switch alert.Kind {
+	case models.AlertIncidentOpened:
+		subject = "[DOWN] " + name
+		body = fmt.Sprintf("%s (%s) is down since %s.\nCause: %s", name, alert.Monitor.Target, since, alert.Incident.Cause)
+	case models.AlertIncidentEscalated:
+		subject = fmt.Sprintf("[DOWN] %s, escalation level %d", name, alert.Level+1)
+		body = fmt.Sprintf("%s (%s) is down since %s and nobody has acknowledged it yet.\nCause: %s",
+			name, alert.Monitor.Target, since, alert.Incident.Cause)
+	case models.AlertIncidentAcknowledged:
+		subject = "[ACK] " + name
+		body = fmt.Sprintf("The incident of %s that started %s was acknowledged.", name, since)
+	case models.AlertIncidentFlapping:
+		subject = "[FLAPPING] " + name
+		body = fmt.Sprintf("%s (%s) keeps switching between up and down: %s.\n"+
+			"Further alerts are held until its status is stable.", name, alert.Monitor.Target, alert.Incident.Cause)
+	case models.AlertIncidentResolved:
+		if alert.Incident.Flapping {
+			subject = "[STABLE] " + name
+			body = fmt.Sprintf("%s stopped flapping.", name)
+			break
+		}
+		subject = "[UP] " + name
+		body = fmt.Sprintf("%s is back up.", name)
+		if alert.Incident.ResolvedAt != nil {
+			body = fmt.Sprintf("%s is back up after %s.", name,
+				alert.Incident.ResolvedAt.Sub(alert.Incident.StartedAt).Round(time.Second))
+		}
+	default:
+		subject = fmt.Sprintf("[%s] %s", alert.Kind, name)
+		body = alert.Incident.Cause
+	}
+
+	return subject, body
+}
@@ -0,0 +1,113 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services/notify"
+)
+
+func alert() models.Alert {
+	return models.Alert{
+		Kind:     models.AlertIncidentEscalated,
+		Level:    1,
+		Monitor:  models.Monitor{ID: 7, Name: "api", Target: "https://example.com"},
+		Incident: models.Incident{ID: 3, MonitorID: 7, StartedAt: time.Now(), Cause: "timeout"},
+	}
+}
+
+func TestSend_WebhookSigned(t *testing.T) {
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(notify.SignatureHeader)
+	}))
+	defer srv.Close()
+
+	sender := notify.NewSender(nil, "", "", srv.Client())
+	err := sender.Send(context.Background(), models.NotificationChannel{
+		Type:   models.ChannelWebhook,
+		Config: models.ChannelConfig{URL: srv.URL, Secret: "s3cret"},
+	}, alert())
+	require.NoError(t, err)
+
+	assert.Equal(t, notify.Sign("s3cret", body), signature)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, models.AlertIncidentEscalated, payload["event"])
+	assert.EqualValues(t, 1, payload["level"])
+}
+
+func TestSend_WebhookUnsigned(t *testing.T) {
+	signed := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, signed = r.Header[notify.SignatureHeader]
+	}))
+	defer srv.Close()
+
+	sender := notify.NewSender(nil, "", "", srv.Client())
+	err := sender.Send(context.Background(), models.NotificationChannel{
+		Type:   models.ChannelWebhook,
+		Config: models.ChannelConfig{URL: srv.URL},
+	}, alert())
+
+	require.NoError(t, err)
+	assert.False(t, signed)
+}
+
+func TestSend_WebhookFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	sender := notify.NewSender(nil, "", "", srv.Client())
+	err := sender.Send(context.Background(), models.NotificationChannel{
+		Type:   models.ChannelWebhook,
+		Config: models.ChannelConfig{URL: srv.URL},
+	}, alert())
+
+	assert.Error(t, err)
+}
+
+func TestSend_Telegram(t *testing.T) {
+	var path string
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	sender := notify.NewSender(nil, "123:abc", srv.URL, srv.Client())
+	err := sender.Send(context.Background(), models.NotificationChannel{
+		Type:   models.ChannelTelegram,
+		Config: models.ChannelConfig{ChatID: 42},
+	}, alert())
+	require.NoError(t, err)
+
+	assert.Equal(t, "/bot123:abc/sendMessage", path)
+	assert.EqualValues(t, 42, payload["chat_id"])
+	assert.Contains(t, payload["text"], "escalation level 2")
+}
+
+func TestSend_TelegramWithoutToken(t *testing.T) {
+	sender := notify.NewSender(nil, "", "", nil)
+	err := sender.Send(context.Background(), models.NotificationChannel{
+		Type:   models.ChannelTelegram,
+		Config: models.ChannelConfig{ChatID: 42},
+	}, alert())
+
+	assert.Error(t, err)
+}
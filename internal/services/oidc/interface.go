@@ -0,0 +1,12 @@
+package oidc
+
+import "context"
+
+type OIDCService interface {
+	// Begin starts a login and returns the provider URL to redirect to,
+	// together with the state the callback has to carry.
+	Begin(ctx context.Context, fingerprint string) (url, state string, err error)
+	// Complete finishes a login and returns the local user it maps to,
+	// together with the fingerprint the login was started with.
+	Complete(ctx context.Context, code, state string) (userID int64, fingerprint string, err error)
+}
@@ -0,0 +1,237 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/sso"
+)
+
+const (
+	minUsernameLen = 3
+	maxUsernameLen = 30
+)
+
+type oidcService struct {
+	client        *sso.Client
+	repo          repository.IdentityRepository
+	users         repository.UserRepository
+	autoProvision bool
+	logger        logger.Logger
+}
+
+func NewOIDCService(client *sso.Client, repo repository.IdentityRepository, users repository.UserRepository,
+	autoProvision bool, log logger.Logger) OIDCService {
+	return &oidcService{
+		client:        client,
+		repo:          repo,
+		users:         users,
+		autoProvision: autoProvision,
+		logger:        log.WithField("component", "oidcService"),
+	}
+}
+
+func (s *oidcService) Begin(ctx context.Context, fingerprint string) (string, string, error) {
+	req, err := s.client.Begin(ctx)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to start sign-on")
+		return "", "", errs.ErrSSOFailed
+	}
+
+	err = s.repo.CreateLoginState(ctx, models.OIDCLoginState{
+		StateHash:    hashState(req.State),
+		Nonce:        req.Nonce,
+		CodeVerifier: req.Verifier,
+		Fingerprint:  fingerprint,
+		ExpiresAt:    time.Now().Add(constants.OIDCLoginStateTTL),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to store sign-on state")
+		return "", "", err
+	}
+
+	return req.URL, req.State, nil
+}
+
+func (s *oidcService) Complete(ctx context.Context, code, state string) (int64, string, error) {
+	loginState, err := s.repo.ConsumeLoginState(ctx, hashState(state))
+	if err != nil {
+		return 0, "", err
+	}
+
+	identity, err := s.client.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Sign-on rejected")
+		return 0, "", errs.ErrSSOFailed
+	}
+
+	userID, err := s.resolveUser(ctx, identity)
+	if err != nil {
+		return 0, "", err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"user_id": userID,
+		"issuer":  identity.Issuer,
+		"subject": identity.Subject,
+	}).Info("Signed on through identity provider")
+
+	return userID, loginState.Fingerprint, nil
+}
+
+// resolveUser finds the local user of an identity. Unknown identities are
+// linked to the user with the same email only if both sides have verified
+// it, otherwise anyone could claim an account by registering its address at
+// the provider. An address held by an unverified local user is not taken over
+// either: the provisioned user is created without it.
+func (s *oidcService) resolveUser(ctx context.Context, identity *sso.Identity) (int64, error) {
+	linked, err := s.repo.GetIdentity(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		if err := s.repo.TouchIdentity(ctx, linked.ID, identity.Email); err != nil {
+			return 0, err
+		}
+		return linked.UserID, nil
+	}
+	if !errors.Is(err, errs.ErrIdentityNotFound) {
+		return 0, err
+	}
+
+	var userID int64
+	emailFree := identity.Email != "" && identity.EmailVerified
+	if emailFree {
+		user, err := s.users.GetUserByEmail(ctx, identity.Email)
+		switch {
+		case err == nil && user.EmailVerifiedAt != nil:
+			userID = user.ID
+		case err == nil:
+			emailFree = false
+		case errors.Is(err, errs.ErrUserNotFound):
+		default:
+			return 0, err
+		}
+	}
+
+	if userID == 0 {
+		if !s.autoProvision {
+			return 0, errs.ErrSSONoAccount
+		}
+
+		userID, err = s.provision(ctx, identity, emailFree)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = s.repo.CreateIdentity(ctx, models.UserIdentity{
+		UserID:  userID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   identity.Email,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to link identity")
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// provision creates a user without a password for the identity, keeping the
+// verified email when withEmail is set. A password can be set later through
+// the reset flow.
+func (s *oidcService) provision(ctx context.Context, identity *sso.Identity, withEmail bool) (int64, error) {
+	username, err := s.freeUsername(ctx, identity)
+	if err != nil {
+		return 0, err
+	}
+
+	user := models.User{Username: username}
+	if withEmail {
+		user.Email = identity.Email
+	}
+
+	id, err := s.users.CreateUserWithOrganization(ctx, user, username)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("username", username).
+			WithError(err).
+			Error("Failed to provision user")
+		return 0, err
+	}
+
+	if user.Email != "" {
+		if err := s.users.MarkEmailVerified(ctx, id); err != nil {
+			return 0, err
+		}
+	}
+
+	s.logger.WithContext(ctx).Infof("Provisioned user id=%d from identity provider", id)
+	return id, nil
+}
+
+func (s *oidcService) freeUsername(ctx context.Context, identity *sso.Identity) (string, error) {
+	base := identity.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = sanitizeUsername(base)
+	if len(base) < minUsernameLen {
+		base = "user"
+	}
+
+	for i := 1; i <= 100; i++ {
+		candidate := base
+		if i > 1 {
+			suffix := fmt.Sprintf("-%d", i)
+			candidate = truncate(base, maxUsernameLen-len(suffix)) + suffix
+		}
+
+		_, err := s.users.GetUserByUsername(ctx, candidate)
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", errs.ErrUsernameTaken
+}
+
+func sanitizeUsername(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return -1
+		}
+	}, s)
+
+	return truncate(s, maxUsernameLen)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func hashState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
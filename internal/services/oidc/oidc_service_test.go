@@ -0,0 +1,129 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/pkg/sso"
+)
+
+const (
+	issuer  = "https://idp.example.com"
+	subject = "sub-1"
+	email   = "alice@example.com"
+)
+
+func setup(t *testing.T, autoProvision bool) (context.Context, *gomock.Controller, *mocks.MockIdentityRepository, *mocks.MockUserRepository, *oidcService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockIdentityRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := NewOIDCService(nil, mockRepo, mockUsers, autoProvision, mockLogger).(*oidcService)
+	return context.Background(), ctrl, mockRepo, mockUsers, svc
+}
+
+func identity() *sso.Identity {
+	return &sso.Identity{
+		Issuer:            issuer,
+		Subject:           subject,
+		Email:             email,
+		EmailVerified:     true,
+		PreferredUsername: "alice",
+	}
+}
+
+func TestResolveUser_LinkedIdentity(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t, true)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetIdentity(ctx, issuer, subject).
+		Return(&models.UserIdentity{ID: 3, UserID: 1, Issuer: issuer, Subject: subject}, nil)
+	mockRepo.EXPECT().TouchIdentity(ctx, int64(3), email).Return(nil)
+
+	userID, err := svc.resolveUser(ctx, identity())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+}
+
+func TestResolveUser_VerifiedLocalEmail(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUsers, svc := setup(t, true)
+	defer ctrl.Finish()
+
+	verified := time.Now()
+	mockRepo.EXPECT().GetIdentity(ctx, issuer, subject).Return(nil, errs.ErrIdentityNotFound)
+	mockUsers.EXPECT().GetUserByEmail(ctx, email).
+		Return(&models.User{ID: 1, Email: email, EmailVerifiedAt: &verified}, nil)
+	mockUsers.EXPECT().CreateUserWithOrganization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().CreateIdentity(ctx, models.UserIdentity{
+		UserID: 1, Issuer: issuer, Subject: subject, Email: email,
+	}).Return(nil)
+
+	userID, err := svc.resolveUser(ctx, identity())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+}
+
+func TestResolveUser_UnverifiedLocalEmail(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUsers, svc := setup(t, true)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetIdentity(ctx, issuer, subject).Return(nil, errs.ErrIdentityNotFound)
+	mockUsers.EXPECT().GetUserByEmail(ctx, email).Return(&models.User{ID: 1, Email: email}, nil)
+	mockUsers.EXPECT().GetUserByUsername(ctx, "alice").Return(&models.User{ID: 1, Username: "alice"}, nil)
+	mockUsers.EXPECT().GetUserByUsername(ctx, "alice-2").Return(nil, errs.ErrUserNotFound)
+	mockUsers.EXPECT().CreateUserWithOrganization(ctx, models.User{Username: "alice-2"}, "alice-2").Return(int64(2), nil)
+	mockUsers.EXPECT().MarkEmailVerified(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().CreateIdentity(ctx, models.UserIdentity{
+		UserID: 2, Issuer: issuer, Subject: subject, Email: email,
+	}).Return(nil)
+
+	userID, err := svc.resolveUser(ctx, identity())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), userID)
+}
+
+func TestResolveUser_NewEmail(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUsers, svc := setup(t, true)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetIdentity(ctx, issuer, subject).Return(nil, errs.ErrIdentityNotFound)
+	mockUsers.EXPECT().GetUserByEmail(ctx, email).Return(nil, errs.ErrUserNotFound)
+	mockUsers.EXPECT().GetUserByUsername(ctx, "alice").Return(nil, errs.ErrUserNotFound)
+	mockUsers.EXPECT().CreateUserWithOrganization(ctx, models.User{Username: "alice", Email: email}, "alice").Return(int64(2), nil)
+	mockUsers.EXPECT().MarkEmailVerified(ctx, int64(2)).Return(nil)
+	mockRepo.EXPECT().CreateIdentity(ctx, gomock.Any()).Return(nil)
+
+	userID, err := svc.resolveUser(ctx, identity())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), userID)
+}
+
+func TestResolveUser_NoAutoProvision(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUsers, svc := setup(t, false)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetIdentity(ctx, issuer, subject).Return(nil, errs.ErrIdentityNotFound)
+	mockUsers.EXPECT().GetUserByEmail(ctx, email).Return(&models.User{ID: 1, Email: email}, nil)
+	mockUsers.EXPECT().CreateUserWithOrganization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := svc.resolveUser(ctx, identity())
+	assert.ErrorIs(t, err, errs.ErrSSONoAccount)
+}
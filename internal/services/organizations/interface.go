@@ -0,0 +1,24 @@
+package organizations
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, ownerID int64, name string) (int64, error)
+	GetUserOrganizations(ctx context.Context, userID int64) ([]models.Organization, error)
+	GetActiveMembership(ctx context.Context, userID int64) (*models.OrganizationMember, error)
+	SwitchActiveOrganization(ctx context.Context, userID, orgID int64) error
+	Authorize(ctx context.Context, userID, orgID int64, required models.OrganizationRole) (*models.OrganizationMember, error)
+
+	GetMembers(ctx context.Context, actorID, orgID int64) ([]models.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, actorID, orgID, userID int64, role models.OrganizationRole) error
+	RemoveMember(ctx context.Context, actorID, orgID, userID int64) error
+
+	InviteMember(ctx context.Context, actorID int64, invitation models.OrganizationInvitation) (int64, error)
+	GetUserInvitations(ctx context.Context, userID int64) ([]models.OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID int64) error
+	DeclineInvitation(ctx context.Context, userID, invitationID int64) error
+}
@@ -0,0 +1,365 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+type organizationService struct {
+	repo   repository.OrganizationRepository
+	users  repository.UserRepository
+	logger logger.Logger
+}
+
+func NewOrganizationService(repo repository.OrganizationRepository, users repository.UserRepository, log logger.Logger) OrganizationService {
+	return &organizationService{
+		repo:   repo,
+		users:  users,
+		logger: log.WithField("component", "organizationService"),
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, ownerID int64, name string) (int64, error) {
+	s.logger.WithContext(ctx).Infof("Creating organization %q for user_id=%d", name, ownerID)
+
+	id, err := s.repo.CreateOrganization(ctx, models.Organization{Name: name}, ownerID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"userID": ownerID,
+			"name":   name,
+		}).WithError(err).Error("Failed to create organization")
+		return 0, err
+	}
+
+	user, err := s.users.GetUser(ctx, ownerID)
+	if err != nil {
+		return 0, err
+	}
+
+	if user.ActiveOrganizationID == nil {
+		if err := s.users.SetActiveOrganization(ctx, ownerID, id); err != nil {
+			s.logger.WithContext(ctx).WithField("userID", ownerID).
+				WithError(err).
+				Error("Failed to set active organization")
+			return 0, err
+		}
+	}
+
+	s.logger.WithContext(ctx).Infof("Organization created successfully with id=%d", id)
+	return id, nil
+}
+
+func (s *organizationService) GetUserOrganizations(ctx context.Context, userID int64) ([]models.Organization, error) {
+	s.logger.WithContext(ctx).Debugf("Fetching organizations for user_id=%d", userID)
+
+	orgs, err := s.repo.GetUserOrganizations(ctx, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to fetch user organizations")
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// GetActiveMembership resolves the organization the user currently works in.
+// If the stored active organization is gone or the user was removed from it,
+// the first remaining membership becomes active.
+func (s *organizationService) GetActiveMembership(ctx context.Context, userID int64) (*models.OrganizationMember, error) {
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ActiveOrganizationID != nil {
+		member, err := s.repo.GetMember(ctx, *user.ActiveOrganizationID, userID)
+		if err == nil {
+			return member, nil
+		}
+		if !errors.Is(err, errs.ErrMemberNotFound) {
+			return nil, err
+		}
+	}
+
+	orgs, err := s.repo.GetUserOrganizations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orgs) == 0 {
+		return nil, errs.ErrNoActiveOrganization
+	}
+
+	if err := s.users.SetActiveOrganization(ctx, userID, orgs[0].ID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMember(ctx, orgs[0].ID, userID)
+}
+
+func (s *organizationService) SwitchActiveOrganization(ctx context.Context, userID, orgID int64) error {
+	if _, err := s.Authorize(ctx, userID, orgID, models.RoleViewer); err != nil {
+		return err
+	}
+
+	if err := s.users.SetActiveOrganization(ctx, userID, orgID); err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"userID":         userID,
+			"organizationID": orgID,
+		}).WithError(err).Error("Failed to switch active organization")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("User user_id=%d switched to organization_id=%d", userID, orgID)
+	return nil
+}
+
+// Authorize returns the caller's membership if its role satisfies required.
+// Non-members get ErrOrganizationNotFound so organization IDs can't be probed.
+func (s *organizationService) Authorize(ctx context.Context, userID, orgID int64, required models.OrganizationRole) (*models.OrganizationMember, error) {
+	member, err := s.repo.GetMember(ctx, orgID, userID)
+	if errors.Is(err, errs.ErrMemberNotFound) {
+		return nil, errs.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.Role.Allows(required) {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"userID":         userID,
+			"organizationID": orgID,
+			"role":           member.Role,
+			"required":       required,
+		}).Warn("Insufficient organization role")
+		return nil, errs.ErrForbidden
+	}
+
+	return member, nil
+}
+
+func (s *organizationService) GetMembers(ctx context.Context, actorID, orgID int64) ([]models.OrganizationMember, error) {
+	if _, err := s.Authorize(ctx, actorID, orgID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetMembers(ctx, orgID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organizationID", orgID).
+			WithError(err).
+			Error("Failed to fetch organization members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *organizationService) UpdateMemberRole(ctx context.Context, actorID, orgID, userID int64, role models.OrganizationRole) error {
+	if !role.IsValid() {
+		return errs.ErrInvalidRole
+	}
+
+	actor, err := s.Authorize(ctx, actorID, orgID, models.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !actor.Role.Allows(role) || !actor.Role.Allows(target.Role) {
+		return errs.ErrForbidden
+	}
+
+	if target.Role == models.RoleOwner && role != models.RoleOwner {
+		if err := s.ensureAnotherOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateMemberRole(ctx, orgID, userID, role); err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"organizationID": orgID,
+			"userID":         userID,
+		}).WithError(err).Error("Failed to update member role")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Member user_id=%d of organization_id=%d is now %s", userID, orgID, role)
+	return nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, actorID, orgID, userID int64) error {
+	required := models.RoleAdmin
+	if actorID == userID {
+		required = models.RoleViewer
+	}
+
+	actor, err := s.Authorize(ctx, actorID, orgID, required)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !actor.Role.Allows(target.Role) {
+		return errs.ErrForbidden
+	}
+
+	if target.Role == models.RoleOwner {
+		if err := s.ensureAnotherOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.RemoveMember(ctx, orgID, userID); err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"organizationID": orgID,
+			"userID":         userID,
+		}).WithError(err).Error("Failed to remove member")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Member user_id=%d removed from organization_id=%d", userID, orgID)
+	return nil
+}
+
+func (s *organizationService) InviteMember(ctx context.Context, actorID int64, invitation models.OrganizationInvitation) (int64, error) {
+	if !invitation.Role.IsValid() {
+		return 0, errs.ErrInvalidRole
+	}
+
+	actor, err := s.Authorize(ctx, actorID, invitation.OrganizationID, models.RoleAdmin)
+	if err != nil {
+		return 0, err
+	}
+
+	if !actor.Role.Allows(invitation.Role) {
+		return 0, errs.ErrForbidden
+	}
+
+	if invitation.Username != nil {
+		user, err := s.users.GetUserByUsername(ctx, *invitation.Username)
+		if err == nil {
+			if _, err := s.repo.GetMember(ctx, invitation.OrganizationID, user.ID); err == nil {
+				return 0, errs.ErrAlreadyMember
+			}
+		} else if !errors.Is(err, errs.ErrUserNotFound) {
+			return 0, err
+		}
+	}
+
+	invitation.InvitedBy = &actorID
+	id, err := s.repo.CreateInvitation(ctx, invitation)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organizationID", invitation.OrganizationID).
+			WithError(err).
+			Error("Failed to create invitation")
+		return 0, err
+	}
+
+	s.logger.WithContext(ctx).Infof("Invitation id=%d created for organization_id=%d", id, invitation.OrganizationID)
+	return id, nil
+}
+
+func (s *organizationService) GetUserInvitations(ctx context.Context, userID int64) ([]models.OrganizationInvitation, error) {
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.repo.GetPendingInvitations(ctx, user.Username, verifiedEmail(user))
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to fetch invitations")
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (s *organizationService) AcceptInvitation(ctx context.Context, userID, invitationID int64) error {
+	if _, err := s.addressedInvitation(ctx, userID, invitationID); err != nil {
+		return err
+	}
+
+	if err := s.repo.AcceptInvitation(ctx, invitationID, userID); err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"userID":       userID,
+			"invitationID": invitationID,
+		}).WithError(err).Error("Failed to accept invitation")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Invitation id=%d accepted by user_id=%d", invitationID, userID)
+	return nil
+}
+
+func (s *organizationService) DeclineInvitation(ctx context.Context, userID, invitationID int64) error {
+	if _, err := s.addressedInvitation(ctx, userID, invitationID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteInvitation(ctx, invitationID)
+}
+
+// addressedInvitation loads a pending invitation and checks that it was sent
+// to the given user, either by username or by verified email.
+func (s *organizationService) addressedInvitation(ctx context.Context, userID, invitationID int64) (*models.OrganizationInvitation, error) {
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := s.repo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv.AcceptedAt != nil {
+		return nil, errs.ErrInvitationNotFound
+	}
+
+	byUsername := inv.Username != nil && *inv.Username == user.Username
+	email := verifiedEmail(user)
+	byEmail := inv.Email != nil && email != "" && *inv.Email == email
+	if !byUsername && !byEmail {
+		return nil, errs.ErrInvitationNotFound
+	}
+
+	return inv, nil
+}
+
+// verifiedEmail returns the user's email once they proved they own it, so an
+// invitation by email can't be claimed by whoever registers the address.
+func verifiedEmail(user *models.User) string {
+	if user.EmailVerifiedAt == nil {
+		return ""
+	}
+	return user.Email
+}
+
+func (s *organizationService) ensureAnotherOwner(ctx context.Context, orgID int64) error {
+	owners, err := s.repo.CountOwners(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return errs.ErrLastOwner
+	}
+
+	return nil
+}
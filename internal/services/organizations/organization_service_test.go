@@ -0,0 +1,217 @@
+package organizations_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/organizations"
+)
+
+const (
+	orgID   = int64(5)
+	actorID = int64(1)
+	userID  = int64(2)
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockOrganizationRepository, *mocks.MockUserRepository, organizations.OrganizationService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockOrganizationRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := organizations.NewOrganizationService(mockRepo, mockUsers, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockUsers, svc
+}
+
+func member(userID int64, role models.OrganizationRole) *models.OrganizationMember {
+	return &models.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: role}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     models.OrganizationRole
+		memberOf bool
+		required models.OrganizationRole
+		err      error
+	}{
+		{name: "owner acts as admin", role: models.RoleOwner, memberOf: true, required: models.RoleAdmin},
+		{name: "editor acts as viewer", role: models.RoleEditor, memberOf: true, required: models.RoleViewer},
+		{name: "viewer acts as editor", role: models.RoleViewer, memberOf: true, required: models.RoleEditor, err: errs.ErrForbidden},
+		{name: "admin acts as owner", role: models.RoleAdmin, memberOf: true, required: models.RoleOwner, err: errs.ErrForbidden},
+		{name: "not a member", required: models.RoleViewer, err: errs.ErrOrganizationNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			if tt.memberOf {
+				mockRepo.EXPECT().GetMember(ctx, orgID, actorID).Return(member(actorID, tt.role), nil)
+			} else {
+				mockRepo.EXPECT().GetMember(ctx, orgID, actorID).Return(nil, errs.ErrMemberNotFound)
+			}
+
+			got, err := svc.Authorize(ctx, actorID, orgID, tt.required)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.role, got.Role)
+		})
+	}
+}
+
+func TestUpdateMemberRole_RoleAboveActor(t *testing.T) {
+	tests := []struct {
+		name   string
+		target models.OrganizationRole
+		role   models.OrganizationRole
+	}{
+		{name: "promote to owner", target: models.RoleEditor, role: models.RoleOwner},
+		{name: "demote an owner", target: models.RoleOwner, role: models.RoleViewer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().GetMember(ctx, orgID, actorID).Return(member(actorID, models.RoleAdmin), nil)
+			mockRepo.EXPECT().GetMember(ctx, orgID, userID).Return(member(userID, tt.target), nil)
+			mockRepo.EXPECT().UpdateMemberRole(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			err := svc.UpdateMemberRole(ctx, actorID, orgID, userID, tt.role)
+			assert.ErrorIs(t, err, errs.ErrForbidden)
+		})
+	}
+}
+
+func TestUpdateMemberRole_LastOwner(t *testing.T) {
+	tests := []struct {
+		name   string
+		owners int
+		err    error
+	}{
+		{name: "last owner", owners: 1, err: errs.ErrLastOwner},
+		{name: "another owner left", owners: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().GetMember(ctx, orgID, actorID).Return(member(actorID, models.RoleOwner), nil).Times(2)
+			mockRepo.EXPECT().CountOwners(ctx, orgID).Return(tt.owners, nil)
+			if tt.err == nil {
+				mockRepo.EXPECT().UpdateMemberRole(ctx, orgID, actorID, models.RoleAdmin).Return(nil)
+			}
+
+			err := svc.UpdateMemberRole(ctx, actorID, orgID, actorID, models.RoleAdmin)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRemoveMember_LastOwnerLeaves(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetMember(ctx, orgID, actorID).Return(member(actorID, models.RoleOwner), nil).Times(2)
+	mockRepo.EXPECT().CountOwners(ctx, orgID).Return(1, nil)
+	mockRepo.EXPECT().RemoveMember(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.RemoveMember(ctx, actorID, orgID, actorID)
+	assert.ErrorIs(t, err, errs.ErrLastOwner)
+}
+
+func TestRemoveMember_EditorRemovesOther(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetMember(ctx, orgID, actorID).Return(member(actorID, models.RoleEditor), nil)
+
+	err := svc.RemoveMember(ctx, actorID, orgID, userID)
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+}
+
+func TestAcceptInvitation_ByEmail(t *testing.T) {
+	verified := time.Now()
+
+	tests := []struct {
+		name       string
+		verifiedAt *time.Time
+		err        error
+	}{
+		{name: "verified email", verifiedAt: &verified},
+		{name: "unverified email", err: errs.ErrInvitationNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, mockUsers, svc := setup(t)
+			defer ctrl.Finish()
+
+			email := "bob@example.com"
+			mockUsers.EXPECT().GetUser(ctx, userID).Return(&models.User{
+				ID:              userID,
+				Username:        "bob",
+				Email:           email,
+				EmailVerifiedAt: tt.verifiedAt,
+			}, nil)
+			mockRepo.EXPECT().GetInvitation(ctx, int64(9)).Return(&models.OrganizationInvitation{
+				ID:             9,
+				OrganizationID: orgID,
+				Email:          &email,
+				Role:           models.RoleEditor,
+			}, nil)
+			if tt.err == nil {
+				mockRepo.EXPECT().AcceptInvitation(ctx, int64(9), userID).Return(nil)
+			}
+
+			err := svc.AcceptInvitation(ctx, userID, 9)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetUserInvitations_UnverifiedEmail(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUsers, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUsers.EXPECT().GetUser(ctx, userID).
+		Return(&models.User{ID: userID, Username: "bob", Email: "bob@example.com"}, nil)
+	mockRepo.EXPECT().GetPendingInvitations(ctx, "bob", "").Return(nil, nil)
+
+	_, err := svc.GetUserInvitations(ctx, userID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,15 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+)
+
+type ProfileService interface {
+	GetProfile(ctx context.Context, userID int64) (*models.User, error)
+	UpdateProfile(ctx context.Context, userID int64, req dto.UpdateProfileRequest) (*models.User, error)
+	ChangePassword(ctx context.Context, userID, sessionID int64, req dto.ChangePasswordRequest) error
+	DeleteAccount(ctx context.Context, userID int64, req dto.DeleteAccountRequest) error
+}
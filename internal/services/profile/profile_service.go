@@ -0,0 +1,145 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/account"
+	"github.com/mixdone/uptime-monitoring/internal/services/attempts"
+	"github.com/mixdone/uptime-monitoring/internal/services/session"
+	"github.com/mixdone/uptime-monitoring/internal/services/twofactor"
+	"github.com/mixdone/uptime-monitoring/internal/services/user"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+type profileService struct {
+	user      user.UserService
+	session   session.SessionService
+	twoFactor twofactor.TwoFactorService
+	account   account.AccountService
+	guard     attempts.LoginGuard
+	logger    logger.Logger
+}
+
+func NewProfileService(user user.UserService, session session.SessionService,
+	twoFactor twofactor.TwoFactorService, account account.AccountService, guard attempts.LoginGuard,
+	log logger.Logger) ProfileService {
+	return &profileService{
+		user:      user,
+		session:   session,
+		twoFactor: twoFactor,
+		account:   account,
+		guard:     guard,
+		logger:    log.WithField("component", "profileService"),
+	}
+}
+
+func (s *profileService) GetProfile(ctx context.Context, userID int64) (*models.User, error) {
+	return s.user.GetByID(ctx, userID)
+}
+
+// UpdateProfile applies the fields present in req. A new email address has
+// to be verified again, so a verification email is sent for it.
+func (s *profileService) UpdateProfile(ctx context.Context, userID int64, req dto.UpdateProfileRequest) (*models.User, error) {
+	user, err := s.user.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	emailChanged := req.Email != nil && *req.Email != user.Email
+
+	if req.Username != nil {
+		user.Username = *req.Username
+	}
+	if req.Email != nil {
+		user.Email = *req.Email
+	}
+	if req.TelegramID != nil {
+		user.TelegramID = *req.TelegramID
+	}
+	if req.Timezone != nil {
+		user.Timezone = *req.Timezone
+	}
+	if req.NotificationPreferences != nil {
+		user.NotificationPreferences = *req.NotificationPreferences
+	}
+
+	if err := s.user.UpdateUser(ctx, *user); err != nil {
+		return nil, err
+	}
+
+	if emailChanged {
+		if err := s.account.SendVerificationEmail(ctx, userID); err != nil {
+			s.logger.WithContext(ctx).WithField("userID", userID).
+				WithError(err).
+				Warn("Verification email not sent")
+		}
+	}
+
+	return s.user.GetByID(ctx, userID)
+}
+
+// ChangePassword replaces the password and signs out every other session, so
+// the current device stays logged in. Wrong current passwords count as failed
+// logins, otherwise a stolen access token could be used to guess it.
+func (s *profileService) ChangePassword(ctx context.Context, userID, sessionID int64, req dto.ChangePasswordRequest) error {
+	user, err := s.user.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.guard.Check(ctx, user.Username, req.Client.IP); err != nil {
+		return err
+	}
+
+	if !s.user.VerifyPassword(user.PasswordHash, req.OldPassword) {
+		s.guard.RegisterFailure(ctx, user.Username, req.Client.IP)
+		return errs.ErrInvalidCredentials
+	}
+
+	s.guard.RegisterSuccess(ctx, user.Username, req.Client.IP)
+
+	if err := s.user.UpdatePassword(ctx, userID, req.NewPassword); err != nil {
+		return err
+	}
+
+	return s.session.DeleteOtherUserSessions(ctx, userID, sessionID)
+}
+
+// DeleteAccount asks for the password, and the second factor when it is
+// enabled, before removing the user.
+func (s *profileService) DeleteAccount(ctx context.Context, userID int64, req dto.DeleteAccountRequest) error {
+	user, err := s.user.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !s.user.VerifyPassword(user.PasswordHash, req.Password) {
+		return errs.ErrInvalidCredentials
+	}
+
+	enabled, err := s.twoFactor.IsEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if enabled {
+		// code may be either an authenticator code or a recovery code
+		if err := s.twoFactor.Verify(ctx, userID, req.Code, req.Code); err != nil {
+			return err
+		}
+	}
+
+	if err := s.user.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"event":   "account_deleted",
+		"user_id": userID,
+	}).Warn("Account deleted")
+
+	return nil
+}
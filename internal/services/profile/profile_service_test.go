@@ -0,0 +1,171 @@
+package profile_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/profile"
+)
+
+const (
+	userID    = int64(1)
+	sessionID = int64(7)
+	ip        = "203.0.113.5"
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockUserService, *mocks.MockSessionService, *mocks.MockTwoFactorService, *mocks.MockAccountService, *mocks.MockLoginGuard, profile.ProfileService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUser := mocks.NewMockUserService(ctrl)
+	mockSession := mocks.NewMockSessionService(ctrl)
+	mockTwoFactor := mocks.NewMockTwoFactorService(ctrl)
+	mockAccount := mocks.NewMockAccountService(ctrl)
+	mockGuard := mocks.NewMockLoginGuard(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	svc := profile.NewProfileService(mockUser, mockSession, mockTwoFactor, mockAccount, mockGuard, mockLogger)
+	return context.Background(), ctrl, mockUser, mockSession, mockTwoFactor, mockAccount, mockGuard, svc
+}
+
+func alice() *models.User {
+	return &models.User{ID: userID, Username: "alice", Email: "a@example.com", PasswordHash: "hash"}
+}
+
+func passwordRequest(old string) dto.ChangePasswordRequest {
+	return dto.ChangePasswordRequest{
+		OldPassword: old,
+		NewPassword: "new-password",
+		Client:      dto.ClientInfo{IP: ip},
+	}
+}
+
+func TestChangePassword_Success(t *testing.T) {
+	ctx, ctrl, mockUser, mockSession, _, _, mockGuard, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUser.EXPECT().GetByID(ctx, userID).Return(alice(), nil)
+	mockGuard.EXPECT().Check(ctx, "alice", ip).Return(nil)
+	mockUser.EXPECT().VerifyPassword("hash", "old-password").Return(true)
+	mockGuard.EXPECT().RegisterSuccess(ctx, "alice", ip).Return(nil)
+	mockUser.EXPECT().UpdatePassword(ctx, userID, "new-password").Return(nil)
+	mockSession.EXPECT().DeleteOtherUserSessions(ctx, userID, sessionID).Return(nil)
+
+	err := svc.ChangePassword(ctx, userID, sessionID, passwordRequest("old-password"))
+	assert.NoError(t, err)
+}
+
+func TestChangePassword_WrongPassword(t *testing.T) {
+	ctx, ctrl, mockUser, _, _, _, mockGuard, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUser.EXPECT().GetByID(ctx, userID).Return(alice(), nil)
+	mockGuard.EXPECT().Check(ctx, "alice", ip).Return(nil)
+	mockUser.EXPECT().VerifyPassword("hash", "wrong").Return(false)
+	mockGuard.EXPECT().RegisterFailure(ctx, "alice", ip).Return(nil)
+	mockUser.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.ChangePassword(ctx, userID, sessionID, passwordRequest("wrong"))
+	assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+}
+
+func TestChangePassword_Throttled(t *testing.T) {
+	ctx, ctrl, mockUser, _, _, _, mockGuard, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUser.EXPECT().GetByID(ctx, userID).Return(alice(), nil)
+	mockGuard.EXPECT().Check(ctx, "alice", ip).
+		Return(&errs.RetryAfterError{Err: errs.ErrTooManyAttempts, RetryAfter: time.Minute})
+	mockUser.EXPECT().VerifyPassword(gomock.Any(), gomock.Any()).Times(0)
+	mockUser.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.ChangePassword(ctx, userID, sessionID, passwordRequest("old-password"))
+	assert.ErrorIs(t, err, errs.ErrTooManyAttempts)
+}
+
+func TestUpdateProfile_EmailChanged(t *testing.T) {
+	ctx, ctrl, mockUser, _, _, mockAccount, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	email := "new@example.com"
+	updated := alice()
+	updated.Email = email
+
+	mockUser.EXPECT().GetByID(ctx, userID).Return(alice(), nil)
+	mockUser.EXPECT().UpdateUser(ctx, *updated).Return(nil)
+	mockAccount.EXPECT().SendVerificationEmail(ctx, userID).Return(nil)
+	mockUser.EXPECT().GetByID(ctx, userID).Return(updated, nil)
+
+	got, err := svc.UpdateProfile(ctx, userID, dto.UpdateProfileRequest{Email: &email})
+	assert.NoError(t, err)
+	assert.Equal(t, email, got.Email)
+}
+
+func TestUpdateProfile_SameEmail(t *testing.T) {
+	ctx, ctrl, mockUser, _, _, mockAccount, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	email := "a@example.com"
+	mockUser.EXPECT().GetByID(ctx, userID).Return(alice(), nil).Times(2)
+	mockUser.EXPECT().UpdateUser(ctx, *alice()).Return(nil)
+	mockAccount.EXPECT().SendVerificationEmail(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := svc.UpdateProfile(ctx, userID, dto.UpdateProfileRequest{Email: &email})
+	assert.NoError(t, err)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  bool
+		twoFactor bool
+		codeErr   error
+		err       error
+	}{
+		{name: "password only", password: true},
+		{name: "wrong password", err: errs.ErrInvalidCredentials},
+		{name: "valid second factor", password: true, twoFactor: true},
+		{name: "invalid second factor", password: true, twoFactor: true, codeErr: errs.ErrInvalidTwoFactorCode, err: errs.ErrInvalidTwoFactorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockUser, _, mockTwoFactor, _, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockUser.EXPECT().GetByID(ctx, userID).Return(alice(), nil)
+			mockUser.EXPECT().VerifyPassword("hash", "secret").Return(tt.password)
+			if tt.password {
+				mockTwoFactor.EXPECT().IsEnabled(ctx, userID).Return(tt.twoFactor, nil)
+			}
+			if tt.twoFactor {
+				mockTwoFactor.EXPECT().Verify(ctx, userID, "123456", "123456").Return(tt.codeErr)
+			}
+			if tt.err == nil {
+				mockUser.EXPECT().DeleteUser(ctx, userID).Return(nil)
+			}
+
+			err := svc.DeleteAccount(ctx, userID, dto.DeleteAccountRequest{Password: "secret", Code: "123456"})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
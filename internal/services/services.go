@@ -2,36 +2,132 @@ package services
 
 import (
 	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/database"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
 	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/account"
+	"github.com/mixdone/uptime-monitoring/internal/services/attempts"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
 	"github.com/mixdone/uptime-monitoring/internal/services/auth"
+	"github.com/mixdone/uptime-monitoring/internal/services/badge"
+	"github.com/mixdone/uptime-monitoring/internal/services/checks"
 	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/internal/services/escalation"
+	"github.com/mixdone/uptime-monitoring/internal/services/feeds"
+	"github.com/mixdone/uptime-monitoring/internal/services/health"
+	"github.com/mixdone/uptime-monitoring/internal/services/incidents"
+	"github.com/mixdone/uptime-monitoring/internal/services/maintenance"
 	"github.com/mixdone/uptime-monitoring/internal/services/monitors"
+	"github.com/mixdone/uptime-monitoring/internal/services/notify"
+	"github.com/mixdone/uptime-monitoring/internal/services/oidc"
+	"github.com/mixdone/uptime-monitoring/internal/services/organizations"
+	"github.com/mixdone/uptime-monitoring/internal/services/profile"
 	"github.com/mixdone/uptime-monitoring/internal/services/session"
+	"github.com/mixdone/uptime-monitoring/internal/services/statuspage"
 	"github.com/mixdone/uptime-monitoring/internal/services/token"
+	"github.com/mixdone/uptime-monitoring/internal/services/twofactor"
 	"github.com/mixdone/uptime-monitoring/internal/services/user"
 	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/mailer"
+	"github.com/mixdone/uptime-monitoring/pkg/message"
+	"github.com/mixdone/uptime-monitoring/pkg/sso"
 )
 
 type Services struct {
-	User    user.UserService
-	Token   token.TokenService
-	Session session.SessionService
-	Auth    auth.AuthenticationService
-	Monitor monitors.MonitorService
+	User         user.UserService
+	Token        token.TokenService
+	Session      session.SessionService
+	Auth         auth.AuthenticationService
+	LoginGuard   attempts.LoginGuard
+	Monitor      monitors.MonitorService
+	Organization organizations.OrganizationService
+	TwoFactor    twofactor.TwoFactorService
+	Account      account.AccountService
+	Profile      profile.ProfileService
+	Audit        audit.AuditService
+	Maintenance  maintenance.MaintenanceService
+	Incident     incidents.IncidentService
+	Check        checks.CheckService
+	Channel      notify.ChannelService
+	Escalation   escalation.EscalationService
+	StatusPage   statuspage.StatusPageService
+	Badge        badge.BadgeService
+	Feed         feeds.FeedService
+	Health       health.HealthService
+	// OIDC is nil when single sign-on is disabled
+	OIDC oidc.OIDCService
 }
 
-func NewServices(repositories *repository.Repository, cfg config.Config, log logger.Logger) *Services {
+func NewServices(repositories *repository.Repository, cfg config.Config, mail mailer.Mailer,
+	mq message.MQ, metrics *metrics.Metrics, log logger.Logger) (*Services, error) {
+	accessKeys, refreshKeys, err := token.LoadKeySets(cfg.Jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := audit.NewAuditService(repositories.Audit, cfg.Audit.Retention, log)
 	user := user.NewUserService(repositories.Users, log)
-	token := token.NewTokenService(cfg.Jwt.AccessSecret, cfg.Jwt.RefreshSecret, constants.AccessTokenTTL, constants.RefreshTokenTTL)
-	session := session.NewSessionService(repositories.Sessions, log)
-	auth := auth.NewAuthService(user, session, token, log)
-	monitor := monitors.NewMonitorService(repositories.Monitors, log)
+	token := token.NewTokenServiceWithKeys(accessKeys, refreshKeys, constants.AccessTokenTTL, constants.RefreshTokenTTL)
+	session := session.NewSessionService(repositories.Sessions, audit, log)
+	organization := organizations.NewOrganizationService(repositories.Organizations, repositories.Users, log)
+	guard := attempts.NewLoginGuard(repositories.LoginAttempts, cfg.Auth.LoginThrottle, log)
+	twoFactor := twofactor.NewTwoFactorService(repositories.TwoFactor, user, log)
+	account := account.NewAccountService(repositories.Users, repositories.UserTokens, session, mail, cfg.Server.PublicURL, log)
 
-	return &Services{
-		User:    user,
-		Token:   token,
-		Session: session,
-		Auth:    auth,
-		Monitor: monitor,
+	var oidcService oidc.OIDCService
+	if cfg.OIDC.Enabled {
+		client := sso.NewClient(sso.Config{
+			IssuerURL:    cfg.OIDC.IssuerURL,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			Scopes:       cfg.OIDC.Scopes,
+		})
+		oidcService = oidc.NewOIDCService(client, repositories.Identities, repositories.Users,
+			cfg.OIDC.AutoProvision, log)
 	}
+
+	auth := auth.NewAuthService(user, session, token, guard, twoFactor, account, oidcService, audit, log)
+	monitor := monitors.NewMonitorService(repositories.Monitors, audit, log)
+	profile := profile.NewProfileService(user, session, twoFactor, account, guard, log)
+	maintenance := maintenance.NewMaintenanceService(repositories.Maintenance, audit, log)
+	channel := notify.NewChannelService(repositories.Channels, log)
+	sender := notify.NewSender(mail, cfg.Telegram.BotToken, cfg.Telegram.APIURL, nil)
+	escalation := escalation.NewEscalationService(repositories.Escalation, repositories.Incidents,
+		repositories.Channels, monitor, sender, notify.NewLogNotifier(log), audit, cfg.Escalation.PollInterval, log)
+	incident := incidents.NewIncidentService(repositories.Checks, repositories.Incidents,
+		maintenance, escalation, log)
+	statusPage := statuspage.NewStatusPageService(repositories.StatusPages, repositories.Announcements,
+		repositories.Checks, repositories.Incidents, maintenance, audit, log)
+	badge := badge.NewBadgeService(repositories.Badges, repositories.Checks, monitor, maintenance, audit, log)
+	feed := feeds.NewFeedService(repositories.FeedTokens, repositories.Organizations, repositories.Monitors,
+		repositories.Incidents, repositories.StatusPages, repositories.Announcements, cfg.Server.PublicURL, log)
+	checks := checks.NewCheckService(monitor, incident, checks.NewHTTPChecker(nil), mq, metrics,
+		cfg.Checks.Workers, cfg.Checks.PollInterval, log)
+	health := health.NewHealthService(repositories.Health, checks, mq, database.SchemaVersion(),
+		cfg.Checks.PollInterval, log)
+
+	return &Services{
+		User:         user,
+		Token:        token,
+		Session:      session,
+		Auth:         auth,
+		LoginGuard:   guard,
+		Monitor:      monitor,
+		Organization: organization,
+		TwoFactor:    twoFactor,
+		Account:      account,
+		Profile:      profile,
+		OIDC:         oidcService,
+		Audit:        audit,
+		Maintenance:  maintenance,
+		Incident:     incident,
+		Check:        checks,
+		Channel:      channel,
+		Escalation:   escalation,
+		StatusPage:   statusPage,
+		Badge:        badge,
+		Feed:         feed,
+		Health:       health,
+	}, nil
 }
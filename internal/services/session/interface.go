@@ -2,16 +2,21 @@ package session
 
 import (
 	"context"
-	"time"
 
 	"github.com/mixdone/uptime-monitoring/internal/models"
 )
 
 type SessionService interface {
-	CreateSession(ctx context.Context, userID int64,
-		refreshToken, fingerprint string, expiresAt time.Time) (int64, error)
-	GetSession(ctx context.Context, userID int64,
+	CreateSession(ctx context.Context, session models.Session) (int64, error)
+	StoreRefreshToken(ctx context.Context, sessionID int64, refreshToken string) error
+	ResolveRefreshToken(ctx context.Context, userID int64,
 		refreshToken, fingerprint string) (*models.Session, error)
+	RotateRefreshToken(ctx context.Context, session models.Session,
+		oldToken, newToken string) error
+	CheckSession(ctx context.Context, userID, sessionID int64) error
+	GetUserSessions(ctx context.Context, userID int64) ([]models.Session, error)
+	DeleteUserSession(ctx context.Context, userID, sessionID int64) error
 	DeleteSession(ctx context.Context, sessionID int64) error
 	DeleteAllUserSessions(ctx context.Context, userID int64) error
+	DeleteOtherUserSessions(ctx context.Context, userID, currentSessionID int64) error
 }
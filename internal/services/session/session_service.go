@@ -2,92 +2,195 @@ package session
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
 	"github.com/mixdone/uptime-monitoring/pkg/logger"
 )
 
 type sessionService struct {
 	repo   repository.SessionRepository
+	audit  audit.AuditService
 	logger logger.Logger
 }
 
-func NewSessionService(repo repository.SessionRepository, log logger.Logger) SessionService {
+func NewSessionService(repo repository.SessionRepository, audit audit.AuditService, log logger.Logger) SessionService {
 	return &sessionService{
 		repo:   repo,
+		audit:  audit,
 		logger: log.WithField("component", "sessionService"),
 	}
 }
 
-func (s *sessionService) CreateSession(ctx context.Context, userID int64,
-	refreshToken, fingerprint string,
-	expiresAt time.Time) (int64, error) {
-	session := models.Session{
-		UserID:       userID,
-		RefreshToken: refreshToken,
-		Fingerprint:  fingerprint,
-		ExpiresAt:    expiresAt,
-	}
-
+func (s *sessionService) CreateSession(ctx context.Context, session models.Session) (int64, error) {
 	id, err := s.repo.CreateSession(ctx, session)
 
 	if err != nil {
-		s.logger.WithField("user_id", userID).
+		s.logger.WithContext(ctx).WithField("user_id", session.UserID).
 			WithError(err).
 			Error("Failed to create session")
 		return 0, err
 	}
 
-	s.logger.WithFields(map[string]any{
-		"user_id":     userID,
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"user_id":     session.UserID,
 		"session":     id,
-		"fingerprint": fingerprint,
+		"fingerprint": session.Fingerprint,
+		"ip":          session.IP,
 	}).Info("Session created successfully")
 
 	return id, nil
 }
 
-func (s *sessionService) GetSession(ctx context.Context, userID int64,
-	refreshToken, fingerprint string) (*models.Session, error) {
+func (s *sessionService) StoreRefreshToken(ctx context.Context, sessionID int64, refreshToken string) error {
+	if err := s.repo.AddRefreshToken(ctx, sessionID, hashToken(refreshToken)); err != nil {
+		s.logger.WithContext(ctx).WithField("session_id", sessionID).
+			WithError(err).
+			Error("Failed to store refresh token")
+		return err
+	}
 
-	session, err := s.repo.GetSession(ctx, userID, refreshToken, fingerprint)
+	return nil
+}
+
+// ResolveRefreshToken finds the session a refresh token belongs to. Presenting
+// a token that was already rotated means it leaked, so the whole family is
+// revoked and ErrTokenReused is returned.
+func (s *sessionService) ResolveRefreshToken(ctx context.Context, userID int64,
+	refreshToken, fingerprint string) (*models.Session, error) {
 
+	token, err := s.repo.GetRefreshToken(ctx, hashToken(refreshToken))
 	if errors.Is(err, errs.ErrSessionNotFound) {
-		s.logger.WithFields(map[string]any{
+		s.logger.WithContext(ctx).WithFields(map[string]any{
 			"user_id":     userID,
 			"fingerprint": fingerprint,
 		}).WithError(err).Info("Session not found")
 		return nil, err
 	} else if err != nil {
-		s.logger.WithFields(map[string]any{
+		s.logger.WithContext(ctx).WithField("user_id", userID).
+			WithError(err).
+			Error("Repository error")
+		return nil, err
+	}
+
+	session, err := s.repo.GetSession(ctx, token.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.UserID != userID || session.Fingerprint != fingerprint {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
 			"user_id":     userID,
+			"session":     session.ID,
 			"fingerprint": fingerprint,
-		}).WithError(err).Error("Repository error")
-		return nil, err
+		}).Warn("Refresh token presented for another user or device")
+		return nil, errs.ErrSessionNotFound
 	}
 
-	s.logger.WithFields(map[string]any{
-		"user_id":     userID,
-		"session":     session.ID,
-		"fingerprint": fingerprint,
-	}).Info("Session found successfully")
+	if token.RotatedAt != nil {
+		s.revokeFamily(ctx, *session, "rotated refresh token presented again")
+		return nil, errs.ErrTokenReused
+	}
 
 	return session, nil
 }
 
+func (s *sessionService) RotateRefreshToken(ctx context.Context, session models.Session,
+	oldToken, newToken string) error {
+
+	err := s.repo.RotateRefreshToken(ctx, session, hashToken(oldToken), hashToken(newToken))
+	if errors.Is(err, errs.ErrTokenReused) {
+		s.revokeFamily(ctx, session, "refresh token rotated concurrently")
+		return err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("session_id", session.ID).
+			WithError(err).
+			Error("Failed to rotate refresh token")
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"user_id": session.UserID,
+		"session": session.ID,
+	}).Debug("Refresh token rotated")
+
+	return nil
+}
+
+// CheckSession reports whether an access token's session is still alive.
+// Logout and family revocation delete the session row, so a missing or
+// expired session means the token must no longer be honoured.
+func (s *sessionService) CheckSession(ctx context.Context, userID, sessionID int64) error {
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		return err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("session_id", sessionID).
+			WithError(err).
+			Error("Failed to check session")
+		return err
+	}
+
+	if session.UserID != userID || time.Now().After(session.ExpiresAt) {
+		return errs.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (s *sessionService) GetUserSessions(ctx context.Context, userID int64) ([]models.Session, error) {
+	sessions, err := s.repo.GetAllUserSessions(ctx, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("user_id", userID).
+			WithError(err).
+			Error("Failed to fetch user sessions")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionService) DeleteUserSession(ctx context.Context, userID, sessionID int64) error {
+	err := s.repo.DeleteUserSession(ctx, userID, sessionID)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		return err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]any{
+			"user_id":    userID,
+			"session_id": sessionID,
+		}).WithError(err).Error("Failed to revoke session")
+		return err
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditSessionRevoke,
+		TargetType: models.AuditTargetSession,
+		TargetID:   &sessionID,
+		Details:    map[string]any{"user_id": userID},
+	})
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"user_id":    userID,
+		"session_id": sessionID,
+	}).Info("Session revoked")
+	return nil
+}
+
 func (s *sessionService) DeleteSession(ctx context.Context, sessionID int64) error {
 	err := s.repo.DeleteSession(ctx, sessionID)
 	if err != nil {
-		s.logger.WithField("session_id", sessionID).
+		s.logger.WithContext(ctx).WithField("session_id", sessionID).
 			WithError(err).
 			Error("Failed to delete session")
 		return err
 	}
-	s.logger.WithField("session_id", sessionID).
+	s.logger.WithContext(ctx).WithField("session_id", sessionID).
 		Info("Session deleted successfully")
 	return nil
 }
@@ -95,12 +198,70 @@ func (s *sessionService) DeleteSession(ctx context.Context, sessionID int64) err
 func (s *sessionService) DeleteAllUserSessions(ctx context.Context, userID int64) error {
 	err := s.repo.DeleteAllSessions(ctx, userID)
 	if err != nil {
-		s.logger.WithField("user_id", userID).
+		s.logger.WithContext(ctx).WithField("user_id", userID).
 			WithError(err).
 			Error("Failed to delete all sessions for user")
 		return err
 	}
-	s.logger.WithField("user_id", userID).
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditSessionRevokeAll,
+		TargetType: models.AuditTargetUser,
+		TargetID:   &userID,
+	})
+
+	s.logger.WithContext(ctx).WithField("user_id", userID).
 		Info("All sessions deleted for user")
 	return nil
 }
+
+func (s *sessionService) DeleteOtherUserSessions(ctx context.Context, userID, currentSessionID int64) error {
+	err := s.repo.DeleteOtherSessions(ctx, userID, currentSessionID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("user_id", userID).
+			WithError(err).
+			Error("Failed to delete other sessions for user")
+		return err
+	}
+	s.audit.Record(ctx, models.AuditEntry{
+		Action:     models.AuditSessionRevokeAll,
+		TargetType: models.AuditTargetUser,
+		TargetID:   &userID,
+		Details:    map[string]any{"kept_session_id": currentSessionID},
+	})
+
+	s.logger.WithContext(ctx).WithField("user_id", userID).
+		Info("Other sessions deleted for user")
+	return nil
+}
+
+// revokeFamily drops the session with every token of its family and records
+// the incident as a security event.
+func (s *sessionService) revokeFamily(ctx context.Context, session models.Session, reason string) {
+	log := s.logger.WithContext(ctx).WithFields(map[string]any{
+		"event":       "refresh_token_reuse",
+		"user_id":     session.UserID,
+		"session":     session.ID,
+		"fingerprint": session.Fingerprint,
+		"ip":          session.IP,
+	})
+
+	if err := s.repo.DeleteSession(ctx, session.ID); err != nil {
+		log.WithError(err).Error("Failed to revoke token family")
+		return
+	}
+
+	s.audit.Record(ctx, models.AuditEntry{
+		ActorID:    &session.UserID,
+		Action:     models.AuditTokenReuse,
+		TargetType: models.AuditTargetSession,
+		TargetID:   &session.ID,
+		Details:    map[string]any{"reason": reason},
+	})
+
+	log.Warnf("Security event: %s, token family revoked", reason)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
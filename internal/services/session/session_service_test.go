@@ -0,0 +1,195 @@
+package session_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/session"
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockSessionRepository, *mocks.MockAuditService, session.SessionService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockSessionRepository(ctrl)
+	mockAudit := mocks.NewMockAuditService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := session.NewSessionService(mockRepo, mockAudit, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockAudit, svc
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func activeSession() *models.Session {
+	return &models.Session{ID: 7, UserID: 1, Fingerprint: "fp", ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func TestResolveRefreshToken_Success(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetRefreshToken(ctx, hash("refresh")).
+		Return(&models.RefreshToken{ID: 3, SessionID: 7}, nil)
+	mockRepo.EXPECT().GetSession(ctx, int64(7)).Return(activeSession(), nil)
+	mockRepo.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+
+	got, err := svc.ResolveRefreshToken(ctx, 1, "refresh", "fp")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), got.ID)
+}
+
+func TestResolveRefreshToken_ReusedToken(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	rotated := time.Now().Add(-time.Minute)
+	mockRepo.EXPECT().GetRefreshToken(ctx, hash("old")).
+		Return(&models.RefreshToken{ID: 3, SessionID: 7, RotatedAt: &rotated}, nil)
+	mockRepo.EXPECT().GetSession(ctx, int64(7)).Return(activeSession(), nil)
+	mockRepo.EXPECT().DeleteSession(ctx, int64(7)).Return(nil)
+	mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(_ context.Context, entry models.AuditEntry) {
+		assert.Equal(t, models.AuditTokenReuse, entry.Action)
+		assert.Equal(t, int64(7), *entry.TargetID)
+	})
+
+	_, err := svc.ResolveRefreshToken(ctx, 1, "old", "fp")
+	assert.ErrorIs(t, err, errs.ErrTokenReused)
+}
+
+func TestResolveRefreshToken_AnotherDevice(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetRefreshToken(ctx, hash("refresh")).
+		Return(&models.RefreshToken{ID: 3, SessionID: 7}, nil)
+	mockRepo.EXPECT().GetSession(ctx, int64(7)).Return(activeSession(), nil)
+	mockRepo.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := svc.ResolveRefreshToken(ctx, 1, "refresh", "other")
+	assert.ErrorIs(t, err, errs.ErrSessionNotFound)
+}
+
+func TestResolveRefreshToken_UnknownToken(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetRefreshToken(ctx, hash("unknown")).Return(nil, errs.ErrSessionNotFound)
+
+	_, err := svc.ResolveRefreshToken(ctx, 1, "unknown", "fp")
+	assert.ErrorIs(t, err, errs.ErrSessionNotFound)
+}
+
+func TestRotateRefreshToken_Success(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	session := activeSession()
+	mockRepo.EXPECT().RotateRefreshToken(ctx, *session, hash("old"), hash("new")).Return(nil)
+	mockRepo.EXPECT().DeleteSession(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.RotateRefreshToken(ctx, *session, "old", "new")
+	assert.NoError(t, err)
+}
+
+func TestRotateRefreshToken_ConcurrentRotation(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	session := activeSession()
+	mockRepo.EXPECT().RotateRefreshToken(ctx, *session, hash("old"), hash("new")).
+		Return(errs.ErrTokenReused)
+	mockRepo.EXPECT().DeleteSession(ctx, session.ID).Return(nil)
+	mockAudit.EXPECT().Record(ctx, gomock.Any())
+
+	err := svc.RotateRefreshToken(ctx, *session, "old", "new")
+	assert.ErrorIs(t, err, errs.ErrTokenReused)
+}
+
+func TestRotateRefreshToken_RevokeFails(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	session := activeSession()
+	mockRepo.EXPECT().RotateRefreshToken(ctx, *session, hash("old"), hash("new")).
+		Return(errs.ErrTokenReused)
+	mockRepo.EXPECT().DeleteSession(ctx, session.ID).Return(errors.New("db down"))
+	mockAudit.EXPECT().Record(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.RotateRefreshToken(ctx, *session, "old", "new")
+	assert.ErrorIs(t, err, errs.ErrTokenReused)
+}
+
+func TestCheckSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *models.Session
+		repoErr error
+		err     error
+	}{
+		{
+			name:    "active session",
+			session: &models.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)},
+		},
+		{
+			name:    "revoked session",
+			repoErr: errs.ErrSessionNotFound,
+			err:     errs.ErrSessionNotFound,
+		},
+		{
+			name:    "expired session",
+			session: &models.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+			err:     errs.ErrSessionNotFound,
+		},
+		{
+			name:    "session of another user",
+			session: &models.Session{ID: 7, UserID: 2, ExpiresAt: time.Now().Add(time.Hour)},
+			err:     errs.ErrSessionNotFound,
+		},
+		{
+			name:    "repository error",
+			repoErr: errors.New("db down"),
+			err:     errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().GetSession(ctx, int64(7)).Return(tt.session, tt.repoErr)
+
+			err := svc.CheckSession(ctx, 1, 7)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+)
+
+var (
+	incidentStatuses = []models.AnnouncementStatus{
+		models.AnnouncementInvestigating,
+		models.AnnouncementIdentified,
+		models.AnnouncementMonitoring,
+		models.AnnouncementResolved,
+	}
+	maintenanceStatuses = []models.AnnouncementStatus{
+		models.AnnouncementScheduled,
+		models.AnnouncementInProgress,
+		models.AnnouncementCompleted,
+	}
+)
+
+func (s *statusPageService) CreateAnnouncement(ctx context.Context, orgID int64,
+	announcement models.Announcement) (int64, error) {
+
+	page, err := s.GetPage(ctx, orgID, announcement.StatusPageID)
+	if err != nil {
+		return 0, err
+	}
+
+	announcement, err = normalizeAnnouncement(announcement)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.announcements.CreateAnnouncement(ctx, announcement)
+	if err != nil {
+		if !errors.Is(err, errs.ErrStatusComponentNotFound) {
+			s.logger.WithContext(ctx).WithField("status_page_id", page.ID).
+				WithError(err).
+				Error("Failed to create announcement")
+		}
+		return 0, err
+	}
+	s.forget(page.Slug)
+
+	announcement.ID = id
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditAnnouncementCreate,
+		TargetType:     models.AuditTargetAnnouncement,
+		TargetID:       &id,
+		Changes:        audit.Diff(nil, announcement, "created_at", "updates"),
+	})
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"status_page_id":  page.ID,
+		"announcement_id": id,
+	}).Info("Announcement created")
+
+	return id, nil
+}
+
+func (s *statusPageService) GetAnnouncements(ctx context.Context, orgID, pageID int64) ([]models.Announcement, error) {
+	if _, err := s.GetPage(ctx, orgID, pageID); err != nil {
+		return nil, err
+	}
+
+	announcements, err := s.announcements.GetPageAnnouncements(ctx, pageID, time.Time{})
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("status_page_id", pageID).
+			WithError(err).
+			Error("Failed to fetch announcements")
+		return nil, err
+	}
+
+	return announcements, nil
+}
+
+func (s *statusPageService) AddAnnouncementUpdate(ctx context.Context, orgID, pageID int64,
+	update models.AnnouncementUpdate) (int64, error) {
+
+	page, before, err := s.getAnnouncement(ctx, orgID, pageID, update.AnnouncementID)
+	if err != nil {
+		return 0, err
+	}
+
+	update.Message = strings.TrimSpace(update.Message)
+	if update.Message == "" {
+		return 0, fmt.Errorf("%w: message is required", errs.ErrInvalidAnnouncement)
+	}
+	if !validStatus(before.Kind, update.Status) {
+		return 0, fmt.Errorf("%w: %q is not a status of %s announcements",
+			errs.ErrInvalidAnnouncement, update.Status, before.Kind)
+	}
+
+	id, err := s.announcements.AddUpdate(ctx, update)
+	if err != nil {
+		if !errors.Is(err, errs.ErrAnnouncementClosed) {
+			s.logger.WithContext(ctx).WithField("announcement_id", update.AnnouncementID).
+				WithError(err).
+				Error("Failed to post announcement update")
+		}
+		return 0, err
+	}
+	s.forget(page.Slug)
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditAnnouncementUpdate,
+		TargetType:     models.AuditTargetAnnouncement,
+		TargetID:       &update.AnnouncementID,
+		Changes: map[string]models.AuditChange{
+			"status": {Old: before.Status, New: update.Status},
+		},
+		Details: map[string]any{"message": update.Message},
+	})
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"announcement_id": update.AnnouncementID,
+		"status":          update.Status,
+	}).Info("Announcement updated")
+
+	return id, nil
+}
+
+func (s *statusPageService) DeleteAnnouncement(ctx context.Context, orgID, pageID, id int64) error {
+	page, before, err := s.getAnnouncement(ctx, orgID, pageID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.announcements.DeleteAnnouncement(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrAnnouncementNotFound) {
+			s.logger.WithContext(ctx).WithField("announcement_id", id).
+				WithError(err).
+				Error("Failed to delete announcement")
+		}
+		return err
+	}
+	s.forget(page.Slug)
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditAnnouncementDelete,
+		TargetType:     models.AuditTargetAnnouncement,
+		TargetID:       &id,
+		Changes:        audit.Diff(before, nil, "updates"),
+	})
+
+	s.logger.WithContext(ctx).WithField("announcement_id", id).Info("Announcement deleted")
+	return nil
+}
+
+// getAnnouncement loads an announcement of a page the organization owns.
+func (s *statusPageService) getAnnouncement(ctx context.Context, orgID, pageID,
+	id int64) (*models.StatusPage, *models.Announcement, error) {
+
+	page, err := s.GetPage(ctx, orgID, pageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	announcement, err := s.announcements.GetAnnouncement(ctx, id)
+	if errors.Is(err, errs.ErrAnnouncementNotFound) {
+		return nil, nil, err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("announcement_id", id).
+			WithError(err).
+			Error("Failed to fetch announcement")
+		return nil, nil, err
+	}
+
+	if announcement.StatusPageID != page.ID {
+		return nil, nil, errs.ErrAnnouncementNotFound
+	}
+
+	return page, announcement, nil
+}
+
+// normalizeAnnouncement checks a new announcement and its first update.
+// Incidents default to a degraded impact; maintenance always has the
+// maintenance impact and needs a schedule.
+func normalizeAnnouncement(announcement models.Announcement) (models.Announcement, error) {
+	announcement.Title = strings.TrimSpace(announcement.Title)
+	if announcement.Title == "" {
+		return announcement, fmt.Errorf("%w: title is required", errs.ErrInvalidAnnouncement)
+	}
+
+	if len(announcement.Updates) != 1 {
+		return announcement, fmt.Errorf("%w: a new announcement needs exactly one update", errs.ErrInvalidAnnouncement)
+	}
+	first := announcement.Updates[0]
+	first.Message = strings.TrimSpace(first.Message)
+	if first.Message == "" {
+		return announcement, fmt.Errorf("%w: message is required", errs.ErrInvalidAnnouncement)
+	}
+
+	switch announcement.Kind {
+	case models.AnnouncementIncident:
+		if first.Status == "" {
+			first.Status = models.AnnouncementInvestigating
+		}
+		switch announcement.Impact {
+		case "":
+			announcement.Impact = models.StatusDegraded
+		case models.StatusDegraded, models.StatusOutage:
+		default:
+			return announcement, fmt.Errorf("%w: impact must be degraded or outage", errs.ErrInvalidAnnouncement)
+		}
+		announcement.ScheduledFor = nil
+		announcement.ScheduledUntil = nil
+
+	case models.AnnouncementMaintenance:
+		if first.Status == "" {
+			first.Status = models.AnnouncementScheduled
+		}
+		announcement.Impact = models.StatusMaintenance
+		if announcement.ScheduledFor == nil || announcement.ScheduledUntil == nil {
+			return announcement, fmt.Errorf("%w: maintenance needs scheduled_for and scheduled_until",
+				errs.ErrInvalidAnnouncement)
+		}
+		if !announcement.ScheduledUntil.After(*announcement.ScheduledFor) {
+			return announcement, fmt.Errorf("%w: scheduled_until must be after scheduled_for",
+				errs.ErrInvalidAnnouncement)
+		}
+
+	default:
+		return announcement, fmt.Errorf("%w: kind must be incident or maintenance", errs.ErrInvalidAnnouncement)
+	}
+
+	if !validStatus(announcement.Kind, first.Status) {
+		return announcement, fmt.Errorf("%w: %q is not a status of %s announcements",
+			errs.ErrInvalidAnnouncement, first.Status, announcement.Kind)
+	}
+
+	announcement.Status = first.Status
+	announcement.Updates = []models.AnnouncementUpdate{first}
+
+	return announcement, nil
+}
+
+func validStatus(kind models.AnnouncementKind, status models.AnnouncementStatus) bool {
+	if kind == models.AnnouncementMaintenance {
+		return slices.Contains(maintenanceStatuses, status)
+	}
+	return slices.Contains(incidentStatuses, status)
+}
@@ -0,0 +1,151 @@
+package statuspage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+func ownedPage(ctx context.Context, mockRepo *mocks.MockStatusPageRepository) {
+	mockRepo.EXPECT().GetPage(ctx, int64(1)).
+		Return(&models.StatusPage{ID: 1, OrganizationID: 2, Slug: "acme"}, nil)
+}
+
+func TestCreateAnnouncement_Invalid(t *testing.T) {
+	start := now.Add(time.Hour)
+	end := start.Add(time.Hour)
+	update := []models.AnnouncementUpdate{{Message: "Looking into it"}}
+
+	tests := []struct {
+		name         string
+		announcement models.Announcement
+	}{
+		{"unknown kind", models.Announcement{Kind: "outage", Title: "t", Updates: update}},
+		{"blank title", models.Announcement{Kind: models.AnnouncementIncident, Title: " ", Updates: update}},
+		{"blank message", models.Announcement{Kind: models.AnnouncementIncident, Title: "t",
+			Updates: []models.AnnouncementUpdate{{Message: " "}}}},
+		{"maintenance status on incident", models.Announcement{Kind: models.AnnouncementIncident, Title: "t",
+			Updates: []models.AnnouncementUpdate{{Status: models.AnnouncementScheduled, Message: "m"}}}},
+		{"operational impact", models.Announcement{Kind: models.AnnouncementIncident, Title: "t",
+			Impact: models.StatusOperational, Updates: update}},
+		{"maintenance without schedule", models.Announcement{Kind: models.AnnouncementMaintenance, Title: "t",
+			Updates: update}},
+		{"maintenance ending before it starts", models.Announcement{Kind: models.AnnouncementMaintenance, Title: "t",
+			ScheduledFor: &end, ScheduledUntil: &start, Updates: update}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, _, _, _, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			ownedPage(ctx, mockRepo)
+			tt.announcement.StatusPageID = 1
+
+			_, err := svc.CreateAnnouncement(ctx, 2, tt.announcement)
+
+			assert.ErrorIs(t, err, errs.ErrInvalidAnnouncement)
+		})
+	}
+}
+
+func TestCreateAnnouncement_Defaults(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAnnouncements, _, _, _, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	ownedPage(ctx, mockRepo)
+
+	mockAnnouncements.EXPECT().CreateAnnouncement(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, announcement models.Announcement) (int64, error) {
+			assert.Equal(t, models.AnnouncementInvestigating, announcement.Status)
+			assert.Equal(t, models.StatusDegraded, announcement.Impact)
+			require.Len(t, announcement.Updates, 1)
+			assert.Equal(t, models.AnnouncementInvestigating, announcement.Updates[0].Status)
+			assert.Equal(t, "Looking into it", announcement.Updates[0].Message)
+			return 5, nil
+		})
+	mockAudit.EXPECT().Record(ctx, gomock.Any())
+
+	id, err := svc.CreateAnnouncement(ctx, 2, models.Announcement{
+		StatusPageID: 1,
+		Kind:         models.AnnouncementIncident,
+		Title:        "Slow API",
+		Updates:      []models.AnnouncementUpdate{{Message: " Looking into it "}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+}
+
+func TestCreateAnnouncement_OtherOrganization(t *testing.T) {
+	ctx, ctrl, mockRepo, _, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	ownedPage(ctx, mockRepo)
+
+	_, err := svc.CreateAnnouncement(ctx, 3, models.Announcement{StatusPageID: 1})
+
+	assert.ErrorIs(t, err, errs.ErrStatusPageNotFound)
+}
+
+func TestAddAnnouncementUpdate_WrongKind(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAnnouncements, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	ownedPage(ctx, mockRepo)
+	mockAnnouncements.EXPECT().GetAnnouncement(ctx, int64(5)).Return(&models.Announcement{
+		ID: 5, StatusPageID: 1, Kind: models.AnnouncementIncident, Status: models.AnnouncementInvestigating,
+	}, nil)
+
+	_, err := svc.AddAnnouncementUpdate(ctx, 2, 1, models.AnnouncementUpdate{
+		AnnouncementID: 5,
+		Status:         models.AnnouncementCompleted,
+		Message:        "Done",
+	})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidAnnouncement)
+}
+
+func TestAddAnnouncementUpdate_OtherPage(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAnnouncements, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	ownedPage(ctx, mockRepo)
+	mockAnnouncements.EXPECT().GetAnnouncement(ctx, int64(5)).
+		Return(&models.Announcement{ID: 5, StatusPageID: 9, Kind: models.AnnouncementIncident}, nil)
+
+	_, err := svc.AddAnnouncementUpdate(ctx, 2, 1, models.AnnouncementUpdate{
+		AnnouncementID: 5,
+		Status:         models.AnnouncementResolved,
+		Message:        "Fixed",
+	})
+
+	assert.ErrorIs(t, err, errs.ErrAnnouncementNotFound)
+}
+
+func TestAddAnnouncementUpdate_Closed(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAnnouncements, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	ownedPage(ctx, mockRepo)
+	mockAnnouncements.EXPECT().GetAnnouncement(ctx, int64(5)).Return(&models.Announcement{
+		ID: 5, StatusPageID: 1, Kind: models.AnnouncementIncident, Status: models.AnnouncementResolved,
+	}, nil)
+	mockAnnouncements.EXPECT().AddUpdate(ctx, gomock.Any()).Return(int64(0), errs.ErrAnnouncementClosed)
+
+	_, err := svc.AddAnnouncementUpdate(ctx, 2, 1, models.AnnouncementUpdate{
+		AnnouncementID: 5,
+		Status:         models.AnnouncementMonitoring,
+		Message:        "Still watching",
+	})
+
+	assert.ErrorIs(t, err, errs.ErrAnnouncementClosed)
+}
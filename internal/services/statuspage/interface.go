@@ -0,0 +1,24 @@
+package statuspage
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+type StatusPageService interface {
+	CreatePage(ctx context.Context, page models.StatusPage) (int64, error)
+	GetPage(ctx context.Context, orgID, id int64) (*models.StatusPage, error)
+	GetOrganizationPages(ctx context.Context, orgID int64) ([]models.StatusPage, error)
+	UpdatePage(ctx context.Context, page models.StatusPage) error
+	DeletePage(ctx context.Context, orgID, id int64) error
+	// GetPublicPage returns what anonymous visitors of the page see.
+	GetPublicPage(ctx context.Context, slug string) (*models.PublicStatusPage, error)
+
+	// CreateAnnouncement posts an announcement with its first update, which
+	// must be the only entry of announcement.Updates.
+	CreateAnnouncement(ctx context.Context, orgID int64, announcement models.Announcement) (int64, error)
+	GetAnnouncements(ctx context.Context, orgID, pageID int64) ([]models.Announcement, error)
+	AddAnnouncementUpdate(ctx context.Context, orgID, pageID int64, update models.AnnouncementUpdate) (int64, error)
+	DeleteAnnouncement(ctx context.Context, orgID, pageID, id int64) error
+}
@@ -0,0 +1,258 @@
+package statuspage
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// UptimeDays is the length of the uptime history on public pages.
+const UptimeDays = 90
+
+var statusRank = map[models.ServiceStatus]int{
+	models.StatusOperational: 0,
+	models.StatusUnknown:     1,
+	models.StatusMaintenance: 2,
+	models.StatusDegraded:    3,
+	models.StatusOutage:      4,
+}
+
+// State is what is known about the monitors of a page at one moment.
+type State struct {
+	// Latest is the status of the most recent check by monitor ID
+	Latest map[int64]string
+	Open   []models.Incident
+	// Maintenance lists the windows in effect by monitor ID
+	Maintenance map[int64][]models.MaintenanceOccurrence
+	Uptime      map[int64][]models.DailyUptime
+	// Announcements are open or recently closed, newest first
+	Announcements []models.Announcement
+}
+
+// BuildPublicPage turns a page and the state of its monitors into the public
+// view. Only display names and derived statuses are copied, never anything
+// from the monitors themselves. Announcements in effect raise the status of
+// the components they name, or of the whole page if they name none.
+func BuildPublicPage(page models.StatusPage, state State, now time.Time) *models.PublicStatusPage {
+	open := make(map[int64]models.Incident, len(state.Open))
+	for _, incident := range state.Open {
+		open[incident.MonitorID] = incident
+	}
+
+	public := &models.PublicStatusPage{
+		Title:                page.Title,
+		Description:          page.Description,
+		Status:               models.StatusOperational,
+		UpdatedAt:            now,
+		Components:           make([]models.PublicComponent, 0, len(page.Components)),
+		Incidents:            []models.PublicIncident{},
+		Announcements:        []models.PublicAnnouncement{},
+		ScheduledMaintenance: []models.PublicAnnouncement{},
+	}
+
+	names := make(map[int64]string, len(page.Components))
+	for _, component := range page.Components {
+		names[component.ID] = component.Name
+	}
+
+	impact := make(map[int64]models.ServiceStatus)
+	for _, announcement := range state.Announcements {
+		publicAnnouncement := toPublicAnnouncement(announcement, names)
+		if upcoming(announcement, now) {
+			public.ScheduledMaintenance = append(public.ScheduledMaintenance, publicAnnouncement)
+			continue
+		}
+		public.Announcements = append(public.Announcements, publicAnnouncement)
+
+		if !inEffect(announcement, now) {
+			continue
+		}
+		if len(announcement.ComponentIDs) == 0 {
+			public.Status = worst(public.Status, announcement.Impact)
+		}
+		for _, id := range announcement.ComponentIDs {
+			impact[id] = worst(impact[id], announcement.Impact)
+		}
+	}
+
+	slices.SortStableFunc(public.ScheduledMaintenance, func(a, b models.PublicAnnouncement) int {
+		return a.ScheduledFor.Compare(*b.ScheduledFor)
+	})
+
+	for _, component := range page.Components {
+		publicComponent := models.PublicComponent{
+			ID:       component.ID,
+			Name:     component.Name,
+			Status:   models.StatusOperational,
+			Monitors: make([]models.PublicMonitor, 0, len(component.Monitors)),
+		}
+
+		for _, monitor := range component.Monitors {
+			status := monitorStatus(monitor.MonitorID, state, open)
+			uptime, days := uptimeHistory(state.Uptime[monitor.MonitorID], now)
+
+			publicComponent.Monitors = append(publicComponent.Monitors, models.PublicMonitor{
+				Name:   monitor.DisplayName,
+				Status: status,
+				Uptime: uptime,
+				Days:   days,
+			})
+			publicComponent.Status = worst(publicComponent.Status, status)
+
+			if incident, ok := open[monitor.MonitorID]; ok && !incident.Maintenance {
+				public.Incidents = append(public.Incidents, models.PublicIncident{
+					Component: component.Name,
+					Monitor:   monitor.DisplayName,
+					Status:    status,
+					StartedAt: incident.StartedAt,
+				})
+			}
+		}
+
+		if status, ok := impact[component.ID]; ok {
+			publicComponent.Status = worst(publicComponent.Status, status)
+		}
+
+		public.Status = worst(public.Status, publicComponent.Status)
+		public.Components = append(public.Components, publicComponent)
+	}
+
+	slices.SortStableFunc(public.Incidents, func(a, b models.PublicIncident) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+
+	return public
+}
+
+// inEffect reports whether an announcement currently affects its
+// components. Incidents do until they are resolved, maintenance from its
+// start, or from being marked in progress, until its scheduled end.
+func inEffect(announcement models.Announcement, now time.Time) bool {
+	if announcement.ClosedAt != nil {
+		return false
+	}
+	if announcement.Kind != models.AnnouncementMaintenance {
+		return true
+	}
+
+	started := announcement.Status == models.AnnouncementInProgress || !now.Before(*announcement.ScheduledFor)
+	return started && now.Before(*announcement.ScheduledUntil)
+}
+
+// upcoming reports whether an announcement is maintenance that hasn't begun.
+func upcoming(announcement models.Announcement, now time.Time) bool {
+	return announcement.Kind == models.AnnouncementMaintenance &&
+		announcement.Status == models.AnnouncementScheduled &&
+		now.Before(*announcement.ScheduledFor)
+}
+
+func toPublicAnnouncement(announcement models.Announcement, names map[int64]string) models.PublicAnnouncement {
+	public := models.PublicAnnouncement{
+		Kind:           announcement.Kind,
+		Title:          announcement.Title,
+		Status:         announcement.Status,
+		Impact:         announcement.Impact,
+		Components:     []string{},
+		ScheduledFor:   announcement.ScheduledFor,
+		ScheduledUntil: announcement.ScheduledUntil,
+		Updates:        make([]models.PublicAnnouncementUpdate, 0, len(announcement.Updates)),
+	}
+
+	for _, id := range announcement.ComponentIDs {
+		if name, ok := names[id]; ok {
+			public.Components = append(public.Components, name)
+		}
+	}
+	for _, update := range announcement.Updates {
+		public.Updates = append(public.Updates, models.PublicAnnouncementUpdate{
+			Status:    update.Status,
+			Message:   update.Message,
+			CreatedAt: update.CreatedAt,
+		})
+	}
+
+	return public
+}
+
+func monitorStatus(monitorID int64, state State, open map[int64]models.Incident) models.ServiceStatus {
+	if len(state.Maintenance[monitorID]) > 0 {
+		return models.StatusMaintenance
+	}
+
+	if incident, ok := open[monitorID]; ok && !incident.Maintenance {
+		if incident.Flapping {
+			return models.StatusDegraded
+		}
+		return models.StatusOutage
+	}
+
+	switch state.Latest[monitorID] {
+	case models.CheckUp:
+		return models.StatusOperational
+	case "":
+		return models.StatusUnknown
+	default:
+		return models.StatusOutage
+	}
+}
+
+// uptimeHistory returns the overall uptime and one entry per day of the
+// history, oldest first.
+func uptimeHistory(daily []models.DailyUptime, now time.Time) (*float64, []models.UptimeDay) {
+	byDay := make(map[string]models.DailyUptime, len(daily))
+	for _, day := range daily {
+		byDay[day.Day.Format(time.DateOnly)] = day
+	}
+
+	var checks, up int
+	days := make([]models.UptimeDay, UptimeDays)
+	start := historyStart(now)
+	for i := range days {
+		date := start.AddDate(0, 0, i).Format(time.DateOnly)
+		days[i].Date = date
+
+		if day, ok := byDay[date]; ok && day.Checks > 0 {
+			days[i].Uptime = percent(day.Up, day.Checks)
+			checks += day.Checks
+			up += day.Up
+		}
+	}
+
+	if checks == 0 {
+		return nil, days
+	}
+
+	return percent(up, checks), days
+}
+
+// historyStart is the beginning of the first UTC day of the uptime history.
+func historyStart(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(UptimeDays - 1))
+}
+
+func percent(part, total int) *float64 {
+	value := math.Round(float64(part)*10000/float64(total)) / 100
+	return &value
+}
+
+func worst(a, b models.ServiceStatus) models.ServiceStatus {
+	if statusRank[b] > statusRank[a] {
+		return b
+	}
+	return a
+}
+
+func monitorIDs(page models.StatusPage) []int64 {
+	var ids []int64
+	for _, component := range page.Components {
+		for _, monitor := range component.Monitors {
+			ids = append(ids, monitor.MonitorID)
+		}
+	}
+
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
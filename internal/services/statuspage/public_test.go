@@ -0,0 +1,158 @@
+package statuspage_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services/statuspage"
+)
+
+var now = time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+
+func page() models.StatusPage {
+	return models.StatusPage{
+		ID:    1,
+		Title: "Example status",
+		Components: []models.StatusComponent{
+			{ID: 10, Name: "API", Monitors: []models.StatusPageMonitor{
+				{MonitorID: 1, DisplayName: "Public API"},
+				{MonitorID: 2, DisplayName: "Webhooks"},
+			}},
+			{ID: 11, Name: "Website", Monitors: []models.StatusPageMonitor{
+				{MonitorID: 3, DisplayName: "Dashboard"},
+			}},
+		},
+	}
+}
+
+func TestBuildPublicPage_Statuses(t *testing.T) {
+	started := now.Add(-time.Hour)
+	state := statuspage.State{
+		Latest: map[int64]string{1: models.CheckUp, 2: models.CheckDown, 3: models.CheckDown},
+		Open: []models.Incident{
+			{ID: 5, MonitorID: 2, StartedAt: started, Cause: "dial tcp 10.0.0.7:443: connection refused"},
+		},
+		Maintenance: map[int64][]models.MaintenanceOccurrence{3: {{WindowID: 1}}},
+	}
+
+	public := statuspage.BuildPublicPage(page(), state, now)
+
+	assert.Equal(t, models.StatusOutage, public.Status)
+	require.Len(t, public.Components, 2)
+	assert.Equal(t, models.StatusOutage, public.Components[0].Status)
+	assert.Equal(t, models.StatusOperational, public.Components[0].Monitors[0].Status)
+	assert.Equal(t, models.StatusOutage, public.Components[0].Monitors[1].Status)
+	assert.Equal(t, models.StatusMaintenance, public.Components[1].Status)
+
+	require.Len(t, public.Incidents, 1)
+	assert.Equal(t, models.PublicIncident{
+		Component: "API",
+		Monitor:   "Webhooks",
+		Status:    models.StatusOutage,
+		StartedAt: started,
+	}, public.Incidents[0])
+
+	body, err := json.Marshal(public)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "10.0.0.7")
+}
+
+func TestBuildPublicPage_FlappingAndUnknown(t *testing.T) {
+	state := statuspage.State{
+		Latest: map[int64]string{1: models.CheckUp},
+		Open:   []models.Incident{{MonitorID: 1, Flapping: true}},
+	}
+
+	public := statuspage.BuildPublicPage(page(), state, now)
+
+	assert.Equal(t, models.StatusDegraded, public.Components[0].Monitors[0].Status)
+	assert.Equal(t, models.StatusUnknown, public.Components[0].Monitors[1].Status)
+	assert.Equal(t, models.StatusDegraded, public.Status)
+}
+
+func TestBuildPublicPage_Uptime(t *testing.T) {
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	state := statuspage.State{
+		Latest: map[int64]string{1: models.CheckUp},
+		Uptime: map[int64][]models.DailyUptime{1: {
+			{Day: today.AddDate(0, 0, -statuspage.UptimeDays), Checks: 10, Up: 0},
+			{Day: today.AddDate(0, 0, -1), Checks: 4, Up: 3},
+			{Day: today, Checks: 4, Up: 4},
+		}},
+	}
+
+	public := statuspage.BuildPublicPage(page(), state, now)
+	monitor := public.Components[0].Monitors[0]
+
+	require.Len(t, monitor.Days, statuspage.UptimeDays)
+	assert.Equal(t, "2026-07-22", monitor.Days[0].Date)
+	assert.Nil(t, monitor.Days[0].Uptime)
+
+	last := monitor.Days[statuspage.UptimeDays-1]
+	assert.Equal(t, "2026-10-19", last.Date)
+	require.NotNil(t, last.Uptime)
+	assert.Equal(t, 100.0, *last.Uptime)
+	require.NotNil(t, monitor.Days[statuspage.UptimeDays-2].Uptime)
+	assert.Equal(t, 75.0, *monitor.Days[statuspage.UptimeDays-2].Uptime)
+
+	// the day before the history doesn't count
+	require.NotNil(t, monitor.Uptime)
+	assert.Equal(t, 87.5, *monitor.Uptime)
+
+	assert.Nil(t, public.Components[0].Monitors[1].Uptime)
+}
+
+func TestBuildPublicPage_Announcements(t *testing.T) {
+	soon := now.Add(24 * time.Hour)
+	later := soon.Add(2 * time.Hour)
+	resolved := now.Add(-time.Hour)
+	state := statuspage.State{
+		Latest: map[int64]string{1: models.CheckUp, 2: models.CheckUp, 3: models.CheckUp},
+		Announcements: []models.Announcement{
+			{
+				Kind: models.AnnouncementIncident, Title: "Slow webhooks", Status: models.AnnouncementIdentified,
+				Impact: models.StatusDegraded, ComponentIDs: []int64{10},
+				Updates: []models.AnnouncementUpdate{
+					{Status: models.AnnouncementIdentified, Message: "A queue is backed up", CreatedAt: now},
+					{Status: models.AnnouncementInvestigating, Message: "Looking into it", CreatedAt: now.Add(-time.Hour)},
+				},
+			},
+			{
+				Kind: models.AnnouncementMaintenance, Title: "Database upgrade", Status: models.AnnouncementScheduled,
+				Impact: models.StatusMaintenance, ComponentIDs: []int64{11}, ScheduledFor: &soon, ScheduledUntil: &later,
+				Updates: []models.AnnouncementUpdate{{Status: models.AnnouncementScheduled, Message: "Expect downtime"}},
+			},
+			{
+				Kind: models.AnnouncementIncident, Title: "Login errors", Status: models.AnnouncementResolved,
+				Impact: models.StatusOutage, ComponentIDs: []int64{11}, ClosedAt: &resolved,
+			},
+		},
+	}
+
+	public := statuspage.BuildPublicPage(page(), state, now)
+
+	assert.Equal(t, models.StatusDegraded, public.Status)
+	assert.Equal(t, models.StatusDegraded, public.Components[0].Status)
+	assert.Equal(t, models.StatusOperational, public.Components[0].Monitors[0].Status)
+	assert.Equal(t, models.StatusOperational, public.Components[1].Status)
+
+	require.Len(t, public.Announcements, 2)
+	assert.Equal(t, "Slow webhooks", public.Announcements[0].Title)
+	assert.Equal(t, []string{"API"}, public.Announcements[0].Components)
+	require.Len(t, public.Announcements[0].Updates, 2)
+	assert.Equal(t, "A queue is backed up", public.Announcements[0].Updates[0].Message)
+	assert.Equal(t, "Login errors", public.Announcements[1].Title)
+
+	require.Len(t, public.ScheduledMaintenance, 1)
+	assert.Equal(t, []string{"Website"}, public.ScheduledMaintenance[0].Components)
+
+	public = statuspage.BuildPublicPage(page(), state, soon.Add(time.Minute))
+	assert.Equal(t, models.StatusMaintenance, public.Components[1].Status)
+	assert.Empty(t, public.ScheduledMaintenance)
+	assert.Len(t, public.Announcements, 3)
+}
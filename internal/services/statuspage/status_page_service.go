@@ -0,0 +1,317 @@
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/mixdone/uptime-monitoring/internal/services/maintenance"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+// publicCacheTTL bounds how often anonymous visitors make a page hit the
+// database.
+const publicCacheTTL = 30 * time.Second
+
+// announcementHistory is how long closed announcements stay on public pages.
+const announcementHistory = 7 * 24 * time.Hour
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,62}[a-z0-9])?$`)
+
+type statusPageService struct {
+	repo          repository.StatusPageRepository
+	announcements repository.AnnouncementRepository
+	checks        repository.CheckRepository
+	incidents     repository.IncidentRepository
+	maintenance   maintenance.MaintenanceService
+	audit         audit.AuditService
+	logger        logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedPage
+}
+
+type cachedPage struct {
+	page    *models.PublicStatusPage
+	expires time.Time
+}
+
+func NewStatusPageService(repo repository.StatusPageRepository, announcements repository.AnnouncementRepository,
+	checks repository.CheckRepository, incidents repository.IncidentRepository, maintenance maintenance.MaintenanceService,
+	audit audit.AuditService, log logger.Logger) StatusPageService {
+
+	return &statusPageService{
+		repo:          repo,
+		announcements: announcements,
+		checks:        checks,
+		incidents:     incidents,
+		maintenance:   maintenance,
+		audit:         audit,
+		logger:        log.WithField("component", "statusPageService"),
+		cache:         make(map[string]cachedPage),
+	}
+}
+
+func (s *statusPageService) CreatePage(ctx context.Context, page models.StatusPage) (int64, error) {
+	page, err := normalize(page)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.CreatePage(ctx, page)
+	if err != nil {
+		if !isClientError(err) {
+			s.logger.WithContext(ctx).WithField("organization_id", page.OrganizationID).
+				WithError(err).
+				Error("Failed to create status page")
+		}
+		return 0, err
+	}
+
+	page.ID = id
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &page.OrganizationID,
+		Action:         models.AuditStatusPageCreate,
+		TargetType:     models.AuditTargetStatusPage,
+		TargetID:       &id,
+		Changes:        audit.Diff(nil, page, "created_at"),
+	})
+
+	s.logger.WithContext(ctx).WithFields(map[string]any{
+		"organization_id": page.OrganizationID,
+		"status_page_id":  id,
+	}).Info("Status page created")
+
+	return id, nil
+}
+
+func (s *statusPageService) GetPage(ctx context.Context, orgID, id int64) (*models.StatusPage, error) {
+	page, err := s.repo.GetPage(ctx, id)
+	if errors.Is(err, errs.ErrStatusPageNotFound) {
+		return nil, err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("status_page_id", id).
+			WithError(err).
+			Error("Failed to fetch status page")
+		return nil, err
+	}
+
+	if page.OrganizationID != orgID {
+		return nil, errs.ErrStatusPageNotFound
+	}
+
+	return page, nil
+}
+
+func (s *statusPageService) GetOrganizationPages(ctx context.Context, orgID int64) ([]models.StatusPage, error) {
+	pages, err := s.repo.GetOrganizationPages(ctx, orgID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("organization_id", orgID).
+			WithError(err).
+			Error("Failed to fetch status pages")
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+func (s *statusPageService) UpdatePage(ctx context.Context, page models.StatusPage) error {
+	before, err := s.GetPage(ctx, page.OrganizationID, page.ID)
+	if err != nil {
+		return err
+	}
+
+	page, err = normalize(page)
+	if err != nil {
+		return err
+	}
+	page.CreatedAt = before.CreatedAt
+
+	if err := s.repo.UpdatePage(ctx, page); err != nil {
+		if !isClientError(err) && !errors.Is(err, errs.ErrStatusPageNotFound) {
+			s.logger.WithContext(ctx).WithField("status_page_id", page.ID).
+				WithError(err).
+				Error("Failed to update status page")
+		}
+		return err
+	}
+	s.forget(before.Slug)
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &page.OrganizationID,
+		Action:         models.AuditStatusPageUpdate,
+		TargetType:     models.AuditTargetStatusPage,
+		TargetID:       &page.ID,
+		Changes:        audit.Diff(before, page),
+	})
+
+	s.logger.WithContext(ctx).WithField("status_page_id", page.ID).Info("Status page updated")
+	return nil
+}
+
+func (s *statusPageService) DeletePage(ctx context.Context, orgID, id int64) error {
+	before, err := s.GetPage(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePage(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrStatusPageNotFound) {
+			s.logger.WithContext(ctx).WithField("status_page_id", id).
+				WithError(err).
+				Error("Failed to delete status page")
+		}
+		return err
+	}
+	s.forget(before.Slug)
+
+	s.audit.Record(ctx, models.AuditEntry{
+		OrganizationID: &orgID,
+		Action:         models.AuditStatusPageDelete,
+		TargetType:     models.AuditTargetStatusPage,
+		TargetID:       &id,
+		Changes:        audit.Diff(before, nil),
+	})
+
+	s.logger.WithContext(ctx).WithField("status_page_id", id).Info("Status page deleted")
+	return nil
+}
+
+func (s *statusPageService) GetPublicPage(ctx context.Context, slug string) (*models.PublicStatusPage, error) {
+	ctx, span := tracing.Start(ctx, "statusPageService.GetPublicPage")
+	defer span.End()
+
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.cache[slug]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.page, nil
+	}
+
+	page, err := s.repo.GetPageBySlug(ctx, slug)
+	if errors.Is(err, errs.ErrStatusPageNotFound) {
+		return nil, err
+	} else if err != nil {
+		s.logger.WithContext(ctx).WithField("slug", slug).
+			WithError(err).
+			Error("Failed to fetch status page")
+		return nil, err
+	}
+
+	state, err := s.loadState(ctx, page, now)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("status_page_id", page.ID).
+			WithError(err).
+			Error("Failed to fetch status page state")
+		return nil, err
+	}
+
+	public := BuildPublicPage(*page, state, now)
+
+	s.mu.Lock()
+	s.cache[slug] = cachedPage{page: public, expires: now.Add(publicCacheTTL)}
+	s.mu.Unlock()
+
+	return public, nil
+}
+
+func (s *statusPageService) loadState(ctx context.Context, page *models.StatusPage, now time.Time) (State, error) {
+	var state State
+	var err error
+	if state.Announcements, err = s.announcements.GetPageAnnouncements(ctx, page.ID,
+		now.Add(-announcementHistory)); err != nil {
+		return state, err
+	}
+
+	ids := monitorIDs(*page)
+	if len(ids) == 0 {
+		return state, nil
+	}
+
+	if state.Latest, err = s.checks.GetLatestStatuses(ctx, ids); err != nil {
+		return state, err
+	}
+	if state.Open, err = s.incidents.GetOpenIncidents(ctx, ids); err != nil {
+		return state, err
+	}
+	if state.Maintenance, err = s.maintenance.OrganizationMaintenance(ctx, page.OrganizationID, now); err != nil {
+		return state, err
+	}
+	if state.Uptime, err = s.checks.GetDailyUptime(ctx, ids, historyStart(now)); err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
+func (s *statusPageService) forget(slug string) {
+	s.mu.Lock()
+	delete(s.cache, slug)
+	s.mu.Unlock()
+}
+
+// normalize trims names and rejects slugs that don't fit in a URL as well
+// as components or monitors listed twice.
+func normalize(page models.StatusPage) (models.StatusPage, error) {
+	page.Slug = strings.ToLower(strings.TrimSpace(page.Slug))
+	if !slugPattern.MatchString(page.Slug) {
+		return page, fmt.Errorf("%w: slug may only contain lowercase letters, digits and inner dashes",
+			errs.ErrInvalidStatusPage)
+	}
+
+	page.Title = strings.TrimSpace(page.Title)
+	if page.Title == "" {
+		return page, fmt.Errorf("%w: title is required", errs.ErrInvalidStatusPage)
+	}
+
+	components := make([]models.StatusComponent, len(page.Components))
+	componentIDs := make(map[int64]bool)
+	for i, component := range page.Components {
+		component.Name = strings.TrimSpace(component.Name)
+		if component.Name == "" {
+			return page, fmt.Errorf("%w: component %d has no name", errs.ErrInvalidStatusPage, i+1)
+		}
+		if component.ID != 0 {
+			if componentIDs[component.ID] {
+				return page, fmt.Errorf("%w: component %d is listed twice", errs.ErrInvalidStatusPage, component.ID)
+			}
+			componentIDs[component.ID] = true
+		}
+
+		monitors := make([]models.StatusPageMonitor, len(component.Monitors))
+		monitorIDs := make(map[int64]bool)
+		for j, monitor := range component.Monitors {
+			if monitorIDs[monitor.MonitorID] {
+				return page, fmt.Errorf("%w: monitor %d is listed twice in %q",
+					errs.ErrInvalidStatusPage, monitor.MonitorID, component.Name)
+			}
+			monitorIDs[monitor.MonitorID] = true
+
+			monitor.DisplayName = strings.TrimSpace(monitor.DisplayName)
+			monitors[j] = monitor
+		}
+		component.Monitors = monitors
+
+		components[i] = component
+	}
+	page.Components = components
+
+	return page, nil
+}
+
+func isClientError(err error) bool {
+	return errors.Is(err, errs.ErrSlugTaken) ||
+		errors.Is(err, errs.ErrMonitorNotFound) ||
+		errors.Is(err, errs.ErrStatusComponentNotFound)
+}
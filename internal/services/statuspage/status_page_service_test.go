@@ -0,0 +1,121 @@
+package statuspage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/statuspage"
+)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockStatusPageRepository, *mocks.MockAnnouncementRepository, *mocks.MockCheckRepository, *mocks.MockIncidentRepository, *mocks.MockMaintenanceService, *mocks.MockAuditService, statuspage.StatusPageService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockStatusPageRepository(ctrl)
+	mockAnnouncements := mocks.NewMockAnnouncementRepository(ctrl)
+	mockChecks := mocks.NewMockCheckRepository(ctrl)
+	mockIncidents := mocks.NewMockIncidentRepository(ctrl)
+	mockMaintenance := mocks.NewMockMaintenanceService(ctrl)
+	mockAudit := mocks.NewMockAuditService(ctrl)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := statuspage.NewStatusPageService(mockRepo, mockAnnouncements, mockChecks, mockIncidents, mockMaintenance, mockAudit, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockAnnouncements, mockChecks, mockIncidents, mockMaintenance, mockAudit, svc
+}
+
+func TestCreatePage_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		page models.StatusPage
+	}{
+		{"slug with spaces", models.StatusPage{Slug: "my page", Title: "t"}},
+		{"slug with trailing dash", models.StatusPage{Slug: "status-", Title: "t"}},
+		{"blank title", models.StatusPage{Slug: "status", Title: "  "}},
+		{"unnamed component", models.StatusPage{Slug: "status", Title: "t",
+			Components: []models.StatusComponent{{Name: " "}}}},
+		{"monitor listed twice", models.StatusPage{Slug: "status", Title: "t",
+			Components: []models.StatusComponent{{Name: "API", Monitors: []models.StatusPageMonitor{
+				{MonitorID: 1}, {MonitorID: 1, DisplayName: "again"},
+			}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, _, _, _, _, _, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			_, err := svc.CreatePage(ctx, tt.page)
+
+			assert.ErrorIs(t, err, errs.ErrInvalidStatusPage)
+		})
+	}
+}
+
+func TestCreatePage_NormalizesSlug(t *testing.T) {
+	ctx, ctrl, mockRepo, _, _, _, _, mockAudit, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().CreatePage(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, page models.StatusPage) (int64, error) {
+			assert.Equal(t, "acme-status", page.Slug)
+			return 1, nil
+		})
+	mockAudit.EXPECT().Record(ctx, gomock.Any())
+
+	id, err := svc.CreatePage(ctx, models.StatusPage{OrganizationID: 1, Slug: " Acme-Status ", Title: "Acme"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
+
+func TestGetPage_OtherOrganization(t *testing.T) {
+	ctx, ctrl, mockRepo, _, _, _, _, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetPage(ctx, int64(1)).Return(&models.StatusPage{ID: 1, OrganizationID: 2}, nil)
+
+	_, err := svc.GetPage(ctx, 3, 1)
+
+	assert.ErrorIs(t, err, errs.ErrStatusPageNotFound)
+}
+
+func TestGetPublicPage_Cached(t *testing.T) {
+	ctx, ctrl, mockRepo, mockAnnouncements, mockChecks, mockIncidents, mockMaintenance, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetPageBySlug(ctx, "acme").Return(&models.StatusPage{
+		ID:             1,
+		OrganizationID: 2,
+		Title:          "Acme",
+		Components: []models.StatusComponent{{ID: 1, Name: "API", Monitors: []models.StatusPageMonitor{
+			{MonitorID: 7, DisplayName: "API"},
+		}}},
+	}, nil).Times(1)
+	mockAnnouncements.EXPECT().GetPageAnnouncements(ctx, int64(1), gomock.Any()).Return(nil, nil)
+	mockChecks.EXPECT().GetLatestStatuses(ctx, []int64{7}).Return(map[int64]string{7: models.CheckUp}, nil)
+	mockIncidents.EXPECT().GetOpenIncidents(ctx, []int64{7}).Return([]models.Incident{}, nil)
+	mockMaintenance.EXPECT().OrganizationMaintenance(ctx, int64(2), gomock.Any()).Return(nil, nil)
+	mockChecks.EXPECT().GetDailyUptime(ctx, []int64{7}, gomock.Any()).Return(nil, nil)
+
+	first, err := svc.GetPublicPage(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusOperational, first.Status)
+
+	second, err := svc.GetPublicPage(ctx, "acme")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
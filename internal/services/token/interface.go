@@ -1,7 +1,11 @@
 package token
 
 type TokenService interface {
-	Generate(userID int64) (accessToken, refreshToken string, err error)
+	Generate(userID, sessionID int64) (accessToken, refreshToken string, err error)
 	ValidateAccess(tokenStr string) (userID int64, err error)
+	ParseAccess(tokenStr string) (*Claims, error)
 	ValidateRefresh(tokenStr string) (userID int64, err error)
+	GenerateChallenge(userID int64) (string, error)
+	ValidateChallenge(tokenStr string) (userID int64, err error)
+	JWKS() JWKSet
 }
@@ -0,0 +1,253 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// Key is one signing or verification key. Retired keys have no private part
+// and only verify tokens issued before the rotation.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private is nil for verification-only keys
+	Private any
+	Public  any
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are
+// still accepted from, looked up by the kid header. Tokens without kid are
+// checked against the fallback key, which is how HS256 tokens keep working.
+type KeySet struct {
+	signing  *Key
+	byID     map[string]*Key
+	fallback *Key
+}
+
+func NewHMACKeySet(secret string) *KeySet {
+	key := &Key{
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}
+
+	return &KeySet{
+		signing:  key,
+		byID:     map[string]*Key{},
+		fallback: key,
+	}
+}
+
+// LoadKeySets builds the access and refresh key sets from the jwt config.
+// With an asymmetric algorithm both sets share the same keys, and configured
+// HMAC secrets are kept as fallback so tokens issued before the switch stay
+// valid until they expire.
+func LoadKeySets(cfg config.JWT) (access, refresh *KeySet, err error) {
+	if cfg.Algorithm == AlgHS256 {
+		return NewHMACKeySet(cfg.AccessSecret), NewHMACKeySet(cfg.RefreshSecret), nil
+	}
+
+	keys := make(map[string]*Key, len(cfg.Keys))
+	for _, kc := range cfg.Keys {
+		key, err := loadKey(kc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key %q: %w", kc.ID, err)
+		}
+		if _, ok := keys[key.ID]; ok {
+			return nil, nil, fmt.Errorf("duplicate jwt key id %q", key.ID)
+		}
+		keys[key.ID] = key
+	}
+
+	signing, ok := keys[cfg.SigningKeyID]
+	if !ok {
+		return nil, nil, fmt.Errorf("signing key %q not configured", cfg.SigningKeyID)
+	}
+	if signing.Private == nil {
+		return nil, nil, fmt.Errorf("signing key %q has no private key", cfg.SigningKeyID)
+	}
+	if signing.Method.Alg() != cfg.Algorithm {
+		return nil, nil, fmt.Errorf("signing key %q is %s, expected %s",
+			cfg.SigningKeyID, signing.Method.Alg(), cfg.Algorithm)
+	}
+
+	access = &KeySet{signing: signing, byID: keys}
+	refresh = &KeySet{signing: signing, byID: keys}
+
+	if cfg.AccessSecret != "" {
+		access.fallback = NewHMACKeySet(cfg.AccessSecret).fallback
+	}
+	if cfg.RefreshSecret != "" {
+		refresh.fallback = NewHMACKeySet(cfg.RefreshSecret).fallback
+	}
+
+	return access, refresh, nil
+}
+
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.Method, claims)
+	if s.signing.ID != "" {
+		token.Header["kid"] = s.signing.ID
+	}
+
+	return token.SignedString(s.signing.Private)
+}
+
+// keyFunc picks the verification key for a parsed token and makes sure the
+// token's alg matches the key, so a public key can't be used as HMAC secret.
+func (s *KeySet) keyFunc(token *jwt.Token) (any, error) {
+	key := s.fallback
+	if kid, ok := token.Header["kid"].(string); ok {
+		key = s.byID[kid]
+	}
+
+	if key == nil || token.Method.Alg() != key.Method.Alg() {
+		return nil, errs.ErrTokenWrongFormat
+	}
+
+	return key.Public, nil
+}
+
+// JWK is the public part of a key in RFC 7517 form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public keys tokens are verified with. HMAC keys are
+// secret and never published.
+func (s *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range s.byID {
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+
+	return set
+}
+
+func loadKey(cfg config.JWTKey) (*Key, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("id is required")
+	}
+
+	var private crypto.Signer
+	var public any
+
+	switch {
+	case cfg.PrivateKeyFile != "":
+		block, err := readPEM(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		private, err = parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+		public = private.Public()
+	case cfg.PublicKeyFile != "":
+		block, err := readPEM(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		public, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+	default:
+		return nil, errors.New("private_key_file or public_key_file is required")
+	}
+
+	key := &Key{ID: cfg.ID, Public: public}
+	if private != nil {
+		key.Private = private
+	}
+
+	switch public.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", public)
+	}
+
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", path)
+	}
+
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+
+	return signer, nil
+}
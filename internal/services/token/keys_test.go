@@ -0,0 +1,192 @@
+package token_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/internal/services/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, name string, key any) (privateFile, publicFile string) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	privateFile = filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	var pub any
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		pub = k.Public()
+	case ed25519.PrivateKey:
+		pub = k.Public()
+	}
+	der, err = x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	publicFile = filepath.Join(dir, name+".pub.pem")
+	require.NoError(t, os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	return privateFile, publicFile
+}
+
+func newService(t *testing.T, cfg config.JWT) token.TokenService {
+	t.Helper()
+
+	access, refresh, err := token.LoadKeySets(cfg)
+	require.NoError(t, err)
+
+	return token.NewTokenServiceWithKeys(access, refresh, constants.AccessTokenTTL, constants.RefreshTokenTTL)
+}
+
+func TestAsymmetric_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rsaFile, _ := writeKey(t, dir, "rsa", rsaKey)
+	edFile, _ := writeKey(t, dir, "ed", edKey)
+
+	tests := []struct {
+		name string
+		alg  string
+		kid  string
+		file string
+	}{
+		{name: "RS256", alg: token.AlgRS256, kid: "rsa", file: rsaFile},
+		{name: "EdDSA", alg: token.AlgEdDSA, kid: "ed", file: edFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newService(t, config.JWT{
+				Algorithm:    tt.alg,
+				SigningKeyID: tt.kid,
+				Keys:         []config.JWTKey{{ID: tt.kid, PrivateKeyFile: tt.file}},
+			})
+
+			aT, rT, err := srv.Generate(userID, sessionID)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(aT, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.kid, parsed.Header["kid"])
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+
+			id, err := srv.ValidateAccess(aT)
+			assert.NoError(t, err)
+			assert.Equal(t, userID, id)
+
+			id, err = srv.ValidateRefresh(rT)
+			assert.NoError(t, err)
+			assert.Equal(t, userID, id)
+
+			// both token kinds share the key, the typ claim keeps them apart
+			_, err = srv.ValidateAccess(rT)
+			assert.ErrorIs(t, err, errs.ErrTokenInvalid)
+			_, err = srv.ValidateRefresh(aT)
+			assert.ErrorIs(t, err, errs.ErrTokenInvalid)
+		})
+	}
+}
+
+func TestAsymmetric_Rotation(t *testing.T) {
+	dir := t.TempDir()
+
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	oldPrivate, oldPublic := writeKey(t, dir, "old", oldKey)
+	newPrivate, _ := writeKey(t, dir, "new", newKey)
+
+	before := newService(t, config.JWT{
+		Algorithm:    token.AlgEdDSA,
+		SigningKeyID: "old",
+		Keys:         []config.JWTKey{{ID: "old", PrivateKeyFile: oldPrivate}},
+	})
+	oldToken, _, err := before.Generate(userID, sessionID)
+	require.NoError(t, err)
+
+	after := newService(t, config.JWT{
+		Algorithm:    token.AlgEdDSA,
+		SigningKeyID: "new",
+		Keys: []config.JWTKey{
+			{ID: "new", PrivateKeyFile: newPrivate},
+			{ID: "old", PublicKeyFile: oldPublic},
+		},
+	})
+
+	id, err := after.ValidateAccess(oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, id)
+
+	jwks := after.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "new", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "old", jwks.Keys[1].Kid)
+
+	retired := newService(t, config.JWT{
+		Algorithm:    token.AlgEdDSA,
+		SigningKeyID: "new",
+		Keys:         []config.JWTKey{{ID: "new", PrivateKeyFile: newPrivate}},
+	})
+
+	_, err = retired.ValidateAccess(oldToken)
+	assert.ErrorIs(t, err, errs.ErrTokenInvalid)
+}
+
+func TestAsymmetric_HMACFallback(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaFile, rsaPublic := writeKey(t, dir, "rsa", rsaKey)
+
+	legacy := token.NewTokenService("my-very-secret-access-key",
+		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
+	legacyToken, _, err := legacy.Generate(userID, sessionID)
+	require.NoError(t, err)
+
+	srv := newService(t, config.JWT{
+		AccessSecret:  "my-very-secret-access-key",
+		RefreshSecret: "my-very-secret-refresh-key",
+		Algorithm:     token.AlgRS256,
+		SigningKeyID:  "rsa",
+		Keys:          []config.JWTKey{{ID: "rsa", PrivateKeyFile: rsaFile}},
+	})
+
+	id, err := srv.ValidateAccess(legacyToken)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, id)
+
+	// an HS256 token keyed with the published RSA key must not verify
+	pem, err := os.ReadFile(rsaPublic)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &token.Claims{UserID: userID, Type: token.TypeAccess})
+	forged.Header["kid"] = "rsa"
+	forgedStr, err := forged.SignedString(pem)
+	require.NoError(t, err)
+
+	_, err = srv.ValidateAccess(forgedStr)
+	assert.ErrorIs(t, err, errs.ErrTokenInvalid)
+
+	assert.Empty(t, legacy.JWKS().Keys)
+}
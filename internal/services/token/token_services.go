@@ -1,61 +1,89 @@
 package token
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+)
+
+const (
+	TypeAccess    = "access"
+	TypeRefresh   = "refresh"
+	TypeChallenge = "2fa_challenge"
 )
 
 type tokenService struct {
-	accessSecret  []byte
-	refreshSecret []byte
-	accessTTL     time.Duration
-	refreshTTL    time.Duration
+	accessKeys   *KeySet
+	refreshKeys  *KeySet
+	accessTTL    time.Duration
+	refreshTTL   time.Duration
+	challengeTTL time.Duration
 }
 
 type Claims struct {
-	UserID int64 `json:"user_id"`
+	UserID    int64  `json:"user_id"`
+	SessionID int64  `json:"sid,omitempty"`
+	Type      string `json:"typ,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// NewTokenService signs tokens with HS256 secrets.
 func NewTokenService(accessSecret, refreshSecret string, accessTTL, refreshTTL time.Duration) TokenService {
+	return NewTokenServiceWithKeys(NewHMACKeySet(accessSecret), NewHMACKeySet(refreshSecret), accessTTL, refreshTTL)
+}
+
+func NewTokenServiceWithKeys(accessKeys, refreshKeys *KeySet, accessTTL, refreshTTL time.Duration) TokenService {
 	return &tokenService{
-		accessSecret:  []byte(accessSecret),
-		refreshSecret: []byte(refreshSecret),
-		accessTTL:     accessTTL,
-		refreshTTL:    refreshTTL,
+		accessKeys:   accessKeys,
+		refreshKeys:  refreshKeys,
+		accessTTL:    accessTTL,
+		refreshTTL:   refreshTTL,
+		challengeTTL: constants.TwoFactorChallengeTTL,
 	}
 }
 
-func (t *tokenService) Generate(userID int64) (accessToken, refreshToken string, err error) {
+func (t *tokenService) Generate(userID, sessionID int64) (accessToken, refreshToken string, err error) {
 	now := time.Now()
 
 	accessClaims := &Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Type:      TypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(t.accessTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	accessTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessToken, err = accessTokenObj.SignedString(t.accessSecret)
+	accessToken, err = t.accessKeys.sign(accessClaims)
+	if err != nil {
+		return "", "", err
+	}
+
+	// refresh tokens are stored by hash, so two issued within the same
+	// second must still differ
+	jti, err := randomID()
 	if err != nil {
 		return "", "", err
 	}
 
 	refreshClaims := &Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Type:      TypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(now.Add(t.refreshTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	refreshTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshToken, err = refreshTokenObj.SignedString(t.refreshSecret)
+	refreshToken, err = t.refreshKeys.sign(refreshClaims)
 	if err != nil {
 		return "", "", err
 	}
@@ -64,28 +92,78 @@ func (t *tokenService) Generate(userID int64) (accessToken, refreshToken string,
 }
 
 func (t *tokenService) ValidateAccess(tokenStr string) (userID int64, err error) {
-	claims, err := t.parseToken(tokenStr, t.accessSecret)
+	claims, err := t.ParseAccess(tokenStr)
 	if err != nil {
 		return 0, err
 	}
 	return claims.UserID, nil
 }
 
+func (t *tokenService) ParseAccess(tokenStr string) (*Claims, error) {
+	claims, err := t.parseToken(tokenStr, t.accessKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// challenge tokens share the access keys, and with asymmetric keys so do
+	// refresh tokens, but neither may grant access
+	if claims.Type == TypeChallenge || claims.Type == TypeRefresh {
+		return nil, errs.ErrTokenInvalid
+	}
+
+	return claims, nil
+}
+
+// GenerateChallenge issues the short-lived token that proves the password
+// step of a two-factor login succeeded.
+func (t *tokenService) GenerateChallenge(userID int64) (string, error) {
+	now := time.Now()
+
+	claims := &Claims{
+		UserID: userID,
+		Type:   TypeChallenge,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(t.challengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return t.accessKeys.sign(claims)
+}
+
+func (t *tokenService) ValidateChallenge(tokenStr string) (userID int64, err error) {
+	claims, err := t.parseToken(tokenStr, t.accessKeys)
+	if err != nil {
+		return 0, err
+	}
+
+	if claims.Type != TypeChallenge {
+		return 0, errs.ErrTokenInvalid
+	}
+
+	return claims.UserID, nil
+}
+
 func (t *tokenService) ValidateRefresh(tokenStr string) (userID int64, err error) {
-	claims, err := t.parseToken(tokenStr, t.refreshSecret)
+	claims, err := t.parseToken(tokenStr, t.refreshKeys)
 	if err != nil {
 		return 0, err
 	}
+
+	if claims.Type == TypeAccess || claims.Type == TypeChallenge {
+		return 0, errs.ErrTokenInvalid
+	}
+
 	return claims.UserID, nil
 }
 
-func (t *tokenService) parseToken(tokenStr string, secret []byte) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errs.ErrTokenWrongFormat
-		}
-		return secret, nil
-	})
+// JWKS returns the public verification keys for /.well-known/jwks.json.
+func (t *tokenService) JWKS() JWKSet {
+	return t.accessKeys.JWKS()
+}
+
+func (t *tokenService) parseToken(tokenStr string, keys *KeySet) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, keys.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -101,3 +179,11 @@ func (t *tokenService) parseToken(tokenStr string, secret []byte) (*Claims, erro
 
 	return claims, nil
 }
+
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
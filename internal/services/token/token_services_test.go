@@ -3,18 +3,22 @@ package token_test
 import (
 	"testing"
 
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 	"github.com/mixdone/uptime-monitoring/internal/services/constants"
 	"github.com/mixdone/uptime-monitoring/internal/services/token"
 	"github.com/stretchr/testify/assert"
 )
 
-const userID = int64(123456789)
+const (
+	userID    = int64(123456789)
+	sessionID = int64(42)
+)
 
 func TestGenerate_NoErr(t *testing.T) {
 	srv := token.NewTokenService("my-very-secret-access-key",
 		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
 
-	_, _, err := srv.Generate(userID)
+	_, _, err := srv.Generate(userID, sessionID)
 
 	assert.NoError(t, err)
 }
@@ -23,7 +27,7 @@ func TestGenerateValidate_Access(t *testing.T) {
 	srv := token.NewTokenService("my-very-secret-access-key",
 		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
 
-	aT, _, err := srv.Generate(userID)
+	aT, _, err := srv.Generate(userID, sessionID)
 
 	assert.NoError(t, err)
 
@@ -37,7 +41,7 @@ func TestGenerateValidate_Refresh(t *testing.T) {
 	srv := token.NewTokenService("my-very-secret-access-key",
 		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
 
-	_, rT, err := srv.Generate(userID)
+	_, rT, err := srv.Generate(userID, sessionID)
 
 	assert.NoError(t, err)
 
@@ -46,3 +50,52 @@ func TestGenerateValidate_Refresh(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, userID, id)
 }
+
+func TestParseAccess_SessionID(t *testing.T) {
+	srv := token.NewTokenService("my-very-secret-access-key",
+		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
+
+	aT, _, err := srv.Generate(userID, sessionID)
+
+	assert.NoError(t, err)
+
+	claims, err := srv.ParseAccess(aT)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, sessionID, claims.SessionID)
+}
+
+func TestGenerate_RefreshTokensDiffer(t *testing.T) {
+	srv := token.NewTokenService("my-very-secret-access-key",
+		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
+
+	_, first, err := srv.Generate(userID, sessionID)
+	assert.NoError(t, err)
+
+	_, second, err := srv.Generate(userID, sessionID)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestChallenge_NotAccess(t *testing.T) {
+	srv := token.NewTokenService("my-very-secret-access-key",
+		"my-very-secret-refresh-key", constants.AccessTokenTTL, constants.RefreshTokenTTL)
+
+	challenge, err := srv.GenerateChallenge(userID)
+	assert.NoError(t, err)
+
+	id, err := srv.ValidateChallenge(challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, id)
+
+	_, err = srv.ValidateAccess(challenge)
+	assert.ErrorIs(t, err, errs.ErrTokenInvalid)
+
+	aT, _, err := srv.Generate(userID, sessionID)
+	assert.NoError(t, err)
+
+	_, err = srv.ValidateChallenge(aT)
+	assert.ErrorIs(t, err, errs.ErrTokenInvalid)
+}
@@ -0,0 +1,15 @@
+package twofactor
+
+import (
+	"context"
+
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+)
+
+type TwoFactorService interface {
+	Setup(ctx context.Context, userID int64) (*dto.TwoFactorSetupResponse, error)
+	Enable(ctx context.Context, userID int64, code string) ([]string, error)
+	Disable(ctx context.Context, userID int64, password, code string) error
+	IsEnabled(ctx context.Context, userID int64) (bool, error)
+	Verify(ctx context.Context, userID int64, code, recoveryCode string) error
+}
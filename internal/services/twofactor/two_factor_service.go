@@ -0,0 +1,200 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/repository"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/internal/services/user"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"github.com/mixdone/uptime-monitoring/pkg/totp"
+)
+
+// allowedSkew accepts codes from one step before and after the current one.
+const allowedSkew = 1
+
+// recoveryAlphabet has 32 characters, so every random byte maps without bias.
+// Easily confused l, o, 0 and 1 are left out.
+const recoveryAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+type twoFactorService struct {
+	repo   repository.TwoFactorRepository
+	user   user.UserService
+	logger logger.Logger
+}
+
+func NewTwoFactorService(repo repository.TwoFactorRepository, user user.UserService, log logger.Logger) TwoFactorService {
+	return &twoFactorService{
+		repo:   repo,
+		user:   user,
+		logger: log.WithField("component", "twoFactorService"),
+	}
+}
+
+// Setup starts enrollment with a fresh secret. Nothing changes for login
+// until the secret is confirmed through Enable.
+func (s *twoFactorService) Setup(ctx context.Context, userID int64) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.user.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetPendingSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Infof("Two-factor setup started for user_id=%d", userID)
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(constants.TwoFactorIssuer, user.Username, secret),
+	}, nil
+}
+
+// Enable confirms the pending secret with a code from the authenticator and
+// returns the recovery codes. They are shown once and stored only as hashes.
+func (s *twoFactorService) Enable(ctx context.Context, userID int64, code string) ([]string, error) {
+	tf, err := s.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tf.Enabled {
+		return nil, errs.ErrTwoFactorAlreadyEnabled
+	}
+	if tf.Secret == nil {
+		return nil, errs.ErrTwoFactorNotSetUp
+	}
+
+	step, ok := totp.Validate(*tf.Secret, code, time.Now(), allowedSkew)
+	if !ok {
+		return nil, errs.ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, 0, constants.RecoveryCodesCount)
+	hashes := make([]string, 0, constants.RecoveryCodesCount)
+	for i := 0; i < constants.RecoveryCodesCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	if err := s.repo.EnableTwoFactor(ctx, userID, step, hashes); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to enable two-factor authentication")
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Infof("Two-factor authentication enabled for user_id=%d", userID)
+	return codes, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userID int64, password, code string) error {
+	user, err := s.user.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !s.user.VerifyPassword(user.PasswordHash, password) {
+		return errs.ErrInvalidCredentials
+	}
+
+	// code may be either an authenticator code or a recovery code
+	if err := s.Verify(ctx, userID, code, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.DisableTwoFactor(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to disable two-factor authentication")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Two-factor authentication disabled for user_id=%d", userID)
+	return nil
+}
+
+func (s *twoFactorService) IsEnabled(ctx context.Context, userID int64) (bool, error) {
+	tf, err := s.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return tf.Enabled, nil
+}
+
+// Verify accepts either a current TOTP code or an unused recovery code. Each
+// TOTP step and each recovery code is accepted only once.
+func (s *twoFactorService) Verify(ctx context.Context, userID int64, code, recoveryCode string) error {
+	tf, err := s.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !tf.Enabled || tf.Secret == nil {
+		return errs.ErrTwoFactorNotEnabled
+	}
+
+	if code != "" {
+		if step, ok := totp.Validate(*tf.Secret, code, time.Now(), allowedSkew); ok {
+			fresh, err := s.repo.ConsumeStep(ctx, userID, step)
+			if err != nil {
+				return err
+			}
+			if fresh {
+				return nil
+			}
+			s.logger.WithContext(ctx).WithField("userID", userID).Warn("Two-factor code replayed")
+		}
+	}
+
+	if recoveryCode != "" {
+		used, err := s.repo.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(recoveryCode))
+		if err != nil {
+			return err
+		}
+		if used {
+			s.logger.WithContext(ctx).Infof("Recovery code used by user_id=%d", userID)
+			return nil
+		}
+	}
+
+	return errs.ErrInvalidTwoFactorCode
+}
+
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, len(b))
+	for i, v := range b {
+		code[i] = recoveryAlphabet[int(v)%len(recoveryAlphabet)]
+	}
+
+	return string(code[:5]) + "-" + string(code[5:]), nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
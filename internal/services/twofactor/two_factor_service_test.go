@@ -0,0 +1,229 @@
+package twofactor_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+	"github.com/mixdone/uptime-monitoring/internal/services/twofactor"
+	"github.com/mixdone/uptime-monitoring/pkg/totp"
+)
+
+const userID = int64(1)
+
+func setup(t *testing.T) (context.Context, *gomock.Controller, *mocks.MockTwoFactorRepository, *mocks.MockUserService, twofactor.TwoFactorService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockTwoFactorRepository(ctrl)
+	mockUser := mocks.NewMockUserService(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithContext(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithError(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	mockLogger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	svc := twofactor.NewTwoFactorService(mockRepo, mockUser, mockLogger)
+	return context.Background(), ctrl, mockRepo, mockUser, svc
+}
+
+func newSecret(t *testing.T) string {
+	t.Helper()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	return secret
+}
+
+func currentCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func recoveryHash(code string) string {
+	sum := sha256.Sum256([]byte(strings.ReplaceAll(code, "-", "")))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestSetup(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUser, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUser.EXPECT().GetByID(ctx, userID).Return(&models.User{ID: userID, Username: "alice"}, nil)
+	mockRepo.EXPECT().SetPendingSecret(ctx, userID, gomock.Any()).Return(nil)
+
+	resp, err := svc.Setup(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Secret)
+	assert.Contains(t, resp.OTPAuthURI, "alice")
+	assert.Contains(t, resp.OTPAuthURI, resp.Secret)
+}
+
+func TestEnable_Success(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	secret := newSecret(t)
+	mockRepo.EXPECT().GetTwoFactor(ctx, userID).Return(&models.TwoFactor{UserID: userID, Secret: &secret}, nil)
+
+	var stored []string
+	mockRepo.EXPECT().EnableTwoFactor(ctx, userID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ int64, hashes []string) error {
+			stored = hashes
+			return nil
+		})
+
+	codes, err := svc.Enable(ctx, userID, currentCode(t, secret))
+	require.NoError(t, err)
+	require.Len(t, codes, constants.RecoveryCodesCount)
+	require.Len(t, stored, constants.RecoveryCodesCount)
+
+	for i, code := range codes {
+		assert.Equal(t, recoveryHash(code), stored[i])
+		assert.NotContains(t, stored, code)
+	}
+}
+
+func TestEnable_Rejected(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+
+	tests := []struct {
+		name string
+		tf   *models.TwoFactor
+		code string
+		err  error
+	}{
+		{name: "not set up", tf: &models.TwoFactor{UserID: userID}, code: "123456", err: errs.ErrTwoFactorNotSetUp},
+		{name: "already enabled", tf: &models.TwoFactor{UserID: userID, Secret: &secret, Enabled: true}, code: "123456", err: errs.ErrTwoFactorAlreadyEnabled},
+		{name: "wrong code", tf: &models.TwoFactor{UserID: userID, Secret: &secret}, code: "abcdef", err: errs.ErrInvalidTwoFactorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().GetTwoFactor(ctx, userID).Return(tt.tf, nil)
+			mockRepo.EXPECT().EnableTwoFactor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := svc.Enable(ctx, userID, tt.code)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerify_TOTP(t *testing.T) {
+	tests := []struct {
+		name  string
+		fresh bool
+		err   error
+	}{
+		{name: "fresh code", fresh: true},
+		{name: "replayed code", fresh: false, err: errs.ErrInvalidTwoFactorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			secret := newSecret(t)
+			mockRepo.EXPECT().GetTwoFactor(ctx, userID).
+				Return(&models.TwoFactor{UserID: userID, Secret: &secret, Enabled: true}, nil)
+			mockRepo.EXPECT().ConsumeStep(ctx, userID, gomock.Any()).Return(tt.fresh, nil)
+
+			err := svc.Verify(ctx, userID, currentCode(t, secret), "")
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerify_RecoveryCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		unused bool
+		err    error
+	}{
+		{name: "unused code", unused: true},
+		{name: "used or unknown code", unused: false, err: errs.ErrInvalidTwoFactorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctrl, mockRepo, _, svc := setup(t)
+			defer ctrl.Finish()
+
+			secret := newSecret(t)
+			mockRepo.EXPECT().GetTwoFactor(ctx, userID).
+				Return(&models.TwoFactor{UserID: userID, Secret: &secret, Enabled: true}, nil)
+			mockRepo.EXPECT().ConsumeRecoveryCode(ctx, userID, recoveryHash("abcde-fghjk")).Return(tt.unused, nil)
+
+			err := svc.Verify(ctx, userID, "", "ABCDE-FGHJK")
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerify_NotEnabled(t *testing.T) {
+	ctx, ctrl, mockRepo, _, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().GetTwoFactor(ctx, userID).Return(&models.TwoFactor{UserID: userID}, nil)
+
+	err := svc.Verify(ctx, userID, "123456", "")
+	assert.ErrorIs(t, err, errs.ErrTwoFactorNotEnabled)
+}
+
+func TestDisable_WrongPassword(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUser, svc := setup(t)
+	defer ctrl.Finish()
+
+	mockUser.EXPECT().GetByID(ctx, userID).Return(&models.User{ID: userID, PasswordHash: "hash"}, nil)
+	mockUser.EXPECT().VerifyPassword("hash", "wrong").Return(false)
+	mockRepo.EXPECT().DisableTwoFactor(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.Disable(ctx, userID, "wrong", "123456")
+	assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+}
+
+func TestDisable_WithRecoveryCode(t *testing.T) {
+	ctx, ctrl, mockRepo, mockUser, svc := setup(t)
+	defer ctrl.Finish()
+
+	secret := newSecret(t)
+	mockUser.EXPECT().GetByID(ctx, userID).Return(&models.User{ID: userID, PasswordHash: "hash"}, nil)
+	mockUser.EXPECT().VerifyPassword("hash", "secret").Return(true)
+	mockRepo.EXPECT().GetTwoFactor(ctx, userID).
+		Return(&models.TwoFactor{UserID: userID, Secret: &secret, Enabled: true}, nil)
+	mockRepo.EXPECT().ConsumeRecoveryCode(ctx, userID, recoveryHash("abcde-fghjk")).Return(true, nil)
+	mockRepo.EXPECT().DisableTwoFactor(ctx, userID).Return(nil)
+
+	err := svc.Disable(ctx, userID, "secret", "abcde-fghjk")
+	assert.NoError(t, err)
+}
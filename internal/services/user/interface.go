@@ -12,5 +12,7 @@ type UserService interface {
 	GetByID(ctx context.Context, userID int64) (*models.User, error)
 	VerifyPassword(hashFromDB, inputPassword string) bool
 	RegisterUser(ctx context.Context, userDTO dto.RegisterRequest) (int64, error)
+	UpdateUser(ctx context.Context, user models.User) error
+	UpdatePassword(ctx context.Context, userID int64, password string) error
 	DeleteUser(ctx context.Context, userID int64) error
 }
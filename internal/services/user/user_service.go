@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/models/dto"
@@ -24,11 +25,11 @@ func NewUserService(repo repository.UserRepository, log logger.Logger) UserServi
 }
 
 func (s *userService) GetByUsername(ctx context.Context, username string) (*models.User, error) {
-	s.logger.Debugf("Fetching user by username: %s", username)
+	s.logger.WithContext(ctx).Debugf("Fetching user by username: %s", username)
 
 	user, err := s.repo.GetUserByUsername(ctx, username)
 	if err != nil {
-		s.logger.WithField("username", username).
+		s.logger.WithContext(ctx).WithField("username", username).
 			WithError(err).
 			Error("Failed to get user by username")
 		return nil, err
@@ -38,11 +39,11 @@ func (s *userService) GetByUsername(ctx context.Context, username string) (*mode
 }
 
 func (s *userService) GetByID(ctx context.Context, userID int64) (*models.User, error) {
-	s.logger.Debugf("Fetching user by ID: %d", userID)
+	s.logger.WithContext(ctx).Debugf("Fetching user by ID: %d", userID)
 
 	user, err := s.repo.GetUser(ctx, userID)
 	if err != nil {
-		s.logger.WithField("userID", userID).
+		s.logger.WithContext(ctx).WithField("userID", userID).
 			WithError(err).
 			Error("Failed to get user by ID")
 		return nil, err
@@ -52,22 +53,22 @@ func (s *userService) GetByID(ctx context.Context, userID int64) (*models.User,
 }
 
 func (s *userService) RegisterUser(ctx context.Context, userDTO dto.RegisterRequest) (int64, error) {
-	s.logger.Infof("Attempting to register user: %s", userDTO.Username)
+	s.logger.WithContext(ctx).Infof("Attempting to register user: username=%s", userDTO.Username)
 
 	_, err := s.repo.GetUserByUsername(ctx, userDTO.Username)
 	if err == nil {
-		s.logger.Warnf("Username already taken: %s", userDTO.Username)
+		s.logger.WithContext(ctx).Warnf("Registration rejected, username=%s is taken", userDTO.Username)
 		return 0, errs.ErrUsernameTaken
 	}
 
 	if err != errs.ErrUserNotFound {
-		s.logger.WithError(err).Error("Unexpected error while checking username")
+		s.logger.WithContext(ctx).WithError(err).Error("Unexpected error while checking username")
 		return 0, errs.ErrInternal
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(userDTO.Password), bcrypt.DefaultCost)
 	if err != nil {
-		s.logger.WithField("username", userDTO.Username).
+		s.logger.WithContext(ctx).WithField("username", userDTO.Username).
 			WithError(err).
 			Error("Failed to hash password")
 		return 0, errs.ErrHashingFailed
@@ -80,15 +81,16 @@ func (s *userService) RegisterUser(ctx context.Context, userDTO dto.RegisterRequ
 		PasswordHash: string(hash),
 	}
 
-	id, err := s.repo.CreateUser(ctx, user)
+	// every user starts in an organization of their own
+	id, err := s.repo.CreateUserWithOrganization(ctx, user, user.Username)
 	if err != nil {
-		s.logger.WithField("username", user.Username).
+		s.logger.WithContext(ctx).WithField("username", user.Username).
 			WithError(err).
 			Error("Failed to create user in DB")
 		return 0, err
 	}
 
-	s.logger.Infof("User registered successfully: %s (id=%d)", user.Username, id)
+	s.logger.WithContext(ctx).Infof("User registered successfully: id=%d", id)
 	return id, nil
 }
 
@@ -101,16 +103,53 @@ func (s *userService) VerifyPassword(hashFromDB, inputPassword string) bool {
 	return true
 }
 
+func (s *userService) UpdateUser(ctx context.Context, user models.User) error {
+	s.logger.WithContext(ctx).Infof("Updating user: id=%d", user.ID)
+
+	if _, err := time.LoadLocation(user.Timezone); err != nil {
+		return errs.ErrInvalidTimezone
+	}
+
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", user.ID).
+			WithError(err).
+			Error("Failed to update user")
+		return err
+	}
+
+	return nil
+}
+
+func (s *userService) UpdatePassword(ctx context.Context, userID int64, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to hash password")
+		return errs.ErrHashingFailed
+	}
+
+	if err := s.repo.UpdatePassword(ctx, userID, string(hash)); err != nil {
+		s.logger.WithContext(ctx).WithField("userID", userID).
+			WithError(err).
+			Error("Failed to update password")
+		return err
+	}
+
+	s.logger.WithContext(ctx).Infof("Password changed: id=%d", userID)
+	return nil
+}
+
 func (s *userService) DeleteUser(ctx context.Context, userID int64) error {
-	s.logger.Infof("Deleting user: id=%d", userID)
+	s.logger.WithContext(ctx).Infof("Deleting user: id=%d", userID)
 
 	if err := s.repo.DeleteUser(ctx, userID); err != nil {
-		s.logger.WithField("userID", userID).
+		s.logger.WithContext(ctx).WithField("userID", userID).
 			WithError(err).
 			Error("Failed to delete user")
 		return err
 	}
 
-	s.logger.Infof("User deleted successfully: id=%d", userID)
+	s.logger.WithContext(ctx).Infof("User deleted successfully: id=%d", userID)
 	return nil
 }
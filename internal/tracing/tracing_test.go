@@ -0,0 +1,63 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestNew_Disabled(t *testing.T) {
+	tr, err := tracing.New(context.Background(), config.Tracing{ServiceName: "uptime"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "uptime", tr.ServiceName())
+	assert.NoError(t, tr.Shutdown(context.Background()))
+}
+
+func TestStart_WithoutTrace(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx := context.Background()
+
+	got, span := tracing.Start(ctx, "monitorService.GetMonitor")
+	span.End()
+
+	assert.Equal(t, ctx, got)
+	assert.Empty(t, recorder.Ended())
+}
+
+func TestStart_InTrace(t *testing.T) {
+	recorder := recordSpans(t)
+
+	ctx, root := tracing.Tracer().Start(context.Background(), "GET /monitors/:id")
+	_, span := tracing.Start(ctx, "monitorService.GetMonitor", tracing.MonitorID(7))
+	tracing.End(span, assert.AnError)
+	root.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child := spans[0]
+	assert.Equal(t, "monitorService.GetMonitor", child.Name())
+	assert.Equal(t, root.SpanContext().SpanID(), child.Parent().SpanID())
+	assert.Contains(t, child.Attributes(), tracing.MonitorID(7))
+	assert.Equal(t, assert.AnError.Error(), child.Status().Description)
+}
@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Confirm email address
+// @Tags auth
+// @Accept json
+// @Param input body dto.VerifyEmailRequest true "token from the email"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Router /auth/email/verify [post]
+func (h *Handler) verifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.services.Account.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		h.respondAccountError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Resend verification email
+// @Security ApiKeyAuth
+// @Tags auth
+// @Success 202 "Accepted"
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /auth/email/resend [post]
+func (h *Handler) resendVerificationEmail(c *gin.Context) {
+	if err := h.services.Account.SendVerificationEmail(c.Request.Context(), c.GetInt64("userID")); err != nil {
+		h.respondAccountError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Request password reset
+// @Description Always answers 202, whether or not the email is registered
+// @Tags auth
+// @Accept json
+// @Param input body dto.ForgotPasswordRequest true "email"
+// @Success 202 "Accepted"
+// @Failure 400 {object} map[string]string
+// @Router /auth/password/forgot [post]
+func (h *Handler) forgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.services.Account.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		h.logger.WithError(err).Error("Password reset request failed")
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Reset password
+// @Tags auth
+// @Accept json
+// @Param input body dto.ResetPasswordRequest true "token and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Router /auth/password/reset [post]
+func (h *Handler) resetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.services.Account.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		h.respondAccountError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrUserTokenInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrEmailVerified):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrEmailMissing):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Account request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
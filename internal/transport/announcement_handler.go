@@ -0,0 +1,181 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Post an announcement on a status page
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param id path int true "Status page ID"
+// @Param input body dto.AnnouncementRequest true "announcement"
+// @Success 201 {object} dto.AnnouncementResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /status-pages/{id}/announcements [post]
+func (h *Handler) createAnnouncement(c *gin.Context) {
+	pageID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetInt64("userID")
+	announcement := models.Announcement{
+		StatusPageID:   pageID,
+		Kind:           models.AnnouncementKind(req.Kind),
+		Title:          req.Title,
+		Impact:         models.ServiceStatus(req.Impact),
+		ComponentIDs:   req.ComponentIDs,
+		ScheduledFor:   req.ScheduledFor,
+		ScheduledUntil: req.ScheduledUntil,
+		CreatedBy:      &userID,
+		Updates: []models.AnnouncementUpdate{{
+			Status:    models.AnnouncementStatus(req.Status),
+			Message:   req.Message,
+			CreatedBy: &userID,
+		}},
+	}
+
+	id, err := h.services.StatusPage.CreateAnnouncement(c.Request.Context(), c.GetInt64("organizationID"), announcement)
+	if err != nil {
+		h.respondAnnouncementError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.AnnouncementResponse{ID: id})
+}
+
+// @Summary Announcements of a status page
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Produce json
+// @Param id path int true "Status page ID"
+// @Success 200 {object} []models.Announcement
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /status-pages/{id}/announcements [get]
+func (h *Handler) getAnnouncements(c *gin.Context) {
+	pageID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	announcements, err := h.services.StatusPage.GetAnnouncements(c.Request.Context(),
+		c.GetInt64("organizationID"), pageID)
+	if err != nil {
+		h.respondAnnouncementError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, announcements)
+}
+
+// @Summary Post an update to an announcement
+// @Description Resolved and completed updates close the announcement.
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param id path int true "Status page ID"
+// @Param announcementID path int true "Announcement ID"
+// @Param input body dto.AnnouncementUpdateRequest true "update"
+// @Success 201 {object} dto.AnnouncementResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /status-pages/{id}/announcements/{announcementID}/updates [post]
+func (h *Handler) addAnnouncementUpdate(c *gin.Context) {
+	pageID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	announcementID, ok := parseIDParam(c, "announcementID")
+	if !ok {
+		return
+	}
+
+	var req dto.AnnouncementUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetInt64("userID")
+	update := models.AnnouncementUpdate{
+		AnnouncementID: announcementID,
+		Status:         models.AnnouncementStatus(req.Status),
+		Message:        req.Message,
+		CreatedBy:      &userID,
+	}
+
+	id, err := h.services.StatusPage.AddAnnouncementUpdate(c.Request.Context(),
+		c.GetInt64("organizationID"), pageID, update)
+	if err != nil {
+		h.respondAnnouncementError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.AnnouncementResponse{ID: id})
+}
+
+// @Summary Delete an announcement
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Param id path int true "Status page ID"
+// @Param announcementID path int true "Announcement ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /status-pages/{id}/announcements/{announcementID} [delete]
+func (h *Handler) deleteAnnouncement(c *gin.Context) {
+	pageID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	announcementID, ok := parseIDParam(c, "announcementID")
+	if !ok {
+		return
+	}
+
+	err := h.services.StatusPage.DeleteAnnouncement(c.Request.Context(),
+		c.GetInt64("organizationID"), pageID, announcementID)
+	if err != nil {
+		h.respondAnnouncementError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondAnnouncementError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidAnnouncement):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrStatusPageNotFound),
+		errors.Is(err, errs.ErrAnnouncementNotFound),
+		errors.Is(err, errs.ErrStatusComponentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrAnnouncementClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Announcement request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
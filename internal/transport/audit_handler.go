@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+)
+
+// @Summary Audit log of the active organization
+// @Security ApiKeyAuth
+// @Tags audit
+// @Produce json
+// @Param actor_id query int false "user who performed the action"
+// @Param action query string false "action, or prefix ending in .* such as monitor.*"
+// @Param target_type query string false "target type"
+// @Param target_id query int false "target ID"
+// @Param from query string false "RFC 3339 lower bound"
+// @Param to query string false "RFC 3339 upper bound"
+// @Param before_id query int false "return entries older than this ID"
+// @Param limit query int false "page size, up to 500"
+// @Success 200 {object} dto.AuditPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /audit [get]
+func (h *Handler) getOrganizationAuditLog(c *gin.Context) {
+	filter, ok := bindAuditQuery(c)
+	if !ok {
+		return
+	}
+
+	orgID := c.GetInt64("organizationID")
+	filter.OrganizationID = &orgID
+
+	h.respondAuditPage(c, filter)
+}
+
+// @Summary Account activity of the current user
+// @Security ApiKeyAuth
+// @Tags audit
+// @Produce json
+// @Param action query string false "action, or prefix ending in .* such as auth.*"
+// @Param from query string false "RFC 3339 lower bound"
+// @Param to query string false "RFC 3339 upper bound"
+// @Param before_id query int false "return entries older than this ID"
+// @Param limit query int false "page size, up to 500"
+// @Success 200 {object} dto.AuditPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /users/me/audit [get]
+func (h *Handler) getUserAuditLog(c *gin.Context) {
+	filter, ok := bindAuditQuery(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetInt64("userID")
+	filter.UserID = &userID
+	filter.ActorID = nil
+
+	h.respondAuditPage(c, filter)
+}
+
+func bindAuditQuery(c *gin.Context) (models.AuditFilter, bool) {
+	var query dto.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return models.AuditFilter{}, false
+	}
+
+	return models.AuditFilter{
+		ActorID:    query.ActorID,
+		Action:     query.Action,
+		TargetType: query.TargetType,
+		TargetID:   query.TargetID,
+		From:       query.From,
+		To:         query.To,
+		BeforeID:   query.BeforeID,
+		Limit:      query.Limit,
+	}, true
+}
+
+func (h *Handler) respondAuditPage(c *gin.Context, filter models.AuditFilter) {
+	entries, err := h.services.Audit.GetEntries(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch audit log"})
+		return
+	}
+
+	page := dto.AuditPage{Entries: entries}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1].ID
+		page.NextBeforeID = &last
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -1,10 +1,14 @@
 package transport
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 )
 
 // @Summary Register user
@@ -23,6 +27,8 @@ func (h *Handler) register(c *gin.Context) {
 		return
 	}
 
+	req.Client = clientInfo(c)
+
 	res, err := h.services.Auth.Register(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -39,6 +45,7 @@ func (h *Handler) register(c *gin.Context) {
 // @Success 200 {object} dto.AuthResult
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /auth/login [post]
 func (h *Handler) login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -48,10 +55,11 @@ func (h *Handler) login(c *gin.Context) {
 		return
 	}
 
-	res, err := h.services.Auth.Login(c.Request.Context(), req)
+	req.Client = clientInfo(c)
 
+	res, err := h.services.Auth.Login(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.respondLoginError(c, err)
 		return
 	}
 
@@ -117,6 +125,8 @@ func (h *Handler) refresh(c *gin.Context) {
 		return
 	}
 
+	req.Client = clientInfo(c)
+
 	authResult, err := h.services.Auth.RefreshTokens(c.Request.Context(), userID, req)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
@@ -125,3 +135,27 @@ func (h *Handler) refresh(c *gin.Context) {
 
 	c.JSON(http.StatusOK, authResult)
 }
+
+func clientInfo(c *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+func (h *Handler) respondLoginError(c *gin.Context, err error) {
+	var retry *errs.RetryAfterError
+	switch {
+	case errors.As(err, &retry):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retry.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many login attempts, try again later"})
+	case errors.Is(err, errs.ErrInvalidCredentials),
+		errors.Is(err, errs.ErrInvalidTwoFactorCode),
+		errors.Is(err, errs.ErrTokenInvalid),
+		errors.Is(err, errs.ErrTokenExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Login failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "login failed"})
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/audit"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
 )
 
 func (h *Handler) authMiddleware(c *gin.Context) {
@@ -25,7 +27,7 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 	}
 
 	accessToken := parts[1]
-	userID, err := h.services.Token.ValidateAccess(accessToken)
+	claims, err := h.services.Token.ParseAccess(accessToken)
 	if err != nil {
 		if errors.Is(err, errs.ErrTokenExpired) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "expired token"})
@@ -35,8 +37,29 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 		return
 	}
 
-	c.Set("userID", userID)
+	err = h.services.Session.CheckSession(c.Request.Context(), claims.UserID, claims.SessionID)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "session revoked"})
+		return
+	} else if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.Set("userID", claims.UserID)
+	c.Set("sessionID", claims.SessionID)
+	ctx := audit.WithActor(c.Request.Context(), claims.UserID)
+	c.Request = c.Request.WithContext(logger.WithUserID(ctx, claims.UserID))
 
 	c.Next()
 
 }
+
+// clientMiddleware makes the client address available to services that
+// record it, such as the audit log.
+func (h *Handler) clientMiddleware(c *gin.Context) {
+	ctx := audit.WithClient(c.Request.Context(), c.ClientIP(), c.Request.UserAgent())
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Next()
+}
@@ -0,0 +1,206 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/badge"
+)
+
+// badgeCacheControl lets README renderers and proxies keep a badge for a
+// minute, about as long as the shortest check interval.
+const badgeCacheControl = "public, max-age=60"
+
+// @Summary Enable the badges of a monitor
+// @Description Issues a new badge token. URLs with an earlier token stop working.
+// @Security ApiKeyAuth
+// @Tags badges
+// @Produce json
+// @Param id path int true "Monitor ID"
+// @Success 201 {object} dto.BadgeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /monitors/{id}/badge [post]
+func (h *Handler) enableBadge(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := h.getOrganizationMonitor(c, id); !ok {
+		return
+	}
+
+	b, err := h.services.Badge.EnableBadge(c.Request.Context(), c.GetInt64("organizationID"), id)
+	if err != nil {
+		h.respondBadgeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, badgeResponse(b))
+}
+
+// @Summary Get the badge URLs of a monitor
+// @Security ApiKeyAuth
+// @Tags badges
+// @Produce json
+// @Param id path int true "Monitor ID"
+// @Success 200 {object} dto.BadgeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /monitors/{id}/badge [get]
+func (h *Handler) getBadge(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := h.getOrganizationMonitor(c, id); !ok {
+		return
+	}
+
+	b, err := h.services.Badge.GetBadge(c.Request.Context(), id)
+	if err != nil {
+		h.respondBadgeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, badgeResponse(b))
+}
+
+// @Summary Disable the badges of a monitor
+// @Security ApiKeyAuth
+// @Tags badges
+// @Param id path int true "Monitor ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /monitors/{id}/badge [delete]
+func (h *Handler) disableBadge(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if _, ok := h.getOrganizationMonitor(c, id); !ok {
+		return
+	}
+
+	if err := h.services.Badge.DisableBadge(c.Request.Context(), c.GetInt64("organizationID"), id); err != nil {
+		h.respondBadgeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Status badge
+// @Tags badges
+// @Produce image/svg+xml
+// @Param token path string true "Badge token"
+// @Success 200 {string} string "SVG"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} map[string]string
+// @Router /badge/{token}/status.svg [get]
+func (h *Handler) getStatusBadge(c *gin.Context) {
+	svg, err := h.services.Badge.StatusBadge(c.Request.Context(), c.Param("token"))
+	h.respondBadge(c, svg, err)
+}
+
+// @Summary Uptime badge
+// @Tags badges
+// @Produce image/svg+xml
+// @Param token path string true "Badge token"
+// @Param period query string false "Period such as 24h or 30d, at most 90d" default(30d)
+// @Success 200 {string} string "SVG"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /badge/{token}/uptime.svg [get]
+func (h *Handler) getUptimeBadge(c *gin.Context) {
+	period, err := badge.ParsePeriod(c.Query("period"))
+	if err != nil {
+		h.respondBadgeError(c, err)
+		return
+	}
+
+	svg, err := h.services.Badge.UptimeBadge(c.Request.Context(), c.Param("token"), period)
+	h.respondBadge(c, svg, err)
+}
+
+// @Summary Latency badge
+// @Description Mean response time of the successful checks of the last day.
+// @Tags badges
+// @Produce image/svg+xml
+// @Param token path string true "Badge token"
+// @Success 200 {string} string "SVG"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} map[string]string
+// @Router /badge/{token}/latency.svg [get]
+func (h *Handler) getLatencyBadge(c *gin.Context) {
+	svg, err := h.services.Badge.LatencyBadge(c.Request.Context(), c.Param("token"))
+	h.respondBadge(c, svg, err)
+}
+
+// respondBadge sends the SVG with an ETag so clients revalidating an
+// unchanged badge get an empty 304.
+func (h *Handler) respondBadge(c *gin.Context, svg []byte, err error) {
+	if err != nil {
+		h.respondBadgeError(c, err)
+		return
+	}
+
+	sum := sha256.Sum256(svg)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("Cache-Control", badgeCacheControl)
+	c.Header("ETag", etag)
+
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", svg)
+}
+
+// matchesETag reports whether an If-None-Match header lists etag, weakly
+// compared as RFC 9110 asks for.
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func badgeResponse(b *models.Badge) dto.BadgeResponse {
+	prefix := "/badge/" + b.Token
+	return dto.BadgeResponse{
+		Token:      b.Token,
+		CreatedAt:  b.CreatedAt,
+		StatusURL:  prefix + "/status.svg",
+		UptimeURL:  prefix + "/uptime.svg?period=30d",
+		LatencyURL: prefix + "/latency.svg",
+	}
+}
+
+func (h *Handler) respondBadgeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidBadgePeriod):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrBadgeNotFound), errors.Is(err, errs.ErrMonitorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": errs.ErrBadgeNotFound.Error()})
+	default:
+		h.logger.WithError(err).Error("Badge request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
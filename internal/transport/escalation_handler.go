@@ -0,0 +1,189 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Create an escalation policy
+// @Security ApiKeyAuth
+// @Tags escalation
+// @Accept json
+// @Produce json
+// @Param input body dto.EscalationPolicyRequest true "policy"
+// @Success 201 {object} dto.EscalationPolicyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /escalation-policies [post]
+func (h *Handler) createEscalationPolicy(c *gin.Context) {
+	var req dto.EscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := h.services.Escalation.CreatePolicy(c.Request.Context(), models.EscalationPolicy{
+		OrganizationID: c.GetInt64("organizationID"),
+		Name:           req.Name,
+		Levels:         req.Levels,
+	})
+	if err != nil {
+		h.respondEscalationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.EscalationPolicyResponse{ID: id})
+}
+
+// @Summary Escalation policies of the active organization
+// @Security ApiKeyAuth
+// @Tags escalation
+// @Produce json
+// @Success 200 {object} []models.EscalationPolicy
+// @Failure 401 {object} map[string]string
+// @Router /escalation-policies [get]
+func (h *Handler) getEscalationPolicies(c *gin.Context) {
+	policies, err := h.services.Escalation.GetOrganizationPolicies(c.Request.Context(), c.GetInt64("organizationID"))
+	if err != nil {
+		h.respondEscalationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// @Summary Get an escalation policy
+// @Security ApiKeyAuth
+// @Tags escalation
+// @Produce json
+// @Param id path int true "Policy ID"
+// @Success 200 {object} models.EscalationPolicy
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /escalation-policies/{id} [get]
+func (h *Handler) getEscalationPolicy(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	policy, err := h.services.Escalation.GetPolicy(c.Request.Context(), c.GetInt64("organizationID"), id)
+	if err != nil {
+		h.respondEscalationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary Update an escalation policy
+// @Security ApiKeyAuth
+// @Tags escalation
+// @Accept json
+// @Param id path int true "Policy ID"
+// @Param input body dto.EscalationPolicyRequest true "policy"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /escalation-policies/{id} [put]
+func (h *Handler) updateEscalationPolicy(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.EscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.services.Escalation.UpdatePolicy(c.Request.Context(), models.EscalationPolicy{
+		ID:             id,
+		OrganizationID: c.GetInt64("organizationID"),
+		Name:           req.Name,
+		Levels:         req.Levels,
+	})
+	if err != nil {
+		h.respondEscalationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Delete an escalation policy
+// @Security ApiKeyAuth
+// @Tags escalation
+// @Param id path int true "Policy ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /escalation-policies/{id} [delete]
+func (h *Handler) deleteEscalationPolicy(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Escalation.DeletePolicy(c.Request.Context(), c.GetInt64("organizationID"), id); err != nil {
+		h.respondEscalationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Acknowledge an incident
+// @Description Stops escalation; channels that were already notified are told who took it.
+// @Security ApiKeyAuth
+// @Tags escalation
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {object} models.Incident
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /incidents/{id}/ack [post]
+func (h *Handler) acknowledgeIncident(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	incident, err := h.services.Escalation.Acknowledge(c.Request.Context(),
+		c.GetInt64("organizationID"), id, c.GetInt64("userID"))
+	if err != nil {
+		h.respondEscalationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
+
+func (h *Handler) respondEscalationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidEscalationPolicy):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrEscalationPolicyNotFound),
+		errors.Is(err, errs.ErrChannelNotFound),
+		errors.Is(err, errs.ErrIncidentNotFound),
+		errors.Is(err, errs.ErrMonitorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrIncidentResolved), errors.Is(err, errs.ErrIncidentAcknowledged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Escalation request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
@@ -0,0 +1,153 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/pkg/feed"
+)
+
+const (
+	atomContentType = "application/atom+xml; charset=utf-8"
+	rssContentType  = "application/rss+xml; charset=utf-8"
+)
+
+// @Summary Issue a personal incident feed token
+// @Description Replaces the earlier token. The token is shown only once.
+// @Security ApiKeyAuth
+// @Tags feeds
+// @Produce json
+// @Success 201 {object} dto.FeedTokenResponse
+// @Failure 401 {object} map[string]string
+// @Router /users/me/feed-token [post]
+func (h *Handler) createFeedToken(c *gin.Context) {
+	token, err := h.services.Feed.CreateToken(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		h.respondFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.FeedTokenResponse{
+		Token:   token,
+		AtomURL: "/feeds/" + token + "/incidents.atom",
+		RSSURL:  "/feeds/" + token + "/incidents.rss",
+	})
+}
+
+// @Summary Revoke the personal incident feed token
+// @Security ApiKeyAuth
+// @Tags feeds
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/me/feed-token [delete]
+func (h *Handler) revokeFeedToken(c *gin.Context) {
+	if err := h.services.Feed.RevokeToken(c.Request.Context(), c.GetInt64("userID")); err != nil {
+		h.respondFeedError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Personal incident feed (Atom)
+// @Tags feeds
+// @Produce application/atom+xml
+// @Param token path string true "Feed token"
+// @Success 200 {string} string "Atom feed"
+// @Failure 404 {object} map[string]string
+// @Router /feeds/{token}/incidents.atom [get]
+func (h *Handler) getUserAtomFeed(c *gin.Context) {
+	f, err := h.services.Feed.UserFeed(c.Request.Context(), c.Param("token"))
+	h.respondFeed(c, f, err, false, true)
+}
+
+// @Summary Personal incident feed (RSS)
+// @Tags feeds
+// @Produce application/rss+xml
+// @Param token path string true "Feed token"
+// @Success 200 {string} string "RSS feed"
+// @Failure 404 {object} map[string]string
+// @Router /feeds/{token}/incidents.rss [get]
+func (h *Handler) getUserRSSFeed(c *gin.Context) {
+	f, err := h.services.Feed.UserFeed(c.Request.Context(), c.Param("token"))
+	h.respondFeed(c, f, err, false, false)
+}
+
+// @Summary Status page feed (Atom)
+// @Tags status-pages
+// @Produce application/atom+xml
+// @Param slug path string true "Status page slug"
+// @Success 200 {string} string "Atom feed"
+// @Failure 404 {object} map[string]string
+// @Router /status/{slug}/feed.atom [get]
+func (h *Handler) getPageAtomFeed(c *gin.Context) {
+	f, err := h.services.Feed.PageFeed(c.Request.Context(), c.Param("slug"))
+	h.respondFeed(c, f, err, true, true)
+}
+
+// @Summary Status page feed (RSS)
+// @Tags status-pages
+// @Produce application/rss+xml
+// @Param slug path string true "Status page slug"
+// @Success 200 {string} string "RSS feed"
+// @Failure 404 {object} map[string]string
+// @Router /status/{slug}/feed.rss [get]
+func (h *Handler) getPageRSSFeed(c *gin.Context) {
+	f, err := h.services.Feed.PageFeed(c.Request.Context(), c.Param("slug"))
+	h.respondFeed(c, f, err, true, false)
+}
+
+// respondFeed renders the feed as Atom or RSS. Personal feeds may only be
+// kept by the reader, public ones by any cache.
+func (h *Handler) respondFeed(c *gin.Context, f *feed.Feed, err error, public, atom bool) {
+	if err != nil {
+		h.respondFeedError(c, err)
+		return
+	}
+
+	f.SelfLink = requestURL(c)
+
+	var body []byte
+	contentType := rssContentType
+	if atom {
+		body, err = f.Atom()
+		contentType = atomContentType
+	} else {
+		body, err = f.RSS()
+	}
+	if err != nil {
+		h.respondFeedError(c, err)
+		return
+	}
+
+	if public {
+		c.Header("Cache-Control", "public, max-age=60")
+	} else {
+		c.Header("Cache-Control", "private, max-age=60")
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// requestURL rebuilds the URL the client asked for, honoring the scheme a
+// TLS terminating proxy reports.
+func requestURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + c.Request.URL.Path
+}
+
+func (h *Handler) respondFeedError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrFeedNotFound), errors.Is(err, errs.ErrStatusPageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Feed request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
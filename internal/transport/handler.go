@@ -4,7 +4,10 @@ import (
 	_ "github.com/mixdone/uptime-monitoring/docs"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
+	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/services"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
 	"github.com/mixdone/uptime-monitoring/pkg/logger"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -12,19 +15,32 @@ import (
 
 type Handler struct {
 	services *services.Services
+	metrics  *metrics.Metrics
+	tracing  *tracing.Tracing
 	logger   logger.Logger
 }
 
-func NewHandler(services *services.Services, log logger.Logger) *Handler {
+func NewHandler(services *services.Services, metrics *metrics.Metrics, tracing *tracing.Tracing,
+	log logger.Logger) *Handler {
 	return &Handler{
 		services: services,
+		metrics:  metrics,
+		tracing:  tracing,
 		logger:   log,
 	}
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
+	router.Use(h.tracingMiddleware(), h.requestIDMiddleware, h.accessLogMiddleware,
+		h.metricsMiddleware, h.recoveryMiddleware, h.clientMiddleware)
+	if path := h.metrics.Path(); path != "" {
+		router.GET(path, gin.WrapH(h.metrics.Handler()))
+	}
+	router.GET("/healthz", h.healthz)
+	router.GET("/readyz", h.readyz)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/.well-known/jwks.json", h.jwks)
 
 	auth := router.Group("/auth")
 	{
@@ -32,16 +48,127 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		auth.POST("/login", h.login)
 		auth.POST("/refresh", h.refresh)
 		auth.POST("/logout", h.authMiddleware, h.logout)
+		auth.POST("/logout-all", h.authMiddleware, h.logoutAll)
+		auth.GET("/sessions", h.authMiddleware, h.getSessions)
+		auth.DELETE("/sessions/:id", h.authMiddleware, h.revokeSession)
+
+		auth.POST("/email/verify", h.verifyEmail)
+		auth.POST("/email/resend", h.authMiddleware, h.resendVerificationEmail)
+		auth.POST("/password/forgot", h.forgotPassword)
+		auth.POST("/password/reset", h.resetPassword)
+
+		auth.GET("/oidc/login", h.oidcLogin)
+		auth.GET("/oidc/callback", h.oidcCallback)
+
+		auth.POST("/2fa/login", h.twoFactorLogin)
+		auth.POST("/2fa/setup", h.authMiddleware, h.twoFactorSetup)
+		auth.POST("/2fa/verify", h.authMiddleware, h.twoFactorVerify)
+		auth.POST("/2fa/disable", h.authMiddleware, h.twoFactorDisable)
+	}
+
+	users := router.Group("/users/me", h.authMiddleware)
+	{
+		users.GET("", h.getProfile)
+		users.PATCH("", h.updateProfile)
+		users.DELETE("", h.deleteProfile)
+		users.POST("/password", h.changePassword)
+		users.GET("/audit", h.getUserAuditLog)
+		users.POST("/feed-token", h.createFeedToken)
+		users.DELETE("/feed-token", h.revokeFeedToken)
+	}
+
+	feeds := router.Group("/feeds/:token")
+	{
+		feeds.GET("/incidents.atom", h.getUserAtomFeed)
+		feeds.GET("/incidents.rss", h.getUserRSSFeed)
+	}
+
+	organization := router.Group("/organizations", h.authMiddleware)
+	{
+		organization.POST("", h.createOrganization)
+		organization.GET("", h.getUserOrganizations)
+		organization.GET("/invitations", h.getUserInvitations)
+		organization.POST("/invitations/:id/accept", h.acceptInvitation)
+		organization.DELETE("/invitations/:id", h.declineInvitation)
+		organization.POST("/:id/switch", h.switchOrganization)
+		organization.GET("/:id/members", h.getOrganizationMembers)
+		organization.PATCH("/:id/members/:userID", h.updateMemberRole)
+		organization.DELETE("/:id/members/:userID", h.removeMember)
+		organization.POST("/:id/invitations", h.inviteMember)
 	}
 
-	monitor := router.Group("/monitors", h.authMiddleware)
+	monitor := router.Group("/monitors", h.authMiddleware, h.organizationMiddleware)
 	{
-		monitor.POST("", h.createMonitor)
-		monitor.GET("", h.getAllUserMonitor)
+		monitor.POST("", h.requireRole(models.RoleEditor), h.createMonitor)
+		monitor.GET("", h.getAllOrganizationMonitors)
 		monitor.GET("/:id", h.getMonitor)
-		monitor.PUT("/:id", h.updateMonitor)
-		monitor.DELETE("/:id", h.deleteMonitor)
+		monitor.GET("/:id/incidents", h.getMonitorIncidents)
+		monitor.PUT("/:id", h.requireRole(models.RoleEditor), h.updateMonitor)
+		monitor.DELETE("/:id", h.requireRole(models.RoleEditor), h.deleteMonitor)
+		monitor.POST("/:id/badge", h.requireRole(models.RoleEditor), h.enableBadge)
+		monitor.GET("/:id/badge", h.getBadge)
+		monitor.DELETE("/:id/badge", h.requireRole(models.RoleEditor), h.disableBadge)
+	}
+
+	badges := router.Group("/badge/:token")
+	{
+		badges.GET("/status.svg", h.getStatusBadge)
+		badges.GET("/uptime.svg", h.getUptimeBadge)
+		badges.GET("/latency.svg", h.getLatencyBadge)
 	}
 
+	maintenance := router.Group("/maintenance-windows", h.authMiddleware, h.organizationMiddleware)
+	{
+		maintenance.POST("", h.requireRole(models.RoleEditor), h.createMaintenanceWindow)
+		maintenance.GET("", h.getMaintenanceWindows)
+		maintenance.GET("/:id", h.getMaintenanceWindow)
+		maintenance.PUT("/:id", h.requireRole(models.RoleEditor), h.updateMaintenanceWindow)
+		maintenance.DELETE("/:id", h.requireRole(models.RoleEditor), h.deleteMaintenanceWindow)
+	}
+
+	channels := router.Group("/notification-channels", h.authMiddleware, h.organizationMiddleware,
+		h.requireRole(models.RoleAdmin))
+	{
+		channels.POST("", h.createNotificationChannel)
+		channels.GET("", h.getNotificationChannels)
+		channels.GET("/:id", h.getNotificationChannel)
+		channels.PUT("/:id", h.updateNotificationChannel)
+		channels.DELETE("/:id", h.deleteNotificationChannel)
+	}
+
+	escalation := router.Group("/escalation-policies", h.authMiddleware, h.organizationMiddleware)
+	{
+		escalation.POST("", h.requireRole(models.RoleAdmin), h.createEscalationPolicy)
+		escalation.GET("", h.getEscalationPolicies)
+		escalation.GET("/:id", h.getEscalationPolicy)
+		escalation.PUT("/:id", h.requireRole(models.RoleAdmin), h.updateEscalationPolicy)
+		escalation.DELETE("/:id", h.requireRole(models.RoleAdmin), h.deleteEscalationPolicy)
+	}
+
+	router.POST("/incidents/:id/ack", h.authMiddleware, h.organizationMiddleware,
+		h.requireRole(models.RoleEditor), h.acknowledgeIncident)
+
+	statusPages := router.Group("/status-pages", h.authMiddleware, h.organizationMiddleware)
+	{
+		statusPages.POST("", h.requireRole(models.RoleEditor), h.createStatusPage)
+		statusPages.GET("", h.getStatusPages)
+		statusPages.GET("/:id", h.getStatusPage)
+		statusPages.PUT("/:id", h.requireRole(models.RoleEditor), h.updateStatusPage)
+		statusPages.DELETE("/:id", h.requireRole(models.RoleEditor), h.deleteStatusPage)
+		statusPages.POST("/:id/announcements", h.requireRole(models.RoleEditor), h.createAnnouncement)
+		statusPages.GET("/:id/announcements", h.getAnnouncements)
+		statusPages.POST("/:id/announcements/:announcementID/updates", h.requireRole(models.RoleEditor),
+			h.addAnnouncementUpdate)
+		statusPages.DELETE("/:id/announcements/:announcementID", h.requireRole(models.RoleEditor),
+			h.deleteAnnouncement)
+	}
+
+	router.GET("/status/:slug", h.getPublicStatusPage)
+	router.GET("/status/:slug/feed.atom", h.getPageAtomFeed)
+	router.GET("/status/:slug/feed.rss", h.getPageRSSFeed)
+
+	router.GET("/audit", h.authMiddleware, h.organizationMiddleware,
+		h.requireRole(models.RoleAdmin), h.getOrganizationAuditLog)
+
 	return router
 }
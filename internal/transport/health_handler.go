@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+)
+
+// @Summary Liveness probe
+// @Description Answers as long as the process serves HTTP.
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /healthz [get]
+func (h *Handler) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": models.HealthOK})
+}
+
+// @Summary Readiness probe
+// @Description Checks the database, the schema version, the check scheduler and the message queues.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.Readiness
+// @Failure 503 {object} models.Readiness
+// @Router /readyz [get]
+func (h *Handler) readyz(c *gin.Context) {
+	readiness := h.services.Health.Readiness(c.Request.Context())
+
+	status := http.StatusOK
+	if !readiness.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, readiness)
+}
@@ -0,0 +1,17 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Public keys for verifying issued tokens
+// @Tags auth
+// @Produce json
+// @Success 200 {object} token.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.services.Token.JWKS())
+}
@@ -0,0 +1,68 @@
+package transport
+
+import (
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
+)
+
+// accessLogMiddleware logs every request once it is answered. Routes are
+// logged as their pattern, so tokens in paths like /feeds/:token stay out
+// of the logs.
+func (h *Handler) accessLogMiddleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = metrics.UnmatchedRoute
+	}
+
+	// The context of the request as the handlers left it, which has the
+	// user ID once authentication passed.
+	log := h.logger.WithContext(c.Request.Context()).WithFields(map[string]any{
+		"method":     c.Request.Method,
+		"route":      route,
+		"status":     c.Writer.Status(),
+		"latency_ms": time.Since(start).Milliseconds(),
+	})
+
+	switch status := c.Writer.Status(); {
+	case status >= http.StatusInternalServerError:
+		log.Error("Request failed")
+	case route == "/healthz" || route == "/readyz" || route == h.metrics.Path():
+		log.Debug("Request served")
+	default:
+		log.Info("Request served")
+	}
+}
+
+// recoveryMiddleware turns a panicking handler into a 500 with the usual
+// error body instead of a dropped connection.
+func (h *Handler) recoveryMiddleware(c *gin.Context) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+
+		h.logger.WithContext(c.Request.Context()).WithFields(map[string]any{
+			"panic": recovered,
+			"stack": string(debug.Stack()),
+		}).Error("Handler panicked")
+
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}()
+
+	c.Next()
+}
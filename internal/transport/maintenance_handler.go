@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Create a maintenance window
+// @Security ApiKeyAuth
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param input body dto.MaintenanceWindowRequest true "window"
+// @Success 201 {object} dto.MaintenanceWindowResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /maintenance-windows [post]
+func (h *Handler) createMaintenanceWindow(c *gin.Context) {
+	var req dto.MaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetInt64("userID")
+	window := maintenanceWindowFromRequest(req)
+	window.OrganizationID = c.GetInt64("organizationID")
+	window.CreatedBy = &userID
+
+	id, err := h.services.Maintenance.CreateWindow(c.Request.Context(), window)
+	if err != nil {
+		h.respondMaintenanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MaintenanceWindowResponse{ID: id})
+}
+
+// @Summary Maintenance windows of the active organization
+// @Security ApiKeyAuth
+// @Tags maintenance
+// @Produce json
+// @Success 200 {object} []models.MaintenanceWindow
+// @Failure 401 {object} map[string]string
+// @Router /maintenance-windows [get]
+func (h *Handler) getMaintenanceWindows(c *gin.Context) {
+	windows, err := h.services.Maintenance.GetOrganizationWindows(c.Request.Context(), c.GetInt64("organizationID"))
+	if err != nil {
+		h.respondMaintenanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, windows)
+}
+
+// @Summary Get a maintenance window
+// @Security ApiKeyAuth
+// @Tags maintenance
+// @Produce json
+// @Param id path int true "Window ID"
+// @Success 200 {object} models.MaintenanceWindow
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /maintenance-windows/{id} [get]
+func (h *Handler) getMaintenanceWindow(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	window, err := h.services.Maintenance.GetWindow(c.Request.Context(), c.GetInt64("organizationID"), id)
+	if err != nil {
+		h.respondMaintenanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, window)
+}
+
+// @Summary Update a maintenance window
+// @Security ApiKeyAuth
+// @Tags maintenance
+// @Accept json
+// @Param id path int true "Window ID"
+// @Param input body dto.MaintenanceWindowRequest true "window"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /maintenance-windows/{id} [put]
+func (h *Handler) updateMaintenanceWindow(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.MaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	window := maintenanceWindowFromRequest(req)
+	window.ID = id
+	window.OrganizationID = c.GetInt64("organizationID")
+
+	if err := h.services.Maintenance.UpdateWindow(c.Request.Context(), window); err != nil {
+		h.respondMaintenanceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Delete a maintenance window
+// @Security ApiKeyAuth
+// @Tags maintenance
+// @Param id path int true "Window ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /maintenance-windows/{id} [delete]
+func (h *Handler) deleteMaintenanceWindow(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Maintenance.DeleteWindow(c.Request.Context(), c.GetInt64("organizationID"), id); err != nil {
+		h.respondMaintenanceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func maintenanceWindowFromRequest(req dto.MaintenanceWindowRequest) models.MaintenanceWindow {
+	return models.MaintenanceWindow{
+		Title:           req.Title,
+		Description:     req.Description,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		Recurrence:      req.Recurrence,
+		DurationMinutes: req.DurationMinutes,
+		Timezone:        req.Timezone,
+		MonitorIDs:      req.MonitorIDs,
+	}
+}
+
+func (h *Handler) respondMaintenanceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidSchedule), errors.Is(err, errs.ErrInvalidTimezone):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrMaintenanceWindowNotFound), errors.Is(err, errs.ErrMonitorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Maintenance window request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
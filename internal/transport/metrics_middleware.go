@@ -0,0 +1,16 @@
+package transport
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// metricsMiddleware counts every request by its route pattern once the
+// handlers are done.
+func (h *Handler) metricsMiddleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	h.metrics.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+}
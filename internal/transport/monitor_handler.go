@@ -1,15 +1,19 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mixdone/uptime-monitoring/internal/models"
 	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
 )
 
 // @Summary Create a new monitor
+// @Security ApiKeyAuth
 // @Tags monitors
 // @Accept json
 // @Produce json
@@ -17,6 +21,7 @@ import (
 // @Success 200 {object} dto.MonitorResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /monitors [post]
 func (h *Handler) createMonitor(c *gin.Context) {
@@ -34,22 +39,27 @@ func (h *Handler) createMonitor(c *gin.Context) {
 	}
 
 	monitor := models.Monitor{
-		UserID:           userID.(int64),
-		Name:             req.Name,
-		Type:             req.Type,
-		Target:           req.Target,
-		Timeout:          req.Timeout,
-		Interval:         req.Interval,
-		IsActive:         req.IsActive,
-		RequestSpec:      req.RequestSpec,
-		ExpectedResponse: req.ExpectedResponse,
+		OrganizationID:     c.GetInt64("organizationID"),
+		UserID:             userID.(int64),
+		Name:               req.Name,
+		Type:               req.Type,
+		Target:             req.Target,
+		Timeout:            req.Timeout,
+		Interval:           req.Interval,
+		IsActive:           req.IsActive,
+		RequestSpec:        req.RequestSpec,
+		ExpectedResponse:   req.ExpectedResponse,
+		ParentIDs:          req.ParentIDs,
+		EscalationPolicyID: req.EscalationPolicyID,
+		FlapThreshold:      req.FlapThreshold,
+		FlapWindowMinutes:  req.FlapWindowMinutes,
 	}
+	monitor.FlapWindowMinutes = monitor.FlapWindow()
 
 	id, err := h.services.Monitor.CreateMonitor(c.Request.Context(), monitor)
 
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to create monitor")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create monitor"})
+		h.respondMonitorError(c, err, "failed to create monitor")
 		return
 	}
 
@@ -57,6 +67,7 @@ func (h *Handler) createMonitor(c *gin.Context) {
 }
 
 // @Summary Get monitor by ID
+// @Security ApiKeyAuth
 // @Tags monitors
 // @Produce json
 // @Param id path int true "Monitor ID"
@@ -71,41 +82,54 @@ func (h *Handler) getMonitor(c *gin.Context) {
 		return
 	}
 
-	monitor, err := h.services.Monitor.GetMonitor(c.Request.Context(), id)
+	monitor, ok := h.getOrganizationMonitor(c, id)
+	if !ok {
+		return
+	}
+
+	active, err := h.services.Maintenance.MonitorMaintenance(c.Request.Context(), id, time.Now())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "monitor not found"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch maintenance windows"})
 		return
 	}
+	monitor.ActiveMaintenance = active
 
 	c.JSON(http.StatusOK, monitor)
 }
 
-// @Summary Get all user's monitors
+// @Summary Get all monitors of the active organization
+// @Security ApiKeyAuth
 // @Tags monitors
 // @Produce json
 // @Success 200 {object} []models.Monitor
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /monitors [get]
-func (h *Handler) getAllUserMonitor(c *gin.Context) {
+func (h *Handler) getAllOrganizationMonitors(c *gin.Context) {
 
-	id, ok := c.Get("userID")
-	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	orgID := c.GetInt64("organizationID")
+
+	monitors, err := h.services.Monitor.GetAllOrganizationMonitors(c.Request.Context(), orgID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to fetch organization monitors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch all organization monitors"})
 		return
 	}
 
-	monitors, err := h.services.Monitor.GetAllUserMonitors(c.Request.Context(), id.(int64))
+	active, err := h.services.Maintenance.OrganizationMaintenance(c.Request.Context(), orgID, time.Now())
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to fetch user monitors")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch all user monitors"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch maintenance windows"})
 		return
 	}
+	for i := range monitors {
+		monitors[i].ActiveMaintenance = active[monitors[i].ID]
+	}
 
 	c.JSON(http.StatusOK, monitors)
 }
 
 // @Summary Update monitor
+// @Security ApiKeyAuth
 // @Tags monitors
 // @Accept json
 // @Produce json
@@ -114,6 +138,8 @@ func (h *Handler) getAllUserMonitor(c *gin.Context) {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /monitors/{id} [put]
 func (h *Handler) updateMonitor(c *gin.Context) {
@@ -129,28 +155,32 @@ func (h *Handler) updateMonitor(c *gin.Context) {
 		return
 	}
 
-	userID, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	existing, ok := h.getOrganizationMonitor(c, id)
+	if !ok {
 		return
 	}
 
 	monitor := models.Monitor{
-		ID:               id,
-		UserID:           userID.(int64),
-		Name:             req.Name,
-		Type:             req.Type,
-		Target:           req.Target,
-		Timeout:          req.Timeout,
-		Interval:         req.Interval,
-		IsActive:         req.IsActive,
-		RequestSpec:      req.RequestSpec,
-		ExpectedResponse: req.ExpectedResponse,
+		ID:                 id,
+		OrganizationID:     existing.OrganizationID,
+		UserID:             existing.UserID,
+		Name:               req.Name,
+		Type:               req.Type,
+		Target:             req.Target,
+		Timeout:            req.Timeout,
+		Interval:           req.Interval,
+		IsActive:           req.IsActive,
+		RequestSpec:        req.RequestSpec,
+		ExpectedResponse:   req.ExpectedResponse,
+		ParentIDs:          req.ParentIDs,
+		EscalationPolicyID: req.EscalationPolicyID,
+		FlapThreshold:      req.FlapThreshold,
+		FlapWindowMinutes:  req.FlapWindowMinutes,
 	}
+	monitor.FlapWindowMinutes = monitor.FlapWindow()
 
 	if err := h.services.Monitor.UpdateMonitor(c.Request.Context(), monitor); err != nil {
-		h.logger.WithError(err).Error("Failed to update monitor")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update monitor"})
+		h.respondMonitorError(c, err, "failed to update monitor")
 		return
 	}
 
@@ -159,6 +189,7 @@ func (h *Handler) updateMonitor(c *gin.Context) {
 }
 
 // @Summary Delete monitor
+// @Security ApiKeyAuth
 // @Tags monitors
 // @Accept json
 // @Produce json
@@ -166,6 +197,8 @@ func (h *Handler) updateMonitor(c *gin.Context) {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /monitors/{id} [delete]
 func (h *Handler) deleteMonitor(c *gin.Context) {
@@ -175,11 +208,79 @@ func (h *Handler) deleteMonitor(c *gin.Context) {
 		return
 	}
 
+	if _, ok := h.getOrganizationMonitor(c, id); !ok {
+		return
+	}
+
 	if err := h.services.Monitor.DeleteMonitor(c.Request.Context(), id); err != nil {
 		h.logger.WithError(err).Error("Failed to delete monitor")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete monitor"})
 		return
 	}
+	h.metrics.ForgetMonitor(id)
 
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary Incidents of a monitor
+// @Security ApiKeyAuth
+// @Tags monitors
+// @Produce json
+// @Param id path int true "Monitor ID"
+// @Param limit query int false "number of incidents, up to 500"
+// @Success 200 {object} []models.Incident
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /monitors/{id}/incidents [get]
+func (h *Handler) getMonitorIncidents(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	if _, ok := h.getOrganizationMonitor(c, id); !ok {
+		return
+	}
+
+	incidents, err := h.services.Incident.GetMonitorIncidents(c.Request.Context(), id, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch incidents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, incidents)
+}
+
+// getOrganizationMonitor loads a monitor and hides it unless it belongs to
+// the caller's active organization. It writes the error response itself.
+func (h *Handler) getOrganizationMonitor(c *gin.Context, id int64) (*models.Monitor, bool) {
+	monitor, err := h.services.Monitor.GetMonitor(c.Request.Context(), id)
+	if err != nil || monitor.OrganizationID != c.GetInt64("organizationID") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "monitor not found"})
+		return nil, false
+	}
+
+	return monitor, true
+}
+
+// respondMonitorError reports invalid dependencies and unknown escalation
+// policies to the client and hides everything else behind message.
+func (h *Handler) respondMonitorError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, errs.ErrDependencyCycle), errors.Is(err, errs.ErrSelfDependency):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrMonitorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "parent monitor not found"})
+	case errors.Is(err, errs.ErrEscalationPolicyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Monitor request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
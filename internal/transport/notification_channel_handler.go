@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Create a notification channel
+// @Security ApiKeyAuth
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param input body dto.NotificationChannelRequest true "channel"
+// @Success 201 {object} dto.NotificationChannelResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /notification-channels [post]
+func (h *Handler) createNotificationChannel(c *gin.Context) {
+	var req dto.NotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := h.services.Channel.CreateChannel(c.Request.Context(), models.NotificationChannel{
+		OrganizationID: c.GetInt64("organizationID"),
+		Name:           req.Name,
+		Type:           req.Type,
+		Config:         req.Config,
+	})
+	if err != nil {
+		h.respondChannelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NotificationChannelResponse{ID: id})
+}
+
+// @Summary Notification channels of the active organization
+// @Security ApiKeyAuth
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} []models.NotificationChannel
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /notification-channels [get]
+func (h *Handler) getNotificationChannels(c *gin.Context) {
+	channels, err := h.services.Channel.GetOrganizationChannels(c.Request.Context(), c.GetInt64("organizationID"))
+	if err != nil {
+		h.respondChannelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, channels)
+}
+
+// @Summary Get a notification channel
+// @Security ApiKeyAuth
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Success 200 {object} models.NotificationChannel
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /notification-channels/{id} [get]
+func (h *Handler) getNotificationChannel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	channel, err := h.services.Channel.GetChannel(c.Request.Context(), c.GetInt64("organizationID"), id)
+	if err != nil {
+		h.respondChannelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+// @Summary Update a notification channel
+// @Security ApiKeyAuth
+// @Tags notifications
+// @Accept json
+// @Param id path int true "Channel ID"
+// @Param input body dto.NotificationChannelRequest true "channel"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /notification-channels/{id} [put]
+func (h *Handler) updateNotificationChannel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.NotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.services.Channel.UpdateChannel(c.Request.Context(), models.NotificationChannel{
+		ID:             id,
+		OrganizationID: c.GetInt64("organizationID"),
+		Name:           req.Name,
+		Type:           req.Type,
+		Config:         req.Config,
+	})
+	if err != nil {
+		h.respondChannelError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Delete a notification channel
+// @Security ApiKeyAuth
+// @Tags notifications
+// @Param id path int true "Channel ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /notification-channels/{id} [delete]
+func (h *Handler) deleteNotificationChannel(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Channel.DeleteChannel(c.Request.Context(), c.GetInt64("organizationID"), id); err != nil {
+		h.respondChannelError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondChannelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidChannel):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrChannelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Notification channel request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
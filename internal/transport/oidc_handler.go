@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+	"github.com/mixdone/uptime-monitoring/internal/services/constants"
+)
+
+// oidcStateCookie ties a sign-on to the browser that started it. Without it
+// an attacker could send a victim to the callback with the attacker's own
+// code and state and log the victim into the attacker's account.
+const oidcStateCookie = "oidc_state"
+
+// @Summary Start single sign-on
+// @Description Redirects to the identity provider
+// @Tags auth
+// @Param fingerprint query string true "device fingerprint"
+// @Success 302 "Found"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /auth/oidc/login [get]
+func (h *Handler) oidcLogin(c *gin.Context) {
+	if h.services.OIDC == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errs.ErrSSODisabled.Error()})
+		return
+	}
+
+	var req dto.OIDCLoginRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	url, state, err := h.services.OIDC.Begin(c.Request.Context(), req.Fingerprint)
+	if err != nil {
+		h.respondOIDCError(c, err)
+		return
+	}
+
+	setOIDCStateCookie(c, state, int(constants.OIDCLoginStateTTL.Seconds()))
+	c.Redirect(http.StatusFound, url)
+}
+
+// @Summary Finish single sign-on
+// @Description The identity provider redirects here after the user signed in
+// @Tags auth
+// @Produce json
+// @Param code query string true "authorization code"
+// @Param state query string true "state"
+// @Success 200 {object} dto.AuthResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /auth/oidc/callback [get]
+func (h *Handler) oidcCallback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": providerErr})
+		return
+	}
+
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cookie, _ := c.Cookie(oidcStateCookie)
+	setOIDCStateCookie(c, "", -1)
+	if cookie == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(req.State)) != 1 {
+		h.respondOIDCError(c, errs.ErrSSOStateInvalid)
+		return
+	}
+
+	req.Client = clientInfo(c)
+
+	authResult, err := h.services.Auth.CompleteOIDCLogin(c.Request.Context(), req)
+	if err != nil {
+		h.respondOIDCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, authResult)
+}
+
+// setOIDCStateCookie scopes the cookie to the sign-on endpoints. Lax is
+// enough because the provider returns with a top-level GET navigation.
+func setOIDCStateCookie(c *gin.Context, state string, maxAge int) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, maxAge, path.Dir(c.Request.URL.Path), "", secure, true)
+}
+
+func (h *Handler) respondOIDCError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrSSODisabled):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrSSOStateInvalid), errors.Is(err, errs.ErrSSOFailed):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrSSONoAccount):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Sign-on failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
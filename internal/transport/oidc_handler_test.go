@@ -0,0 +1,91 @@
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/services"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
+	"github.com/mixdone/uptime-monitoring/internal/transport"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+func TestOIDCLogin_StateCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	oidc := mocks.NewMockOIDCService(ctrl)
+	authService := mocks.NewMockAuthenticationService(ctrl)
+
+	oidc.EXPECT().Begin(gomock.Any(), "fp").
+		Return("https://idp.example.com/authorize?state=abc", "abc", nil).AnyTimes()
+
+	base := logrus.New()
+	base.SetOutput(io.Discard)
+	tr, err := tracing.New(context.Background(), config.Tracing{})
+	require.NoError(t, err)
+
+	router := transport.NewHandler(&services.Services{Auth: authService, OIDC: oidc},
+		metrics.New(config.Metrics{}), tr, logger.NewLoggerAdapter(base)).InitRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/login?fingerprint=fp", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusFound, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "abc", cookies[0].Value)
+	assert.Equal(t, "/auth/oidc", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+
+	tests := []struct {
+		name   string
+		cookie string
+		code   int
+	}{
+		{name: "same browser", cookie: "abc", code: http.StatusOK},
+		{name: "no cookie", code: http.StatusUnauthorized},
+		{name: "other state", cookie: "xyz", code: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.code == http.StatusOK {
+				authService.EXPECT().CompleteOIDCLogin(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req dto.OIDCCallbackRequest) (*dto.AuthResult, error) {
+						assert.Equal(t, "abc", req.State)
+						return &dto.AuthResult{AccessToken: "access"}, nil
+					})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?code=c&state=abc", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oidc_state", Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			cleared := w.Result().Cookies()
+			require.Len(t, cleared, 1)
+			assert.Equal(t, "oidc_state", cleared[0].Name)
+			assert.Negative(t, cleared[0].MaxAge)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Create organization
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param input body dto.CreateOrganizationRequest true "organization"
+// @Success 200 {object} dto.OrganizationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations [post]
+func (h *Handler) createOrganization(c *gin.Context) {
+	var req dto.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := h.services.Organization.CreateOrganization(c.Request.Context(), c.GetInt64("userID"), req.Name)
+	if err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.OrganizationResponse{ID: id})
+}
+
+// @Summary List organizations of the current user
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Produce json
+// @Success 200 {object} []models.Organization
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations [get]
+func (h *Handler) getUserOrganizations(c *gin.Context) {
+	orgs, err := h.services.Organization.GetUserOrganizations(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orgs)
+}
+
+// @Summary Switch active organization
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Param id path int true "Organization ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/switch [post]
+func (h *Handler) switchOrganization(c *gin.Context) {
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Organization.SwitchActiveOrganization(c.Request.Context(), c.GetInt64("userID"), orgID); err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List organization members
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} []models.OrganizationMember
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/members [get]
+func (h *Handler) getOrganizationMembers(c *gin.Context) {
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	members, err := h.services.Organization.GetMembers(c.Request.Context(), c.GetInt64("userID"), orgID)
+	if err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// @Summary Change member role
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Accept json
+// @Param id path int true "Organization ID"
+// @Param userID path int true "User ID"
+// @Param input body dto.UpdateMemberRoleRequest true "role"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /organizations/{id}/members/{userID} [patch]
+func (h *Handler) updateMemberRole(c *gin.Context) {
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseIDParam(c, "userID")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.services.Organization.UpdateMemberRole(c.Request.Context(),
+		c.GetInt64("userID"), orgID, userID, models.OrganizationRole(req.Role))
+	if err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Remove member or leave organization
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Param id path int true "Organization ID"
+// @Param userID path int true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /organizations/{id}/members/{userID} [delete]
+func (h *Handler) removeMember(c *gin.Context) {
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseIDParam(c, "userID")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Organization.RemoveMember(c.Request.Context(), c.GetInt64("userID"), orgID, userID); err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Invite user by email or username
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param input body dto.InviteMemberRequest true "invitation"
+// @Success 200 {object} dto.InvitationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /organizations/{id}/invitations [post]
+func (h *Handler) inviteMember(c *gin.Context) {
+	orgID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitation := models.OrganizationInvitation{
+		OrganizationID: orgID,
+		Role:           models.OrganizationRole(req.Role),
+	}
+	if req.Email != "" {
+		invitation.Email = &req.Email
+	}
+	if req.Username != "" {
+		invitation.Username = &req.Username
+	}
+
+	id, err := h.services.Organization.InviteMember(c.Request.Context(), c.GetInt64("userID"), invitation)
+	if err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.InvitationResponse{ID: id})
+}
+
+// @Summary List pending invitations of the current user
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Produce json
+// @Success 200 {object} []models.OrganizationInvitation
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/invitations [get]
+func (h *Handler) getUserInvitations(c *gin.Context) {
+	invitations, err := h.services.Organization.GetUserInvitations(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// @Summary Accept invitation
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Param id path int true "Invitation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /organizations/invitations/{id}/accept [post]
+func (h *Handler) acceptInvitation(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Organization.AcceptInvitation(c.Request.Context(), c.GetInt64("userID"), id); err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Decline invitation
+// @Security ApiKeyAuth
+// @Tags organizations
+// @Param id path int true "Invitation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/invitations/{id} [delete]
+func (h *Handler) declineInvitation(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.Organization.DeclineInvitation(c.Request.Context(), c.GetInt64("userID"), id); err != nil {
+		h.respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrOrganizationNotFound),
+		errors.Is(err, errs.ErrMemberNotFound),
+		errors.Is(err, errs.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrLastOwner), errors.Is(err, errs.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Organization request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
+
+func parseIDParam(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+
+	return id, true
+}
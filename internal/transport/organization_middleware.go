@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// organizationMiddleware resolves the caller's active organization and role.
+// It must run after authMiddleware.
+func (h *Handler) organizationMiddleware(c *gin.Context) {
+	userID, ok := c.Get("userID")
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	member, err := h.services.Organization.GetActiveMembership(c.Request.Context(), userID.(int64))
+	if err != nil {
+		if errors.Is(err, errs.ErrNoActiveOrganization) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "no active organization"})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to resolve active organization")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve organization"})
+		return
+	}
+
+	c.Set("organizationID", member.OrganizationID)
+	c.Set("role", member.Role)
+
+	c.Next()
+}
+
+// requireRole rejects callers whose role in the active organization is below required.
+func (h *Handler) requireRole(required models.OrganizationRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := c.Get("role")
+		if !ok || !role.(models.OrganizationRole).Allows(required) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+			return
+		}
+
+		c.Next()
+	}
+}
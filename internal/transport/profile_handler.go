@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Get current user
+// @Security ApiKeyAuth
+// @Tags users
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 {object} map[string]string
+// @Router /users/me [get]
+func (h *Handler) getProfile(c *gin.Context) {
+	user, err := h.services.Profile.GetProfile(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		h.respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// @Summary Update current user
+// @Security ApiKeyAuth
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body dto.UpdateProfileRequest true "fields to change"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/me [patch]
+func (h *Handler) updateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.services.Profile.UpdateProfile(c.Request.Context(), c.GetInt64("userID"), req)
+	if err != nil {
+		h.respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// @Summary Change password
+// @Description Signs out every session except the current one
+// @Security ApiKeyAuth
+// @Tags users
+// @Accept json
+// @Param input body dto.ChangePasswordRequest true "old and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /users/me/password [post]
+func (h *Handler) changePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.Client = clientInfo(c)
+
+	err := h.services.Profile.ChangePassword(c.Request.Context(), c.GetInt64("userID"), c.GetInt64("sessionID"), req)
+	if err != nil {
+		h.respondProfileError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Delete current user
+// @Description Requires the password and, with two-factor enabled, a code
+// @Security ApiKeyAuth
+// @Tags users
+// @Accept json
+// @Param input body dto.DeleteAccountRequest true "credentials"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/me [delete]
+func (h *Handler) deleteProfile(c *gin.Context) {
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.services.Profile.DeleteAccount(c.Request.Context(), c.GetInt64("userID"), req); err != nil {
+		h.respondProfileError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondProfileError(c *gin.Context, err error) {
+	var retry *errs.RetryAfterError
+	switch {
+	case errors.As(err, &retry):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retry.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many attempts, try again later"})
+	case errors.Is(err, errs.ErrInvalidCredentials), errors.Is(err, errs.ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrInvalidTimezone):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrUsernameTaken),
+		errors.Is(err, errs.ErrEmailTaken),
+		errors.Is(err, errs.ErrTelegramIDTaken),
+		errors.Is(err, errs.ErrLastOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Profile request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID keeps IDs from proxies usable in logs: no spaces, quotes
+// or control characters, and no longer than a few UUIDs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// fallbackSeq numbers the IDs made up when the random source fails.
+var fallbackSeq atomic.Uint64
+
+// requestIDMiddleware takes the X-Request-ID of a proxy in front of the
+// service or makes one up, echoes it in the response and puts it in the
+// request context for the service logs, along with the trace ID.
+func (h *Handler) requestIDMiddleware(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !validRequestID.MatchString(id) {
+		id = newRequestID()
+	}
+
+	c.Set("requestID", id)
+	c.Header(requestIDHeader, id)
+
+	ctx := logger.WithRequestID(c.Request.Context(), id)
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		span.SetAttributes(attribute.String("http.request_id", id))
+		ctx = logger.WithTraceID(ctx, span.SpanContext().TraceID().String())
+	}
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Next()
+}
+
+// newRequestID returns 32 random hex digits. Should the random source fail,
+// the ID is built from the clock and a counter, which is still unique within
+// the process, rather than being all zeros.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatUint(fallbackSeq.Add(1), 36)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary List active sessions
+// @Security ApiKeyAuth
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []dto.SessionResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/sessions [get]
+func (h *Handler) getSessions(c *gin.Context) {
+	sessions, err := h.services.Session.GetUserSessions(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch sessions"})
+		return
+	}
+
+	currentID := c.GetInt64("sessionID")
+	res := make([]dto.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, dto.SessionResponse{
+			ID:          s.ID,
+			Fingerprint: s.Fingerprint,
+			CreatedAt:   s.CreatedAt,
+			ExpiresAt:   s.ExpiresAt,
+			LastUsedAt:  s.LastUsedAt,
+			IP:          s.IP,
+			UserAgent:   s.UserAgent,
+			Current:     s.ID == currentID,
+		})
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary Revoke session
+// @Security ApiKeyAuth
+// @Tags auth
+// @Param id path int true "Session ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/sessions/{id} [delete]
+func (h *Handler) revokeSession(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.services.Session.DeleteUserSession(c.Request.Context(), c.GetInt64("userID"), id)
+	if errors.Is(err, errs.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke session"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Logout from all sessions
+// @Security ApiKeyAuth
+// @Tags auth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/logout-all [post]
+func (h *Handler) logoutAll(c *gin.Context) {
+	if err := h.services.Auth.LogoutAll(c.Request.Context(), c.GetInt64("userID")); err != nil {
+		h.logger.WithError(err).Error("Logout all error")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "logout failed"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,304 @@
+package transport
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+//go:embed templates/status_page.html
+var templates embed.FS
+
+var statusPageTemplate = template.Must(template.New("status_page.html").Funcs(template.FuncMap{
+	"statusText":         statusText,
+	"bannerText":         bannerText,
+	"percent":            formatPercent,
+	"barClass":           barClass,
+	"announcementStatus": announcementStatusText,
+	"closed":             models.AnnouncementStatus.Closes,
+	"join":               func(names []string) string { return strings.Join(names, ", ") },
+}).ParseFS(templates, "templates/status_page.html"))
+
+// @Summary Create a status page
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param input body dto.StatusPageRequest true "page"
+// @Success 201 {object} dto.StatusPageResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /status-pages [post]
+func (h *Handler) createStatusPage(c *gin.Context) {
+	var req dto.StatusPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page := statusPageFromRequest(req)
+	page.OrganizationID = c.GetInt64("organizationID")
+
+	id, err := h.services.StatusPage.CreatePage(c.Request.Context(), page)
+	if err != nil {
+		h.respondStatusPageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.StatusPageResponse{ID: id})
+}
+
+// @Summary Status pages of the active organization
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Produce json
+// @Success 200 {object} []models.StatusPage
+// @Failure 401 {object} map[string]string
+// @Router /status-pages [get]
+func (h *Handler) getStatusPages(c *gin.Context) {
+	pages, err := h.services.StatusPage.GetOrganizationPages(c.Request.Context(), c.GetInt64("organizationID"))
+	if err != nil {
+		h.respondStatusPageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pages)
+}
+
+// @Summary Get a status page
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Produce json
+// @Param id path int true "Status page ID"
+// @Success 200 {object} models.StatusPage
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /status-pages/{id} [get]
+func (h *Handler) getStatusPage(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	page, err := h.services.StatusPage.GetPage(c.Request.Context(), c.GetInt64("organizationID"), id)
+	if err != nil {
+		h.respondStatusPageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Update a status page
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Accept json
+// @Param id path int true "Status page ID"
+// @Param input body dto.StatusPageRequest true "page"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /status-pages/{id} [put]
+func (h *Handler) updateStatusPage(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.StatusPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page := statusPageFromRequest(req)
+	page.ID = id
+	page.OrganizationID = c.GetInt64("organizationID")
+
+	if err := h.services.StatusPage.UpdatePage(c.Request.Context(), page); err != nil {
+		h.respondStatusPageError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Delete a status page
+// @Security ApiKeyAuth
+// @Tags status-pages
+// @Param id path int true "Status page ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /status-pages/{id} [delete]
+func (h *Handler) deleteStatusPage(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.services.StatusPage.DeletePage(c.Request.Context(), c.GetInt64("organizationID"), id); err != nil {
+		h.respondStatusPageError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Public status page
+// @Description Returns HTML to browsers that ask for it and JSON otherwise.
+// @Tags status-pages
+// @Produce json,html
+// @Param slug path string true "Status page slug"
+// @Success 200 {object} models.PublicStatusPage
+// @Failure 404 {object} map[string]string
+// @Router /status/{slug} [get]
+func (h *Handler) getPublicStatusPage(c *gin.Context) {
+	page, err := h.services.StatusPage.GetPublicPage(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.respondStatusPageError(c, err)
+		return
+	}
+
+	// The same URL serves JSON and HTML, caches must keep them apart.
+	c.Header("Cache-Control", "public, max-age=30")
+	c.Header("Vary", "Accept")
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) != gin.MIMEHTML {
+		c.JSON(http.StatusOK, page)
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := statusPageTemplate.Execute(c.Writer, page); err != nil {
+		h.logger.WithError(err).Error("Failed to render status page")
+	}
+}
+
+func statusPageFromRequest(req dto.StatusPageRequest) models.StatusPage {
+	components := make([]models.StatusComponent, len(req.Components))
+	for i, component := range req.Components {
+		monitors := make([]models.StatusPageMonitor, len(component.Monitors))
+		for j, monitor := range component.Monitors {
+			monitors[j] = models.StatusPageMonitor{
+				MonitorID:   monitor.MonitorID,
+				DisplayName: monitor.DisplayName,
+			}
+		}
+
+		components[i] = models.StatusComponent{
+			ID:       component.ID,
+			Name:     component.Name,
+			Monitors: monitors,
+		}
+	}
+
+	return models.StatusPage{
+		Slug:        req.Slug,
+		Title:       req.Title,
+		Description: req.Description,
+		Components:  components,
+	}
+}
+
+func statusText(status models.ServiceStatus) string {
+	switch status {
+	case models.StatusOperational:
+		return "Operational"
+	case models.StatusMaintenance:
+		return "Under maintenance"
+	case models.StatusDegraded:
+		return "Degraded performance"
+	case models.StatusOutage:
+		return "Outage"
+	default:
+		return "No data yet"
+	}
+}
+
+func bannerText(status models.ServiceStatus) string {
+	switch status {
+	case models.StatusOperational:
+		return "All systems operational"
+	case models.StatusMaintenance:
+		return "Scheduled maintenance in progress"
+	case models.StatusDegraded:
+		return "Some systems are degraded"
+	case models.StatusOutage:
+		return "Some systems are down"
+	default:
+		return "No data yet"
+	}
+}
+
+func announcementStatusText(status models.AnnouncementStatus) string {
+	switch status {
+	case models.AnnouncementInvestigating:
+		return "Investigating"
+	case models.AnnouncementIdentified:
+		return "Identified"
+	case models.AnnouncementMonitoring:
+		return "Monitoring"
+	case models.AnnouncementResolved:
+		return "Resolved"
+	case models.AnnouncementScheduled:
+		return "Scheduled"
+	case models.AnnouncementInProgress:
+		return "In progress"
+	case models.AnnouncementCompleted:
+		return "Completed"
+	default:
+		return string(status)
+	}
+}
+
+func formatPercent(value *float64) string {
+	if value == nil {
+		return "no data"
+	}
+	return fmt.Sprintf("%.2f%%", *value)
+}
+
+func barClass(uptime *float64) string {
+	switch {
+	case uptime == nil:
+		return "none"
+	case *uptime >= 99.9:
+		return "operational"
+	case *uptime >= 95:
+		return "partial"
+	default:
+		return "down"
+	}
+}
+
+func (h *Handler) respondStatusPageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidStatusPage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrStatusPageNotFound),
+		errors.Is(err, errs.ErrStatusComponentNotFound),
+		errors.Is(err, errs.ErrMonitorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Status page request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
@@ -0,0 +1,64 @@
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/internal/metrics"
+	"github.com/mixdone/uptime-monitoring/internal/mocks"
+	"github.com/mixdone/uptime-monitoring/internal/models"
+	"github.com/mixdone/uptime-monitoring/internal/services"
+	"github.com/mixdone/uptime-monitoring/internal/tracing"
+	"github.com/mixdone/uptime-monitoring/internal/transport"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+func TestGetPublicStatusPage_VariesByAccept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	statusPages := mocks.NewMockStatusPageService(ctrl)
+	statusPages.EXPECT().GetPublicPage(gomock.Any(), "acme").
+		Return(&models.PublicStatusPage{Title: "Acme", Status: models.StatusOperational}, nil).AnyTimes()
+
+	base := logrus.New()
+	base.SetOutput(io.Discard)
+	tr, err := tracing.New(context.Background(), config.Tracing{})
+	require.NoError(t, err)
+
+	router := transport.NewHandler(&services.Services{StatusPage: statusPages},
+		metrics.New(config.Metrics{}), tr, logger.NewLoggerAdapter(base)).InitRoutes()
+
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{"application/json", "application/json; charset=utf-8"},
+		{"text/html", "text/html; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/status/acme", nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			assert.Equal(t, "public, max-age=30", w.Header().Get("Cache-Control"))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package transport
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// tracingMiddleware starts a server span per request, continuing the trace
+// of a traceparent header when the caller sent one. Probes and scrapes are
+// left out, they would drown the traces worth looking at.
+func (h *Handler) tracingMiddleware() gin.HandlerFunc {
+	untraced := map[string]bool{"/healthz": true, "/readyz": true}
+	if path := h.metrics.Path(); path != "" {
+		untraced[path] = true
+	}
+
+	return otelgin.Middleware(h.tracing.ServiceName(),
+		otelgin.WithGinFilter(func(c *gin.Context) bool {
+			return !untraced[c.FullPath()]
+		}),
+	)
+}
@@ -0,0 +1,123 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mixdone/uptime-monitoring/internal/models/dto"
+	"github.com/mixdone/uptime-monitoring/internal/models/errs"
+)
+
+// @Summary Start two-factor enrollment
+// @Security ApiKeyAuth
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.TwoFactorSetupResponse
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/2fa/setup [post]
+func (h *Handler) twoFactorSetup(c *gin.Context) {
+	res, err := h.services.TwoFactor.Setup(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		h.respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// @Summary Confirm two-factor enrollment
+// @Security ApiKeyAuth
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.TwoFactorVerifyRequest true "code from authenticator"
+// @Success 200 {object} dto.TwoFactorVerifyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/2fa/verify [post]
+func (h *Handler) twoFactorVerify(c *gin.Context) {
+	var req dto.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.services.TwoFactor.Enable(c.Request.Context(), c.GetInt64("userID"), req.Code)
+	if err != nil {
+		h.respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TwoFactorVerifyResponse{RecoveryCodes: codes})
+}
+
+// @Summary Disable two-factor authentication
+// @Security ApiKeyAuth
+// @Tags auth
+// @Accept json
+// @Param input body dto.TwoFactorDisableRequest true "password and code"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/2fa/disable [post]
+func (h *Handler) twoFactorDisable(c *gin.Context) {
+	var req dto.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.services.TwoFactor.Disable(c.Request.Context(), c.GetInt64("userID"), req.Password, req.Code)
+	if err != nil {
+		h.respondTwoFactorError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Complete login with a two-factor code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.TwoFactorLoginRequest true "challenge and code"
+// @Success 200 {object} dto.AuthResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /auth/2fa/login [post]
+func (h *Handler) twoFactorLogin(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.Client = clientInfo(c)
+
+	res, err := h.services.Auth.CompleteTwoFactorLogin(c.Request.Context(), req)
+	if err != nil {
+		h.respondLoginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) respondTwoFactorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidTwoFactorCode), errors.Is(err, errs.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, errs.ErrTwoFactorNotEnabled),
+		errors.Is(err, errs.ErrTwoFactorNotSetUp):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Two-factor request failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
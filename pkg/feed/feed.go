@@ -0,0 +1,184 @@
+// Package feed writes Atom 1.0 and RSS 2.0 documents from one description
+// of a feed.
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+)
+
+// Feed is a list of entries, newest first. IDs must never change once
+// published, feed readers use them to tell new entries from updated ones.
+type Feed struct {
+	ID    string
+	Title string
+	// Link is the page the feed describes, SelfLink the URL of the feed
+	Link     string
+	SelfLink string
+	Updated  time.Time
+	Entries  []Entry
+}
+
+// Entry is a single item. Content is plain text.
+type Entry struct {
+	ID        string
+	Title     string
+	Link      string
+	Content   string
+	Published time.Time
+	Updated   time.Time
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Link      *atomLink   `xml:"link"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Self          *rssSelf  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// Atom renders the feed as Atom 1.0.
+func (f Feed) Atom() ([]byte, error) {
+	doc := atomFeed{
+		ID:      f.ID,
+		Title:   f.Title,
+		Updated: f.updated().UTC().Format(time.RFC3339),
+		Entries: make([]atomEntry, 0, len(f.Entries)),
+	}
+	if f.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Href: f.Link, Rel: "alternate"})
+	}
+	if f.SelfLink != "" {
+		doc.Links = append(doc.Links, atomLink{Href: f.SelfLink, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for _, entry := range f.Entries {
+		atom := atomEntry{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "text", Body: entry.Content},
+		}
+		if entry.Link != "" {
+			atom.Link = &atomLink{Href: entry.Link, Rel: "alternate"}
+		}
+		doc.Entries = append(doc.Entries, atom)
+	}
+
+	return marshal(doc)
+}
+
+// RSS renders the feed as RSS 2.0. RSS has no update time per item, so
+// pubDate carries the time of the last update and the stable guid lets
+// readers recognize the item.
+func (f Feed) RSS() ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Title,
+			LastBuildDate: f.updated().UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(f.Entries)),
+		},
+	}
+	if f.SelfLink != "" {
+		doc.Channel.Self = &rssSelf{Href: f.SelfLink, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	for _, entry := range f.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Description: entry.Content,
+			GUID:        rssGUID{Value: entry.ID},
+			PubDate:     entry.Updated.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return marshal(doc)
+}
+
+// updated falls back to the newest entry when the feed has no time set.
+func (f Feed) updated() time.Time {
+	updated := f.Updated
+	for _, entry := range f.Entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+	return updated
+}
+
+func marshal(doc any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,70 @@
+package feed_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/pkg/feed"
+)
+
+var example = feed.Feed{
+	ID:       "urn:example:feed",
+	Title:    "Incidents",
+	Link:     "https://status.example.com/status/acme",
+	SelfLink: "https://status.example.com/status/acme/feed.atom",
+	Entries: []feed.Entry{{
+		ID:        "urn:example:incident:2",
+		Title:     "API <down>",
+		Content:   "Down & out",
+		Published: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		Updated:   time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+	}},
+}
+
+func TestAtom(t *testing.T) {
+	body, err := example.Atom()
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name
+		Updated string `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Title   string `xml:"title"`
+			Updated string `xml:"updated"`
+			Content string `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+
+	assert.Equal(t, "http://www.w3.org/2005/Atom", doc.XMLName.Space)
+	assert.Equal(t, "2026-10-19T11:00:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "urn:example:incident:2", doc.Entries[0].ID)
+	assert.Equal(t, "API <down>", doc.Entries[0].Title)
+	assert.Equal(t, "Down & out", doc.Entries[0].Content)
+}
+
+func TestRSS(t *testing.T) {
+	body, err := example.RSS()
+	require.NoError(t, err)
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Items   []struct {
+			GUID    string `xml:"guid"`
+			PubDate string `xml:"pubDate"`
+		} `xml:"channel>item"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+
+	assert.Equal(t, "2.0", doc.Version)
+	require.Len(t, doc.Items, 1)
+	assert.Equal(t, "urn:example:incident:2", doc.Items[0].GUID)
+	assert.Equal(t, "Mon, 19 Oct 2026 11:00:00 +0000", doc.Items[0].PubDate)
+	assert.Contains(t, string(body), `isPermaLink="false"`)
+}
@@ -0,0 +1,74 @@
+package logger
+
+import "context"
+
+type contextKey int
+
+const fieldsKey contextKey = iota
+
+// contextFields are what loggers obtained through WithContext attach to
+// every entry. Zero values are left out.
+type contextFields struct {
+	requestID string
+	traceID   string
+	userID    int64
+	monitorID int64
+}
+
+// WithRequestID stores the ID of the request being served.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	f := fromContext(ctx)
+	f.requestID = id
+	return context.WithValue(ctx, fieldsKey, f)
+}
+
+// WithTraceID stores the trace the current work is part of.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	f := fromContext(ctx)
+	f.traceID = id
+	return context.WithValue(ctx, fieldsKey, f)
+}
+
+// WithUserID stores the authenticated user of the current request.
+func WithUserID(ctx context.Context, id int64) context.Context {
+	f := fromContext(ctx)
+	f.userID = id
+	return context.WithValue(ctx, fieldsKey, f)
+}
+
+// WithMonitorID stores the monitor the current work is about, e.g. a check.
+func WithMonitorID(ctx context.Context, id int64) context.Context {
+	f := fromContext(ctx)
+	f.monitorID = id
+	return context.WithValue(ctx, fieldsKey, f)
+}
+
+// RequestID returns the request ID stored in ctx, or "" outside a request.
+func RequestID(ctx context.Context) string {
+	return fromContext(ctx).requestID
+}
+
+// Fields returns the fields stored in ctx, keyed as they appear in logs.
+func Fields(ctx context.Context) map[string]any {
+	f := fromContext(ctx)
+
+	fields := make(map[string]any, 4)
+	if f.requestID != "" {
+		fields["request_id"] = f.requestID
+	}
+	if f.traceID != "" {
+		fields["trace_id"] = f.traceID
+	}
+	if f.userID != 0 {
+		fields["user_id"] = f.userID
+	}
+	if f.monitorID != 0 {
+		fields["monitor_id"] = f.monitorID
+	}
+	return fields
+}
+
+func fromContext(ctx context.Context) contextFields {
+	f, _ := ctx.Value(fieldsKey).(contextFields)
+	return f
+}
@@ -1,5 +1,7 @@
 package logger
 
+import "context"
+
 type Logger interface {
 	Info(args ...any)
 	Infof(format string, args ...any)
@@ -16,4 +18,7 @@ type Logger interface {
 	WithField(key string, value any) Logger
 	WithFields(fields map[string]any) Logger
 	WithError(err error) Logger
+	// WithContext adds the fields stored in ctx by WithRequestID,
+	// WithTraceID, WithUserID and WithMonitorID.
+	WithContext(ctx context.Context) Logger
 }
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/mixdone/uptime-monitoring/internal/config"
@@ -17,7 +18,12 @@ func NewLoggerAdapter(base *logrus.Logger) Logger {
 	}
 }
 
+// InitStructuredLogger builds the backend chosen by log.backend.
 func InitStructuredLogger(cfg *config.Config) (Logger, error) {
+	if cfg.Log.Backend == "slog" {
+		return newSlogLogger(cfg)
+	}
+
 	base, err := newLogrusBase(cfg)
 	if err != nil {
 		return nil, err
@@ -34,13 +40,13 @@ func newLogrusBase(cfg *config.Config) (*logrus.Logger, error) {
 	}
 	log.SetLevel(level)
 
-	if cfg.Log.Format == "json" {
-		log.SetFormatter(&logrus.JSONFormatter{})
-	} else {
-		log.SetFormatter(&logrus.TextFormatter{
+	var formatter logrus.Formatter = &logrus.JSONFormatter{}
+	if cfg.Log.Format != "json" {
+		formatter = &logrus.TextFormatter{
 			FullTimestamp: true,
-		})
+		}
 	}
+	log.SetFormatter(NewRedactingFormatter(formatter, NewRedactor(cfg.Log.RedactFields)))
 
 	return log, nil
 }
@@ -75,3 +81,30 @@ func (l *logrusLogger) WithError(err error) Logger {
 		entry: l.entry.WithError(err),
 	}
 }
+
+func (l *logrusLogger) WithContext(ctx context.Context) Logger {
+	return &logrusLogger{
+		entry: l.entry.WithContext(ctx).WithFields(Fields(ctx)),
+	}
+}
+
+type redactingFormatter struct {
+	next     logrus.Formatter
+	redactor *Redactor
+}
+
+// NewRedactingFormatter masks secrets in a copy of each entry before next
+// formats it, the caller's fields stay as they are.
+func NewRedactingFormatter(next logrus.Formatter, redactor *Redactor) logrus.Formatter {
+	return &redactingFormatter{next: next, redactor: redactor}
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	redacted := *entry
+	redacted.Message = f.redactor.String(entry.Message)
+	redacted.Data = make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		redacted.Data[k] = f.redactor.Field(k, v)
+	}
+	return f.next.Format(&redacted)
+}
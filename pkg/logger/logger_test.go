@@ -0,0 +1,90 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+func requestContext() context.Context {
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+	ctx = logger.WithTraceID(ctx, "4bf92f3577b34da6a3ce929d0e0e4736")
+	ctx = logger.WithUserID(ctx, 7)
+	return logger.WithMonitorID(ctx, 42)
+}
+
+func newLogrus(buf *bytes.Buffer) logger.Logger {
+	base := logrus.New()
+	base.SetOutput(buf)
+	base.SetFormatter(&logrus.JSONFormatter{})
+	return logger.NewLoggerAdapter(base)
+}
+
+func newSlog(buf *bytes.Buffer) logger.Logger {
+	return logger.NewSlogAdapter(slog.New(slog.NewJSONHandler(buf, nil)))
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestFields(t *testing.T) {
+	assert.Empty(t, logger.Fields(context.Background()))
+
+	assert.Equal(t, map[string]any{
+		"request_id": "req-1",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"user_id":    int64(7),
+		"monitor_id": int64(42),
+	}, logger.Fields(requestContext()))
+}
+
+func TestAdapters_WithContext(t *testing.T) {
+	adapters := map[string]func(*bytes.Buffer) logger.Logger{
+		"logrus": newLogrus,
+		"slog":   newSlog,
+	}
+
+	for name, newLogger := range adapters {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := newLogger(&buf)
+
+			log.WithContext(requestContext()).WithField("component", "test").
+				WithError(errors.New("boom")).Infof("checked %d", 3)
+
+			entry := decode(t, &buf)
+			assert.Equal(t, "checked 3", entry["msg"])
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry["trace_id"])
+			assert.EqualValues(t, 7, entry["user_id"])
+			assert.EqualValues(t, 42, entry["monitor_id"])
+			assert.Equal(t, "test", entry["component"])
+			assert.Equal(t, "boom", entry["error"])
+		})
+	}
+}
+
+func TestSlogAdapter_Level(t *testing.T) {
+	var buf bytes.Buffer
+	log := newSlog(&buf)
+
+	log.Debug("hidden")
+	assert.Empty(t, buf.String())
+
+	log.Warn("shown")
+	assert.Equal(t, "WARN", decode(t, &buf)["level"])
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces whatever the Redactor hides.
+const Mask = "[REDACTED]"
+
+// DefaultRedactFields are the field names masked when the config names none.
+var DefaultRedactFields = []string{"password", "token", "authorization", "fingerprint", "secret", "username", "email"}
+
+var (
+	// jwtPattern matches the header.payload.signature shape of a JWT; every
+	// JWT header starts with `{"`, which is eyJ in base64.
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)\b(?:bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+)
+
+// Redactor masks secrets before a log entry is written: the value of any
+// field whose name contains one of the configured names, and JWTs, bearer
+// credentials and name=value pairs of those names anywhere in messages and
+// string values.
+type Redactor struct {
+	fields   []string
+	assigned *regexp.Regexp
+}
+
+func NewRedactor(fields []string) *Redactor {
+	if len(fields) == 0 {
+		fields = DefaultRedactFields
+	}
+
+	lower := make([]string, len(fields))
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		lower[i] = strings.ToLower(field)
+		quoted[i] = regexp.QuoteMeta(lower[i])
+	}
+
+	return &Redactor{
+		fields: lower,
+		// name=value, name: value and "name": "value", the value ending
+		// where a query string or JSON would end it
+		assigned: regexp.MustCompile(`(?i)(\w*(?:` + strings.Join(quoted, "|") +
+			`)\w*)(["']?\s*[=:]\s*["']?)([^\s"',}&]+)`),
+	}
+}
+
+// Field returns value, or Mask when the field name is a sensitive one.
+func (r *Redactor) Field(key string, value any) any {
+	if r.sensitive(key) {
+		return Mask
+	}
+	return r.Value(value)
+}
+
+// Value masks secrets inside strings, errors and maps of fields.
+func (r *Redactor) Value(value any) any {
+	switch v := value.(type) {
+	case string:
+		return r.String(v)
+	case error:
+		return r.String(v.Error())
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, value := range v {
+			redacted[key] = r.Field(key, value)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) String(s string) string {
+	s = bearerPattern.ReplaceAllString(s, Mask)
+	s = jwtPattern.ReplaceAllString(s, Mask)
+	return r.assigned.ReplaceAllString(s, "${1}${2}"+Mask)
+}
+
+// ReplaceAttr masks slog attributes the way Field masks logrus fields. The
+// message reaches it as an attribute too.
+func (r *Redactor) ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.MessageKey {
+		return slog.String(a.Key, r.String(a.Value.String()))
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString, slog.KindAny:
+		return slog.Any(a.Key, r.Field(a.Key, a.Value.Any()))
+	default:
+		if r.sensitive(a.Key) {
+			return slog.String(a.Key, Mask)
+		}
+		return a
+	}
+}
+
+func (r *Redactor) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range r.fields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
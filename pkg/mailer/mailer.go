@@ -0,0 +1,118 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mixdone/uptime-monitoring/internal/config"
+	"github.com/mixdone/uptime-monitoring/pkg/logger"
+)
+
+// New builds the mailer selected by mail.driver.
+func New(cfg *config.Config, log logger.Logger) (Mailer, error) {
+	switch cfg.Mail.Driver {
+	case "log":
+		return NewLogMailer(log), nil
+	case "file":
+		return NewFileMailer(cfg.Mail.Dir)
+	case "smtp":
+		return NewSMTPMailer(cfg.Mail.SMTP.Host, cfg.Mail.SMTP.Port,
+			cfg.Mail.SMTP.Username, cfg.Mail.SMTP.Password, cfg.Mail.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Mail.Driver)
+	}
+}
+
+type logMailer struct {
+	log logger.Logger
+}
+
+// NewLogMailer writes messages to the log instead of sending them. Meant for
+// local development.
+func NewLogMailer(log logger.Logger) Mailer {
+	return &logMailer{log: log.WithField("component", "mailer")}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	m.log.WithFields(map[string]any{
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"body":    msg.Body,
+	}).Info("Mail message")
+	return nil
+}
+
+type fileMailer struct {
+	dir string
+	seq atomic.Int64
+}
+
+// NewFileMailer stores every message as an .eml file in dir.
+func NewFileMailer(dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail dir: %w", err)
+	}
+	return &fileMailer{dir: dir}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s-%d-%s.eml",
+		time.Now().UTC().Format("20060102T150405"), m.seq.Add(1), sanitize(msg.To))
+
+	return os.WriteFile(filepath.Join(m.dir, name), compose("", msg), 0o600)
+}
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpMailer{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, compose(m.from, msg))
+}
+
+func compose(from string, msg Message) []byte {
+	var b strings.Builder
+	if from != "" {
+		fmt.Fprintf(&b, "From: %s\r\n", from)
+	}
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -6,4 +6,8 @@ type MQ interface {
 	Publish(queue string, body []byte) error
 	Consume(ctx context.Context, queue string, handler func([]byte) error) error
 	Close() error
+	// Ping fails once the queues are closed.
+	Ping() error
+	// Depths returns the number of messages waiting in each queue.
+	Depths() map[string]int
 }
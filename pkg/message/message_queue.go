@@ -62,8 +62,9 @@ func (mq *localMQ) Consume(ctx context.Context, queue string, handler func([]byt
 				if !ok {
 					return
 				}
-				err = handler(msg)
-				mq.log.Error(err)
+				if err := handler(msg); err != nil {
+					mq.log.WithField("queue", queue).WithError(err).Error("Failed to handle message")
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -72,6 +73,27 @@ func (mq *localMQ) Consume(ctx context.Context, queue string, handler func([]byt
 	return nil
 }
 
+func (mq *localMQ) Ping() error {
+	mq.mutex.RLock()
+	defer mq.mutex.RUnlock()
+
+	if mq.closed {
+		return errors.New("message queues closed")
+	}
+	return nil
+}
+
+func (mq *localMQ) Depths() map[string]int {
+	mq.mutex.RLock()
+	defer mq.mutex.RUnlock()
+
+	depths := make(map[string]int, len(mq.queues))
+	for name, ch := range mq.queues {
+		depths[name] = len(ch)
+	}
+	return depths
+}
+
 func (mq *localMQ) Close() error {
 	mq.mutex.Lock()
 	defer mq.mutex.Unlock()
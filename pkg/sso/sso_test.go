@@ -0,0 +1,203 @@
+package sso_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mixdone/uptime-monitoring/pkg/sso"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clientID = "uptime"
+
+// mockProvider is a minimal OIDC provider: discovery, JWKS and a token
+// endpoint that checks the PKCE verifier of codes handed out by authorize.
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+type authorization struct {
+	challenge string
+	nonce     string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &mockProvider{t: t, key: key, codes: map[string]authorization{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/keys", p.keys)
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"jwks_uri":                              p.server.URL + "/keys",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *mockProvider) keys(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize plays the user approving the login and returns the code the
+// provider would redirect back with.
+func (p *mockProvider) authorize(authURL string) (code, state string) {
+	u, err := url.Parse(authURL)
+	require.NoError(p.t, err)
+	q := u.Query()
+
+	assert.Equal(p.t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(p.t, clientID, q.Get("client_id"))
+
+	code = "code-" + q.Get("state")[:8]
+
+	p.mu.Lock()
+	p.codes[code] = authorization{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	p.mu.Unlock()
+
+	return code, q.Get("state")
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	auth, ok := p.codes[r.Form.Get("code")]
+	delete(p.codes, r.Form.Get("code"))
+	p.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != auth.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "user-1",
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          auth.nonce,
+		"email":          "alice@example.com",
+		"email_verified": true,
+	})
+	idToken.Header["kid"] = "test"
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func newClient(p *mockProvider) *sso.Client {
+	return sso.NewClient(sso.Config{
+		IssuerURL:   p.server.URL,
+		ClientID:    clientID,
+		RedirectURL: "http://localhost:8080/auth/oidc/callback",
+		Scopes:      []string{"email", "profile"},
+	})
+}
+
+func TestLogin_Success(t *testing.T) {
+	provider := newMockProvider(t)
+	client := newClient(provider)
+	ctx := context.Background()
+
+	req, err := client.Begin(ctx)
+	require.NoError(t, err)
+
+	code, state := provider.authorize(req.URL)
+	assert.Equal(t, req.State, state)
+
+	identity, err := client.Exchange(ctx, code, req.Verifier, req.Nonce)
+	require.NoError(t, err)
+
+	assert.Equal(t, provider.server.URL, identity.Issuer)
+	assert.Equal(t, "user-1", identity.Subject)
+	assert.Equal(t, "alice@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+}
+
+func TestLogin_WrongVerifier(t *testing.T) {
+	provider := newMockProvider(t)
+	client := newClient(provider)
+	ctx := context.Background()
+
+	req, err := client.Begin(ctx)
+	require.NoError(t, err)
+
+	code, _ := provider.authorize(req.URL)
+
+	_, err = client.Exchange(ctx, code, "not-the-verifier-of-this-login-at-all-0000000", req.Nonce)
+	assert.Error(t, err)
+}
+
+func TestLogin_NonceMismatch(t *testing.T) {
+	provider := newMockProvider(t)
+	client := newClient(provider)
+	ctx := context.Background()
+
+	req, err := client.Begin(ctx)
+	require.NoError(t, err)
+
+	code, _ := provider.authorize(req.URL)
+
+	_, err = client.Exchange(ctx, code, req.Verifier, "another-nonce")
+	assert.ErrorContains(t, err, "nonce")
+}
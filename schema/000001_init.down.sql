@@ -1,4 +1,7 @@
-DROP TABLE users;
+DROP TABLE monitor_specs;
+
+DROP TABLE monitors;
 
 DROP TABLE sessions;
 
+DROP TABLE users;
@@ -0,0 +1,16 @@
+DELETE FROM monitors WHERE user_id IS NULL;
+
+ALTER TABLE monitors DROP CONSTRAINT monitors_user_id_fkey;
+ALTER TABLE monitors
+    ADD CONSTRAINT monitors_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+ALTER TABLE monitors ALTER COLUMN user_id SET NOT NULL;
+
+ALTER TABLE monitors DROP COLUMN organization_id;
+
+ALTER TABLE users DROP COLUMN active_organization_id;
+
+DROP TABLE organization_invitations;
+
+DROP TABLE organization_members;
+
+DROP TABLE organizations;
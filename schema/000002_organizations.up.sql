@@ -0,0 +1,58 @@
+CREATE TABLE organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'editor', 'viewer')),
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE TABLE organization_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    email VARCHAR(200),
+    username VARCHAR(200),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'editor', 'viewer')),
+    invited_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    accepted_at TIMESTAMPTZ,
+    CHECK (email IS NOT NULL OR username IS NOT NULL)
+);
+
+CREATE INDEX organization_invitations_email_idx ON organization_invitations (email) WHERE accepted_at IS NULL;
+CREATE INDEX organization_invitations_username_idx ON organization_invitations (username) WHERE accepted_at IS NULL;
+
+ALTER TABLE users
+    ADD COLUMN active_organization_id BIGINT REFERENCES organizations (id) ON DELETE SET NULL;
+
+ALTER TABLE monitors
+    ADD COLUMN organization_id BIGINT REFERENCES organizations (id) ON DELETE CASCADE;
+
+-- every existing user gets a personal organization that takes over their monitors
+DO $$
+DECLARE
+    u RECORD;
+    org_id BIGINT;
+BEGIN
+    FOR u IN SELECT id, username FROM users LOOP
+        INSERT INTO organizations (name) VALUES (u.username) RETURNING id INTO org_id;
+        INSERT INTO organization_members (organization_id, user_id, role) VALUES (org_id, u.id, 'owner');
+        UPDATE users SET active_organization_id = org_id WHERE id = u.id;
+        UPDATE monitors SET organization_id = org_id WHERE user_id = u.id;
+    END LOOP;
+END $$;
+
+ALTER TABLE monitors ALTER COLUMN organization_id SET NOT NULL;
+
+-- user_id now only records who created the monitor
+ALTER TABLE monitors ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE monitors DROP CONSTRAINT monitors_user_id_fkey;
+ALTER TABLE monitors
+    ADD CONSTRAINT monitors_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX monitors_organization_id_idx ON monitors (organization_id);
@@ -0,0 +1,8 @@
+DROP INDEX sessions_user_id_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN user_agent,
+    DROP COLUMN ip,
+    DROP COLUMN last_used_at,
+    DROP COLUMN created_at,
+    ALTER COLUMN expires_at TYPE DATE;
@@ -0,0 +1,8 @@
+ALTER TABLE sessions
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ,
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN last_used_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN ip VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
@@ -0,0 +1,6 @@
+-- raw tokens can't be recovered from hashes, so every session is dropped
+DELETE FROM sessions;
+
+ALTER TABLE sessions ADD COLUMN refresh_token TEXT NOT NULL DEFAULT '';
+
+DROP TABLE refresh_tokens;
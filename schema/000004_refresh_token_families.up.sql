@@ -0,0 +1,18 @@
+-- a session is one refresh token family: every rotation adds a row here and
+-- marks the previous token as rotated
+CREATE TABLE refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    session_id BIGINT NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    rotated_at TIMESTAMPTZ
+);
+
+CREATE INDEX refresh_tokens_session_id_idx ON refresh_tokens (session_id);
+
+INSERT INTO refresh_tokens (session_id, token_hash)
+SELECT id, encode(sha256(convert_to(refresh_token, 'UTF8')), 'hex')
+FROM sessions
+WHERE refresh_token <> '';
+
+ALTER TABLE sessions DROP COLUMN refresh_token;
@@ -0,0 +1,10 @@
+CREATE TABLE login_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(200) NOT NULL,
+    ip VARCHAR(64) NOT NULL,
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX login_attempts_username_idx ON login_attempts (username, created_at);
+CREATE INDEX login_attempts_ip_idx ON login_attempts (ip, created_at);
@@ -0,0 +1,6 @@
+-- 0 was stored for users without Telegram and breaks the UNIQUE constraint
+UPDATE users SET telegram_id = NULL WHERE telegram_id = 0;
+
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN notification_preferences JSONB NOT NULL DEFAULT '{"email": true, "telegram": true}';
@@ -0,0 +1,30 @@
+-- actor and organization are plain columns, not foreign keys, so entries
+-- outlive the users and organizations they mention
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    actor_id BIGINT,
+    organization_id BIGINT,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id BIGINT,
+    changes JSONB,
+    details JSONB,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX audit_log_organization_idx ON audit_log (organization_id, id DESC);
+CREATE INDEX audit_log_actor_idx ON audit_log (actor_id, id DESC);
+CREATE INDEX audit_log_target_idx ON audit_log (target_type, target_id, id DESC);
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+
+CREATE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update
+    BEFORE UPDATE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
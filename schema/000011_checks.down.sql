@@ -0,0 +1,2 @@
+DROP TABLE incidents;
+DROP TABLE check_results;
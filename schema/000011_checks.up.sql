@@ -0,0 +1,25 @@
+CREATE TABLE check_results (
+    id BIGSERIAL PRIMARY KEY,
+    monitor_id BIGINT NOT NULL REFERENCES monitors (id) ON DELETE CASCADE,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    status VARCHAR(16) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    response_time_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    maintenance BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX check_results_monitor_idx ON check_results (monitor_id, checked_at DESC);
+
+CREATE TABLE incidents (
+    id BIGSERIAL PRIMARY KEY,
+    monitor_id BIGINT NOT NULL REFERENCES monitors (id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    cause TEXT NOT NULL DEFAULT '',
+    maintenance BOOLEAN NOT NULL DEFAULT false
+);
+
+-- a monitor has at most one open incident
+CREATE UNIQUE INDEX incidents_open_idx ON incidents (monitor_id) WHERE resolved_at IS NULL;
+CREATE INDEX incidents_monitor_idx ON incidents (monitor_id, started_at DESC);
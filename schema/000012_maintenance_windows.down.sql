@@ -0,0 +1,2 @@
+DROP TABLE maintenance_window_monitors;
+DROP TABLE maintenance_windows;
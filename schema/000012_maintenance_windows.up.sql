@@ -0,0 +1,26 @@
+CREATE TABLE maintenance_windows (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    -- empty for a one-off window, otherwise a cron expression or an RRULE
+    recurrence TEXT NOT NULL DEFAULT '',
+    duration_minutes INT NOT NULL DEFAULT 0,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (recurrence <> '' OR ends_at IS NOT NULL),
+    CHECK (recurrence = '' OR duration_minutes > 0)
+);
+
+CREATE INDEX maintenance_windows_organization_idx ON maintenance_windows (organization_id);
+
+CREATE TABLE maintenance_window_monitors (
+    window_id BIGINT NOT NULL REFERENCES maintenance_windows (id) ON DELETE CASCADE,
+    monitor_id BIGINT NOT NULL REFERENCES monitors (id) ON DELETE CASCADE,
+    PRIMARY KEY (window_id, monitor_id)
+);
+
+CREATE INDEX maintenance_window_monitors_monitor_idx ON maintenance_window_monitors (monitor_id);
@@ -0,0 +1 @@
+DROP TABLE monitor_dependencies;
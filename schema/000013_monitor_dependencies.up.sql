@@ -0,0 +1,8 @@
+CREATE TABLE monitor_dependencies (
+    monitor_id BIGINT NOT NULL REFERENCES monitors (id) ON DELETE CASCADE,
+    parent_id BIGINT NOT NULL REFERENCES monitors (id) ON DELETE CASCADE,
+    PRIMARY KEY (monitor_id, parent_id),
+    CHECK (monitor_id <> parent_id)
+);
+
+CREATE INDEX monitor_dependencies_parent_idx ON monitor_dependencies (parent_id);
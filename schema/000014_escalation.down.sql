@@ -0,0 +1,12 @@
+ALTER TABLE incidents
+    DROP COLUMN next_escalation_at,
+    DROP COLUMN escalation_started_at,
+    DROP COLUMN escalation_level,
+    DROP COLUMN escalation_policy_id,
+    DROP COLUMN acknowledged_by,
+    DROP COLUMN acknowledged_at;
+
+ALTER TABLE monitors DROP COLUMN escalation_policy_id;
+
+DROP TABLE escalation_policies;
+DROP TABLE notification_channels;
@@ -0,0 +1,38 @@
+CREATE TABLE notification_channels (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('telegram', 'email', 'webhook')),
+    config JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX notification_channels_organization_idx ON notification_channels (organization_id);
+
+-- levels is an ordered array of {"delay_minutes": n, "channel_ids": [...]}
+CREATE TABLE escalation_policies (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    levels JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX escalation_policies_organization_idx ON escalation_policies (organization_id);
+
+ALTER TABLE monitors
+    ADD COLUMN escalation_policy_id BIGINT REFERENCES escalation_policies (id) ON DELETE SET NULL;
+
+-- escalation_level is the next level to notify, next_escalation_at is when;
+-- keeping both here lets escalation resume after a restart. Level delays
+-- count from escalation_started_at, which is later than started_at for
+-- incidents that began during maintenance.
+ALTER TABLE incidents
+    ADD COLUMN acknowledged_at TIMESTAMPTZ,
+    ADD COLUMN acknowledged_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN escalation_policy_id BIGINT REFERENCES escalation_policies (id) ON DELETE SET NULL,
+    ADD COLUMN escalation_level INT NOT NULL DEFAULT 0,
+    ADD COLUMN escalation_started_at TIMESTAMPTZ,
+    ADD COLUMN next_escalation_at TIMESTAMPTZ;
+
+CREATE INDEX incidents_escalation_idx ON incidents (next_escalation_at) WHERE next_escalation_at IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE incidents DROP COLUMN flapping;
+
+ALTER TABLE monitors
+    DROP COLUMN flap_window_minutes,
+    DROP COLUMN flap_threshold;
@@ -0,0 +1,7 @@
+-- a monitor flaps when its status changes flap_threshold times within
+-- flap_window_minutes; zero turns detection off
+ALTER TABLE monitors
+    ADD COLUMN flap_threshold INT NOT NULL DEFAULT 0 CHECK (flap_threshold >= 0),
+    ADD COLUMN flap_window_minutes INT NOT NULL DEFAULT 10 CHECK (flap_window_minutes > 0);
+
+ALTER TABLE incidents ADD COLUMN flapping BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+DROP TABLE status_page_monitors;
+DROP TABLE status_page_components;
+DROP TABLE status_pages;
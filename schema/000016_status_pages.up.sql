@@ -0,0 +1,30 @@
+CREATE TABLE status_pages (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX status_pages_organization_idx ON status_pages (organization_id);
+
+CREATE TABLE status_page_components (
+    id BIGSERIAL PRIMARY KEY,
+    status_page_id BIGINT NOT NULL REFERENCES status_pages (id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    position INT NOT NULL
+);
+
+CREATE INDEX status_page_components_page_idx ON status_page_components (status_page_id, position);
+
+CREATE TABLE status_page_monitors (
+    component_id BIGINT NOT NULL REFERENCES status_page_components (id) ON DELETE CASCADE,
+    monitor_id BIGINT NOT NULL REFERENCES monitors (id) ON DELETE CASCADE,
+    -- the only name of the monitor shown on the public page
+    display_name VARCHAR(200) NOT NULL,
+    position INT NOT NULL,
+    PRIMARY KEY (component_id, monitor_id)
+);
+
+CREATE INDEX status_page_monitors_monitor_idx ON status_page_monitors (monitor_id);
@@ -0,0 +1,3 @@
+DROP TABLE announcement_updates;
+DROP TABLE announcement_components;
+DROP TABLE announcements;
@@ -0,0 +1,35 @@
+CREATE TABLE announcements (
+    id BIGSERIAL PRIMARY KEY,
+    status_page_id BIGINT NOT NULL REFERENCES status_pages (id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('incident', 'maintenance')),
+    title VARCHAR(200) NOT NULL,
+    -- status of the latest update
+    status VARCHAR(16) NOT NULL,
+    impact VARCHAR(16) NOT NULL,
+    scheduled_for TIMESTAMPTZ,
+    scheduled_until TIMESTAMPTZ,
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    -- set by the update that resolved or completed the announcement
+    closed_at TIMESTAMPTZ,
+    CHECK (kind <> 'maintenance' OR scheduled_until > scheduled_for)
+);
+
+CREATE INDEX announcements_page_idx ON announcements (status_page_id, created_at DESC);
+
+CREATE TABLE announcement_components (
+    announcement_id BIGINT NOT NULL REFERENCES announcements (id) ON DELETE CASCADE,
+    component_id BIGINT NOT NULL REFERENCES status_page_components (id) ON DELETE CASCADE,
+    PRIMARY KEY (announcement_id, component_id)
+);
+
+CREATE TABLE announcement_updates (
+    id BIGSERIAL PRIMARY KEY,
+    announcement_id BIGINT NOT NULL REFERENCES announcements (id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    message TEXT NOT NULL,
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX announcement_updates_announcement_idx ON announcement_updates (announcement_id, created_at);
@@ -0,0 +1,7 @@
+-- a monitor's badges are public once it has a token; the token keeps
+-- monitor IDs from being enumerated
+CREATE TABLE monitor_badges (
+    monitor_id BIGINT PRIMARY KEY REFERENCES monitors (id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
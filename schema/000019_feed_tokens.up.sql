@@ -0,0 +1,6 @@
+-- one incident feed token per user; only its SHA-256 hash is stored
+CREATE TABLE feed_tokens (
+    user_id BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
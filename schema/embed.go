@@ -0,0 +1,8 @@
+// Package schema embeds the SQL migrations, so the binary can apply them
+// without the files next to it.
+package schema
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS